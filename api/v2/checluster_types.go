@@ -16,6 +16,7 @@ package v2
 // to regenerate `api/v2/zz_generatedxxx` code after modifying this file.
 
 import (
+	"regexp"
 	"strconv"
 	"strings"
 
@@ -36,10 +37,13 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 var logger = ctrl.Log.WithName("checluster")
 
+var invalidNamespaceChars = regexp.MustCompile("[^a-z0-9-]")
+
 // +k8s:openapi-gen=true
 // Desired configuration of Eclipse Che installation.
 type CheClusterSpec struct {
@@ -71,6 +75,38 @@ type CheClusterSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=5
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Container registry"
 	ContainerRegistry CheClusterContainerRegistry `json:"containerRegistry"`
+	// Configuration of the upgrades to a new Che version.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,order=6
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Upgrade"
+	Upgrade *CheClusterUpgrade `json:"upgrade,omitempty"`
+}
+
+// CheClusterUpgrade configures the upgrades to a new Che version.
+// Before upgrading, the operator checks the DevWorkspace Operator version, the served CRD versions
+// and the free resource quota in the Che namespace. The previous version is kept deployed while the checks fail.
+// Then components are rolled out one by one, and rolled back to the previous version if any of them fails to become available.
+// The pod templates of the components before the upgrade are kept in the `che-upgrade-previous-pod-templates` ConfigMap
+// and restored as a whole, the changes to the components configuration are applied once the upgrade is retried.
+// To retry a rolled back upgrade, annotate the CheCluster with `che.eclipse.org/retry-upgrade`.
+type CheClusterUpgrade struct {
+	// Skips the pre-flight checks.
+	// +optional
+	SkipPreflightChecks bool `json:"skipPreflightChecks,omitempty"`
+	// Disables the automatic rollback when the upgrade fails.
+	// +optional
+	DisableRollback bool `json:"disableRollback,omitempty"`
+	// The maximum time in seconds for all components to be rolled out.
+	// The upgrade fails after this time, or earlier if a Deployment exceeds its progress deadline.
+	// +optional
+	// +kubebuilder:default:=1800
+	// +kubebuilder:validation:Minimum=60
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+	// Previews the migrations run by a new operator version instead of running them.
+	// The pending migrations are reported in `status.migrations.pending`,
+	// and the reconciliation is paused until this field is disabled.
+	// +optional
+	MigrationsDryRun bool `json:"migrationsDryRun,omitempty"`
 }
 
 // Development environment configuration.
@@ -109,6 +145,16 @@ type CheClusterDevEnvironments struct {
 	// The pod tolerations of the workspace pods limit where the workspace pods can run.
 	// +optional
 	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// ResourceQuota applied to every user namespace to limit the total amount of resources,
+	// such as CPU, memory, number of PVCs and storage, consumed by the workspaces of the user.
+	// The Operator reverts manual changes and deletes the ResourceQuota when the field is removed.
+	// +optional
+	ResourceQuota *corev1.ResourceQuotaSpec `json:"resourceQuota,omitempty"`
+	// LimitRange applied to every user namespace to constrain the resources of the workspace containers
+	// and set their defaults.
+	// The Operator reverts manual changes and deletes the LimitRange when the field is removed.
+	// +optional
+	LimitRange *corev1.LimitRangeSpec `json:"limitRange,omitempty"`
 	// User's default namespace.
 	// +optional
 	// +kubebuilder:default:={template: <username>-che, autoProvision: true}
@@ -183,6 +229,14 @@ type CheClusterDevEnvironments struct {
 	// Container run configuration.
 	// +optional
 	ContainerRunConfiguration *ContainerRunConfiguration `json:"containerRunConfiguration,omitempty"`
+	// Pod Security Admission levels enforced in the user namespaces.
+	// For Kubernetes clusters only, on OpenShift the container capabilities are granted with SecurityContextConstraints.
+	// When set, the container build and run capabilities can be enabled on Kubernetes, since the user namespaces
+	// are then labeled with the level admitting the workspace pods security context required by the capabilities.
+	// The level follows the capabilities of the development environment profile of the user namespace,
+	// so that only the users granted the capabilities get the elevated levels.
+	// +optional
+	PodSecurityAdmission *PodSecurityAdmission `json:"podSecurityAdmission,omitempty"`
 	// ServiceAccount to use by the DevWorkspace operator when starting the workspaces.
 	// +optional
 	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
@@ -263,6 +317,21 @@ type CheClusterDevEnvironments struct {
 	// CLI Activity Tracker configuration for preventing workspace idling during active CLI processes.
 	// +optional
 	CliActivityTracker *CliActivityTrackerConfig `json:"cliActivityTracker,omitempty"`
+	// Named development environment profiles that override the global settings for a subset of users.
+	// A profile is applied to a user namespace if the namespace matches the profile `namespaceSelector`
+	// or if the namespace owner is a member of one of the profile `groups`.
+	// When several profiles match, the first one in the list is applied.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Profiles []DevEnvironmentProfile `json:"profiles,omitempty"`
+	// Scheduled shutdown of workspaces.
+	// The Operator stops running workspaces during the configured time windows.
+	// +optional
+	ScheduledShutdown *WorkspaceScheduledShutdown `json:"scheduledShutdown,omitempty"`
+	// Retention policy of inactive workspaces and user namespaces.
+	// +optional
+	RetentionPolicy *WorkspaceRetentionPolicy `json:"retentionPolicy,omitempty"`
 }
 
 // Che components configuration.
@@ -395,6 +464,30 @@ type CheClusterContainerRegistry struct {
 	// This is particularly useful for installing Eclipse Che in a restricted environment.
 	// +optional
 	Organization string `json:"organization,omitempty"`
+	// Image mirrors applied to all the images managed by the Operator: the components images,
+	// the editor definitions, the default components, the pre-pulled images and the DevWorkspace Operator defaults.
+	// An image matching a mirror source is pulled from the mirror instead, the longest matching source wins.
+	// Mirrors take precedence over the `hostname` and `organization` fields.
+	// +optional
+	Mirrors []ImageMirror `json:"mirrors,omitempty"`
+	// Applies the mirrors defined by the OpenShift `ImageDigestMirrorSet` objects of the cluster as well.
+	// As for `ImageDigestMirrorSet`, these mirrors apply only to the images referenced by digest,
+	// and the first mirror of each set is used.
+	// +optional
+	UseImageDigestMirrorSets bool `json:"useImageDigestMirrorSets,omitempty"`
+}
+
+// ImageMirror maps a source repository to its mirror.
+type ImageMirror struct {
+	// Source registry or repository, for example `quay.io/eclipse`.
+	// Matches the images in the given registry or repository, and in the nested ones.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength:=1
+	Source string `json:"source"`
+	// Mirror registry or repository replacing the source, for example `mirror.example.com/eclipse`.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength:=1
+	Mirror string `json:"mirror"`
 }
 
 // +k8s:openapi-gen=true
@@ -510,6 +603,82 @@ type OpenVSXRegistry struct {
 	// OpenVSX registry database configuration.
 	// +optional
 	Database *OpenVSXDatabase `json:"database,omitempty"`
+	// Extensions mirrored from an upstream registry into the internal OpenVSX registry.
+	// +optional
+	ExtensionsMirror *OpenVSXExtensionsMirror `json:"extensionsMirror,omitempty"`
+	// Backup of the internal OpenVSX registry database and extensions storage.
+	// +optional
+	Backup *OpenVSXBackup `json:"backup,omitempty"`
+}
+
+// Backup and restore configuration of the internal OpenVSX registry.
+// A backup consists of the database dump and the archive of the extensions storage,
+// and is named after the Job that created it.
+// +k8s:openapi-gen=true
+type OpenVSXBackup struct {
+	// The schedule of the backups in the Cron format.
+	// +optional
+	// +kubebuilder:default:="0 2 * * *"
+	Schedule string `json:"schedule,omitempty"`
+	// The number of backups kept in the PVC, older ones are removed.
+	// Ignored when backups are uploaded to an S3-compatible storage, use the bucket lifecycle rules instead.
+	// +optional
+	// +kubebuilder:default:=7
+	// +kubebuilder:validation:Minimum=1
+	MaxBackups int32 `json:"maxBackups,omitempty"`
+	// PVC settings for storing backups, used when no S3-compatible storage is configured.
+	// The PVC is kept when backups or the internal OpenVSX registry are disabled, and is deleted along with
+	// the CheCluster. Configure an S3-compatible storage to keep backups outside the cluster.
+	// +optional
+	Storage *PVC `json:"pvc,omitempty"`
+	// S3-compatible storage, such as MinIO, to upload backups to.
+	// +optional
+	S3 *OpenVSXBackupS3 `json:"s3,omitempty"`
+	// The name of the backup to restore, for instance `openvsx-backup-29345678`.
+	// The OpenVSX registry server is scaled down while the backup is being restored.
+	// A backup is restored once, change the name to restore another backup.
+	// +optional
+	RestoreFrom string `json:"restoreFrom,omitempty"`
+}
+
+// S3-compatible storage to upload OpenVSX registry backups to.
+// +k8s:openapi-gen=true
+type OpenVSXBackupS3 struct {
+	// The S3 endpoint URL, for instance `http://minio.minio.svc:9000`. Path-style requests are used.
+	Endpoint string `json:"endpoint"`
+	// The bucket to upload backups to.
+	Bucket string `json:"bucket"`
+	// The bucket region.
+	// +optional
+	// +kubebuilder:default:=us-east-1
+	Region string `json:"region,omitempty"`
+	// The name of the Kubernetes Secret that contains the S3 credentials.
+	// The Secret must contain the `access-key-id` and `secret-access-key` keys.
+	// The secret must have the `app.kubernetes.io/part-of=che.eclipse.org` label.
+	CredentialsSecretName string `json:"credentialsSecretName"`
+}
+
+// Configuration of the extensions mirrored from an upstream registry into the internal OpenVSX registry.
+// +k8s:openapi-gen=true
+type OpenVSXExtensionsMirror struct {
+	// URL of the upstream registry the extensions are resolved and downloaded from.
+	// +optional
+	// +kubebuilder:default:="https://open-vsx.org"
+	UpstreamURL string `json:"upstreamURL,omitempty"`
+	// Type of the upstream registry:
+	//   - `OpenVSX`: an OpenVSX registry, versions are resolved with its API.
+	//   - `FileServer`: a file server, for instance in air-gapped environments, hosting extensions
+	//     as `<publisher>/<name>/<version>/<publisher>.<name>-<version>.vsix`. Extensions must have an exact version.
+	// +optional
+	// +kubebuilder:default:=OpenVSX
+	// +kubebuilder:validation:Enum=OpenVSX;FileServer
+	UpstreamType string `json:"upstreamType,omitempty"`
+	// Extensions to mirror in the `<publisher>.<name>@<version>` format.
+	// The version is an exact version, `latest` or a range such as `>=1.2.0 <2.0.0`.
+	// The latest version is mirrored when the version is omitted.
+	// +optional
+	// +kubebuilder:validation:items:Pattern=`^[a-zA-Z0-9][a-zA-Z0-9_-]*\.[a-zA-Z0-9][a-zA-Z0-9_-]*(@.+)?$`
+	Extensions []string `json:"extensions,omitempty"`
 }
 
 // OpenVSX registry server configuration.
@@ -532,6 +701,22 @@ type OpenVSXDatabase struct {
 	// PVC settings for PostgreSQL data.
 	// +optional
 	Storage *PVC `json:"pvc,omitempty"`
+	// The name of the Kubernetes Secret that contains the connection settings of an external PostgreSQL database.
+	// When set, the operator does not deploy the database and the OpenVSX registry uses the external one instead.
+	// The Secret must contain the following keys:
+	//   - `host`		: PostgreSQL host.
+	//   - `port`		: PostgreSQL port, optional, `5432` by default.
+	//   - `database`	: PostgreSQL database name.
+	//   - `user`		: PostgreSQL username.
+	//   - `password`	: PostgreSQL password.
+	//   - `sslmode`	: PostgreSQL SSL mode, optional, for instance `require` or `verify-full`.
+	//   - `sslrootcert`	: PEM encoded CA certificate to verify the PostgreSQL server, optional,
+	//                    required for the `verify-ca` and `verify-full` SSL modes.
+	// The `database-*` keys of the credentials Secret are not used in this case,
+	// and the PVC of the in-cluster database, if any, is kept.
+	// The secret must have the `app.kubernetes.io/part-of=che.eclipse.org` label.
+	// +optional
+	ExternalConnectionSecretName *string `json:"externalConnectionSecretName,omitempty"`
 }
 
 // Configuration settings related to the devfile registry used by the Che installation.
@@ -566,6 +751,7 @@ type ImagePuller struct {
 	// regardless of whether a spec is provided.
 	// If you leave the `spec.images` field empty, a set of recommended workspace-related images is automatically detected and
 	// pre-pulled after installation.
+	// If the Kubernetes Image Puller Operator is not installed, the images are pre-pulled by a DaemonSet managed by the Operator itself.
 	// Note that while this Operator and its behavior is community-supported, its payload may be commercially-supported
 	// for pulling commercially-supported images.
 	// +optional
@@ -573,6 +759,57 @@ type ImagePuller struct {
 	// A Kubernetes Image Puller spec to configure the image puller in the CheCluster.
 	// +optional
 	Spec imagepullerv1alpha1.KubernetesImagePullerSpec `json:"spec"`
+	// Pre-pull the images used by the workspaces, in addition to the automatically detected ones.
+	// The images are ranked by the number of workspaces using them, and the images nobody uses anymore are dropped.
+	// Applies only if the `spec.images` field is empty.
+	// +optional
+	WorkspaceImages *ImagePullerWorkspaceImages `json:"workspaceImages,omitempty"`
+	// Configuration of the image pre-pulling DaemonSet managed by the Operator itself.
+	// The DaemonSet is used instead of the Kubernetes Image Puller Operator when that one is not installed on the cluster.
+	// +optional
+	BuiltIn *BuiltInImagePuller `json:"builtIn,omitempty"`
+}
+
+// BuiltInImagePuller configures the image pre-pulling DaemonSet managed by the Operator.
+type BuiltInImagePuller struct {
+	// Node selector limiting the nodes the images are pre-pulled on.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Node tolerations of the pre-pulling pods.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// Maximum number or percentage of nodes the images are pulled on at the same time
+	// when the list of images changes. Defaults to `1`.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+	// Image of the pre-pulling pods providing the `sleep` binary.
+	// Defaults to the Kubernetes Image Puller image.
+	// +optional
+	Image string `json:"image,omitempty"`
+}
+
+// ImagePullerWorkspaceImages defines which images used by the workspaces are pre-pulled.
+type ImagePullerWorkspaceImages struct {
+	// Maximum number of the most used workspace images to pre-pull.
+	// +optional
+	// +kubebuilder:default:=20
+	// +kubebuilder:validation:Minimum:=1
+	MaxImages *int32 `json:"maxImages,omitempty"`
+	// Maximum total size of the pre-pulled workspace images, for example `20Gi`.
+	// The size of an image is known once it has been pulled on at least one node,
+	// `unknownImageSize` is counted for the other images.
+	// +optional
+	MaxTotalSize *resource.Quantity `json:"maxTotalSize,omitempty"`
+	// Size counted against `maxTotalSize` for an image that has not been pulled on any node yet.
+	// +optional
+	// +kubebuilder:default:="1Gi"
+	UnknownImageSize *resource.Quantity `json:"unknownImageSize,omitempty"`
+	// Stopped workspaces started within the given number of days are taken into account,
+	// in addition to the running ones. Set to `0` to take into account the running workspaces only.
+	// +optional
+	// +kubebuilder:default:=7
+	// +kubebuilder:validation:Minimum:=0
+	DaysSinceLastStart *int32 `json:"daysSinceLastStart,omitempty"`
 }
 
 // Settings for installation and configuration of the DevWorkspace Operator
@@ -602,6 +839,35 @@ type DefaultNamespace struct {
 	// is used instead to trigger cluster-specific Project Templates.
 	// +optional
 	CreateKubernetesNamespaces *bool `json:"createKubernetesNamespaces,omitempty"`
+	// Users and groups whose namespaces are created by the Operator in advance, before they start their first workspace.
+	// It is intended for the setups where `autoProvision` is disabled.
+	// The namespace names are resolved from the `template` and cannot contain the `<userid>` placeholder.
+	// Namespaces removed from the list are not deleted.
+	// +optional
+	Provisioning *NamespaceProvisioning `json:"provisioning,omitempty"`
+	// Labels which the Operator sets on every user namespace, for example a cost center or
+	// a Pod Security Admission level. Values can contain the `<username>` placeholder.
+	// A label whose value is not valid for a given user is not set and a warning event is reported on the namespace.
+	// Labels changed in the namespace are reverted, labels removed from the list are removed from the namespaces.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations which the Operator sets on every user namespace.
+	// Values can contain the `<username>` placeholder.
+	// Annotations changed in the namespace are reverted, annotations removed from the list are removed from the namespaces.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type NamespaceProvisioning struct {
+	// Names of the users whose namespaces are created.
+	// +optional
+	// +listType=set
+	Users []string `json:"users,omitempty"`
+	// Groups of users whose namespaces are created for each member.
+	// For OpenShift clusters with OpenShift OAuth only.
+	// +optional
+	// +listType=set
+	Groups []string `json:"groups,omitempty"`
 }
 
 type DashboardHeaderMessage struct {
@@ -635,6 +901,32 @@ type UserConfiguration struct {
 	// The role must have `app.kubernetes.io/part-of=che.eclipse.org` label.
 	// +optional
 	ClusterRoles []string `json:"clusterRoles,omitempty"`
+	// Additional roles bound in the user namespace to the members of Identity Provider groups.
+	// The groups of a user are the ones the cluster API server authenticates the user with,
+	// for instance from the groups claim of the OIDC token, see `networking.auth.claimMappings.groups`.
+	// They are recorded in the `che.eclipse.org/groups` annotation of the user namespace by an admission webhook
+	// each time the user creates or updates a DevWorkspace.
+	// With OpenShift OAuth, the members of the OpenShift `Group` resources are granted the roles as well.
+	// The roles are revoked when the user is no longer a member of the group.
+	// The operator must be allowed to grant the permissions of the roles.
+	// +optional
+	// +listType=map
+	// +listMapKey=group
+	GroupRoles []GroupRoles `json:"groupRoles,omitempty"`
+}
+
+// GroupRoles are the roles granted to the members of a group.
+type GroupRoles struct {
+	// The group name, including the groups prefix if any.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Group string `json:"group"`
+	// ClusterRoles bound in the user namespace.
+	// +optional
+	ClusterRoles []string `json:"clusterRoles,omitempty"`
+	// Roles from the user namespace bound in the user namespace.
+	// +optional
+	Roles []string `json:"roles,omitempty"`
 }
 
 // Configuration settings related to the workspaces persistent storage.
@@ -654,6 +946,28 @@ type WorkspaceStorage struct {
 	// +kubebuilder:default:="per-user"
 	// +kubebuilder:validation:Enum=common;per-user;per-workspace;ephemeral
 	PvcStrategy string `json:"pvcStrategy,omitempty"`
+	// Periodic backup of the user workspace PVCs with VolumeSnapshots.
+	// A snapshot is restored by annotating the user namespace with
+	// `che.eclipse.org/restore-from-snapshot: <snapshot name>` while all the user workspaces are stopped.
+	// +optional
+	Backup *WorkspaceStorageBackup `json:"backup,omitempty"`
+}
+
+// WorkspaceStorageBackup defines periodic VolumeSnapshots of the user workspace PVCs.
+type WorkspaceStorageBackup struct {
+	// Name of the VolumeSnapshotClass used to snapshot the PVCs.
+	// +kubebuilder:validation:Required
+	VolumeSnapshotClassName string `json:"volumeSnapshotClassName"`
+	// Backup schedule in the cron format: `minute hour day-of-month month day-of-week`.
+	// The schedule is evaluated in UTC.
+	// +optional
+	// +kubebuilder:default:="0 1 * * *"
+	Schedule string `json:"schedule,omitempty"`
+	// Number of snapshots kept for each PVC. The oldest snapshots are deleted first.
+	// +optional
+	// +kubebuilder:default:=7
+	// +kubebuilder:validation:Minimum:=1
+	MaxSnapshots *int32 `json:"maxSnapshots,omitempty"`
 }
 
 type PersistentHomeConfig struct {
@@ -744,6 +1058,131 @@ type CliActivityTrackerConfig struct {
 	Verbose *bool `json:"verbose,omitempty"`
 }
 
+// DevEnvironmentProfile defines development environment settings applied to the matching user namespaces.
+type DevEnvironmentProfile struct {
+	// Profile name.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+	// Label selector of the user namespaces the profile is applied to.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// Groups whose members get the profile applied to their namespaces.
+	// Groups can be set on OpenShift with built-in OAuth only, elsewhere use the namespace selector.
+	// +optional
+	Groups []string `json:"groups,omitempty"`
+	// The node selector limits the nodes that can run the workspace pods.
+	// When set, overrides `devEnvironments.nodeSelector`.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// The pod tolerations of the workspace pods limit where the workspace pods can run.
+	// When set, overrides `devEnvironments.tolerations`.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// ResourceQuota applied to the user namespaces matching the profile.
+	// When set, overrides `devEnvironments.resourceQuota`.
+	// +optional
+	ResourceQuota *corev1.ResourceQuotaSpec `json:"resourceQuota,omitempty"`
+	// LimitRange applied to the user namespaces matching the profile.
+	// When set, overrides `devEnvironments.limitRange`.
+	// +optional
+	LimitRange *corev1.LimitRangeSpec `json:"limitRange,omitempty"`
+	// Resource requirements of the workspace containers that do not define limits or requests.
+	// When set, overrides `devEnvironments.defaultContainerResources`.
+	// The workspaces of the users matching the profile use a dedicated DevWorkspaceOperatorConfig,
+	// the running workspaces get the change once they are stopped.
+	// +optional
+	DefaultContainerResources *corev1.ResourceRequirements `json:"defaultContainerResources,omitempty"`
+	// Maximum resource requirements enforced for the workspace containers.
+	// When set, overrides `devEnvironments.containerResourceCaps`.
+	// The workspaces of the users matching the profile use a dedicated DevWorkspaceOperatorConfig,
+	// the running workspaces get the change once they are stopped.
+	// +optional
+	ContainerResourceCaps *corev1.ResourceRequirements `json:"containerResourceCaps,omitempty"`
+	// Disables the container build capabilities for the users matching the profile.
+	// The profile can not grant the capabilities disabled by `devEnvironments.disableContainerBuildCapabilities`.
+	// On Kubernetes, the user namespaces get the Pod Security Admission level of the capabilities left enabled, see `devEnvironments.podSecurityAdmission`.
+	// +optional
+	DisableContainerBuildCapabilities *bool `json:"disableContainerBuildCapabilities,omitempty"`
+	// Disables the container run capabilities for the users matching the profile.
+	// The profile can not grant the capabilities disabled by `devEnvironments.disableContainerRunCapabilities`.
+	// On Kubernetes, the user namespaces get the Pod Security Admission level of the capabilities left enabled, see `devEnvironments.podSecurityAdmission`.
+	// +optional
+	DisableContainerRunCapabilities *bool `json:"disableContainerRunCapabilities,omitempty"`
+}
+
+// WorkspaceScheduledShutdown defines time windows during which workspaces are not allowed to run.
+type WorkspaceScheduledShutdown struct {
+	// Time windows during which the Operator stops running workspaces.
+	// Workspaces started inside a window are stopped as well.
+	// +optional
+	Windows []WorkspaceShutdownWindow `json:"windows,omitempty"`
+	// IANA time zone used to evaluate the windows schedule, for example `Europe/Berlin`.
+	// Defaults to `UTC`.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+	// Number of minutes before a window starts during which users are warned
+	// with the `status.scheduledShutdown.message` shown by the dashboard and a Warning event on their running workspaces.
+	// Set to `0` to disable the warning.
+	// +optional
+	// +kubebuilder:default:=30
+	// +kubebuilder:validation:Minimum:=0
+	MinutesOfWarningBeforeShutdown *int32 `json:"minutesOfWarningBeforeShutdown,omitempty"`
+}
+
+// WorkspaceShutdownWindow defines a recurring time window.
+type WorkspaceShutdownWindow struct {
+	// Window start in the cron format: `minute hour day-of-month month day-of-week`.
+	// For example, `0 20 * * 1-5` starts the window at 20:00 on weekdays.
+	// +kubebuilder:validation:Required
+	Schedule string `json:"schedule"`
+	// Window duration, for example `12h` or `30m`.
+	// +kubebuilder:validation:Required
+	Duration metav1.Duration `json:"duration"`
+}
+
+// WorkspaceRetentionPolicy defines how inactive workspaces and user namespaces are cleaned up.
+// A running workspace is not considered inactive, it is only stopped once it has been running for too long.
+// A stopped workspace is considered inactive since it was last stopped or started, or since it was created.
+// Each step is disabled when the corresponding field is omitted.
+type WorkspaceRetentionPolicy struct {
+	// Number of days of inactivity after which the workspace owner is warned with an Event.
+	// Required to delete workspaces.
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+	DaysOfInactivityBeforeWarning *int32 `json:"daysOfInactivityBeforeWarning,omitempty"`
+	// Number of days since a running workspace was last started after which the workspace is stopped,
+	// regardless of the user activity that the DevWorkspace does not expose.
+	// Idle workspaces are stopped earlier by the DevWorkspace Operator, see `secondsOfInactivityBeforeIdling`.
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+	DaysOfUptimeBeforeStop *int32 `json:"daysOfUptimeBeforeStop,omitempty"`
+	// Number of days of inactivity after which the stopped workspace is deleted.
+	// A workspace is deleted only if its owner has been warned, and not earlier than
+	// the difference between the deletion and the warning periods after the warning.
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+	DaysOfInactivityBeforeDeletion *int32 `json:"daysOfInactivityBeforeDeletion,omitempty"`
+	// Name of the VolumeSnapshotClass used to snapshot the workspace PVCs before the workspace is deleted.
+	// If omitted, no snapshot is taken. The workspace is deleted once the snapshots are ready to use.
+	// +optional
+	VolumeSnapshotClassName string `json:"volumeSnapshotClassName,omitempty"`
+	// Number of days the volume snapshots taken before workspace deletion are kept.
+	// Set to `0` to keep the snapshots until they are deleted by the administrator.
+	// +optional
+	// +kubebuilder:default:=30
+	// +kubebuilder:validation:Minimum:=0
+	DaysOfSnapshotRetention *int32 `json:"daysOfSnapshotRetention,omitempty"`
+	// Deletes the user namespaces created from `defaultNamespace.template`
+	// whose workspaces were all deleted at least the deletion period ago.
+	// User namespaces that never had a workspace are kept, the ones holding the snapshots taken
+	// before workspace deletion are kept until the snapshots are deleted.
+	// +optional
+	// +kubebuilder:default:=false
+	DeleteNamespaces *bool `json:"deleteNamespaces,omitempty"`
+}
+
 // Authentication settings.
 type Auth struct {
 	// Public URL of the Identity Provider server.
@@ -792,6 +1231,43 @@ type Auth struct {
 	// Note: group-based authorization (`allowGroups` and `denyGroups`) is currently supported on OpenShift only.
 	// +optional
 	AdvancedAuthorization *AdvancedAuthorization `json:"advancedAuthorization,omitempty"`
+	// Mappings of the OIDC token claims to the username and groups of a user.
+	// The mappings are propagated to Che server, and to kube-rbac-proxy if `gateway.kubeRbacProxy.oidcAuthentication` is enabled.
+	// On Kubernetes, they are also published in the `che-authentication-config` ConfigMap as an API server structured
+	// authentication configuration, to pass to the cluster API server with the `--authentication-config` flag.
+	// They must be consistent with the cluster authentication configuration.
+	// For OpenShift with external OIDC authentication, unset fields are resolved from the cluster `Authentication` resource.
+	// +optional
+	ClaimMappings *ClaimMappings `json:"claimMappings,omitempty"`
+}
+
+// ClaimMappings defines how the OIDC token claims are mapped to the user identity.
+type ClaimMappings struct {
+	// The username mapping.
+	// The expression must evaluate to a string.
+	// +optional
+	Username *ClaimMapping `json:"username,omitempty"`
+	// The groups mapping.
+	// The expression must evaluate to a string or a list of strings.
+	// +optional
+	Groups *ClaimMapping `json:"groups,omitempty"`
+}
+
+// ClaimMapping maps a claim, or the result of an expression over the claims, to a user attribute.
+// +kubebuilder:validation:XValidation:rule="!(has(self.claim) && has(self.expression))",message="claim and expression are mutually exclusive"
+type ClaimMapping struct {
+	// The name of the claim, for example `preferred_username` or `groups`.
+	// +optional
+	Claim string `json:"claim,omitempty"`
+	// CEL expression evaluated over the token claims available as the `claims` variable,
+	// for example `has(claims.upn) ? claims.upn : claims.email`.
+	// Expressions are evaluated by the cluster API server, kube-rbac-proxy then authenticates users with the TokenReview API.
+	// The prefix can't be used with an expression, include it in the expression instead.
+	// +optional
+	Expression string `json:"expression,omitempty"`
+	// The prefix added to the claim value.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
 }
 
 type AdvancedAuthorization struct {
@@ -840,6 +1316,15 @@ type OAuthProxy struct {
 	// +kubebuilder:default:=86400
 	// +kubebuilder:validation:Minimum:=0
 	CookieExpireSeconds *int32 `json:"cookieExpireSeconds,omitempty"`
+	// Enables the secure mode of oauth-proxy. This field is specific to Kubernetes, it is not used with OpenShift built-in OAuth.
+	// In the secure mode, the TLS certificate of the Identity Provider is verified using the issuer CA
+	// and the Che trusted CA bundle (`ca-certs-merged` ConfigMap) in addition to the system trust store,
+	// and the OIDC client secret and the cookie secret are read from a Kubernetes secret
+	// instead of being rendered into the oauth-proxy configuration ConfigMap.
+	// The result of the CA bundle validation is reported in the `OAuthProxyTLSVerification` status condition.
+	// +optional
+	// +kubebuilder:default:=false
+	SecureMode bool `json:"secureMode,omitempty"`
 }
 
 // Proxy server configuration.
@@ -893,6 +1378,8 @@ type ExternalDevfileRegistry struct {
 	// The public URL of the devfile registry that serves sample ready-to-use devfiles.
 	// +optional
 	Url string `json:"url,omitempty"`
+	// +optional
+	ExternalRegistryOptions `json:",inline"`
 }
 
 // External plug-in registries configuration.
@@ -900,6 +1387,34 @@ type ExternalPluginRegistry struct {
 	// Public URL of the plug-in registry.
 	// +optional
 	Url string `json:"url,omitempty"`
+	// +optional
+	ExternalRegistryOptions `json:",inline"`
+}
+
+// External registry options.
+// The operator periodically probes the registry URL and reports its health in the CheCluster status.
+type ExternalRegistryOptions struct {
+	// The name of the registry displayed in the dashboard.
+	// +optional
+	DisplayName string `json:"displayName,omitempty"`
+	// Registries with a higher priority are listed first in the dashboard.
+	// Registries with the same priority keep the order they are defined in.
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+	// The name of the Kubernetes Secret that contains the credentials to access the registry.
+	// The Secret must contain either the `token` key, sent as a bearer token,
+	// or the `username` and `password` keys, used for basic authentication.
+	// The secret must have the `app.kubernetes.io/part-of=che.eclipse.org` label.
+	// +optional
+	CredentialsSecretName string `json:"credentialsSecretName,omitempty"`
+	// The name of the ConfigMap that contains the PEM-encoded CA certificates to verify the registry certificate,
+	// in addition to the Che trusted CA certificates. All keys of the ConfigMap are used.
+	// The ConfigMap must have the `app.kubernetes.io/part-of=che.eclipse.org` label.
+	// +optional
+	CABundleConfigMapName string `json:"caBundleConfigMapName,omitempty"`
+	// Hides the registry from the dashboard while it is unhealthy.
+	// +optional
+	HideWhenUnhealthy bool `json:"hideWhenUnhealthy,omitempty"`
 }
 
 // Deployment custom settings.
@@ -1066,6 +1581,29 @@ type ContainerBuildConfiguration struct {
 	OpenShiftSecurityContextConstraint string `json:"openShiftSecurityContextConstraint,omitempty"`
 }
 
+type PodSecurityAdmission struct {
+	// Level enforced in the user namespaces.
+	// The `restricted` level requires the pod and container security contexts defined in `devEnvironments.security`
+	// to comply with it, otherwise the workspace pods are rejected.
+	// +optional
+	// +kubebuilder:default:=restricted
+	// +kubebuilder:validation:Enum=restricted;baseline;privileged
+	Enforce string `json:"enforce,omitempty"`
+	// Level enforced in the user namespaces when the container build capabilities are enabled.
+	// The `restricted` level is not allowed, it does not admit the container build security context.
+	// +optional
+	// +kubebuilder:default:=baseline
+	// +kubebuilder:validation:Enum=baseline;privileged
+	ContainerBuildEnforce string `json:"containerBuildEnforce,omitempty"`
+	// Level enforced in the user namespaces when the container run capabilities are enabled.
+	// The `restricted` level is not allowed, it does not admit the container run security context.
+	// The default `privileged` level admits the unmasked `/proc` mount the container run security context requests.
+	// +optional
+	// +kubebuilder:default:=privileged
+	// +kubebuilder:validation:Enum=baseline;privileged
+	ContainerRunEnforce string `json:"containerRunEnforce,omitempty"`
+}
+
 type ContainerRunConfiguration struct {
 	// Specifies the OpenShift SecurityContextConstraint used to run containers.
 	// +kubebuilder:validation:Required
@@ -1102,6 +1640,12 @@ type KubeRbacProxy struct {
 	// +kubebuilder:default:=0
 	// +kubebuilder:validation:Minimum:=0
 	LogLevel *int32 `json:"logLevel,omitempty"`
+	// Authenticates users by validating their OIDC tokens with the mappings defined in `spec.networking.auth.claimMappings`,
+	// instead of using the TokenReview API. Enable it only if the cluster API server maps the claims the same way,
+	// otherwise the authorization checks fail. Ignored on OpenShift, and when a claim mapping uses an expression,
+	// which kube-rbac-proxy can't evaluate.
+	// +optional
+	OIDCAuthentication bool `json:"oidcAuthentication,omitempty"`
 }
 
 type AllowedSources struct {
@@ -1134,6 +1678,15 @@ const (
 	RollingUpdate               = "RollingUpdate"
 )
 
+const (
+	UpgradePhasePreflightCheckFailed = "PreflightCheckFailed"
+	UpgradePhaseInProgress           = "InProgress"
+	UpgradePhaseSucceeded            = "Succeeded"
+	UpgradePhaseFailed               = "Failed"
+	UpgradePhaseRollingBack          = "RollingBack"
+	UpgradePhaseRolledBack           = "RolledBack"
+)
+
 // CheClusterStatus defines the observed state of Che installation.
 type CheClusterStatus struct {
 	// Deprecated.
@@ -1199,6 +1752,225 @@ type CheClusterStatus struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Workspace base domain"
 	// +operator-sdk:csv:customresourcedefinitions:type=status,xDescriptors="urn:alm:descriptor:text"
 	WorkspaceBaseDomain string `json:"workspaceBaseDomain,omitempty"`
+	// The images rewritten by the image mirrors.
+	// +optional
+	ResolvedImages []ResolvedImage `json:"resolvedImages,omitempty"`
+	// The mirrors read from the cluster `ImageDigestMirrorSet` objects,
+	// applied if `spec.containerRegistry.useImageDigestMirrorSets` is enabled.
+	// +optional
+	ImageDigestMirrors []ImageMirror `json:"imageDigestMirrors,omitempty"`
+	// The status of the extensions mirrored into the internal OpenVSX registry.
+	// +optional
+	OpenVSXExtensions []OpenVSXExtensionStatus `json:"openVSXExtensions,omitempty"`
+	// The status of the internal OpenVSX registry backups.
+	// +optional
+	OpenVSXBackup *OpenVSXBackupStatus `json:"openVSXBackup,omitempty"`
+	// The health of the external devfile and plug-in registries.
+	// +optional
+	ExternalRegistries []ExternalRegistryStatus `json:"externalRegistries,omitempty"`
+	// The status of the scheduled shutdown of workspaces.
+	// +optional
+	ScheduledShutdown *ScheduledShutdownStatus `json:"scheduledShutdown,omitempty"`
+	// The status of the last upgrade to a new Che version.
+	// +optional
+	Upgrade *UpgradeStatus `json:"upgrade,omitempty"`
+	// The migrations run by the operator.
+	// +optional
+	Migrations *MigrationsStatus `json:"migrations,omitempty"`
+	// The conditions of the Che installation.
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// Progress of the reconciliation of the user namespaces triggered by the last change
+	// of the CheCluster or of the objects synced into the user namespaces.
+	// +optional
+	UserNamespacesReconciliation *UserNamespacesReconciliationStatus `json:"userNamespacesReconciliation,omitempty"`
+}
+
+// UserNamespacesReconciliationStatus reports the progress of the reconciliation of the user namespaces.
+type UserNamespacesReconciliationStatus struct {
+	// Number of user namespaces to reconcile.
+	// +optional
+	Total int32 `json:"total,omitempty"`
+	// Number of user namespaces reconciled so far.
+	// +optional
+	Reconciled int32 `json:"reconciled,omitempty"`
+	// Number of user namespaces skipped since their inputs have not changed since the previous reconciliation.
+	// +optional
+	Skipped int32 `json:"skipped,omitempty"`
+	// Time the reconciliation started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// Time all the user namespaces were reconciled.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+const (
+	// OAuthProxyTLSVerificationCondition reports whether oauth-proxy verifies the TLS certificate of the Identity Provider.
+	OAuthProxyTLSVerificationCondition = "OAuthProxyTLSVerification"
+
+	OAuthProxyTLSVerificationReasonVerified           = "Verified"
+	OAuthProxyTLSVerificationReasonSecureModeDisabled = "SecureModeDisabled"
+	OAuthProxyTLSVerificationReasonInvalidIssuerCA    = "InvalidIssuerCA"
+	OAuthProxyTLSVerificationReasonInvalidCABundle    = "InvalidCABundle"
+)
+
+const (
+	// CustomEditorDefinitionsValidCondition reports whether the custom editor definitions
+	// provided in the labeled ConfigMaps of the Che namespace are valid.
+	CustomEditorDefinitionsValidCondition = "CustomEditorDefinitionsValid"
+
+	CustomEditorDefinitionsValidReasonValid   = "Valid"
+	CustomEditorDefinitionsValidReasonInvalid = "InvalidDefinitions"
+)
+
+const (
+	// NamespacesProvisionedCondition reports whether the namespaces of the users listed in
+	// `spec.devEnvironments.defaultNamespace.provisioning` are created.
+	NamespacesProvisionedCondition = "NamespacesProvisioned"
+
+	NamespacesProvisionedReasonProvisioned   = "Provisioned"
+	NamespacesProvisionedReasonPartiallyDone = "PartiallyProvisioned"
+)
+
+const (
+	MigrationResultApplied     = "Applied"
+	MigrationResultNotRequired = "NotRequired"
+	MigrationResultSkipped     = "Skipped"
+	MigrationResultImported    = "Imported"
+)
+
+// MigrationsStatus is the status of the migrations run by the operator.
+type MigrationsStatus struct {
+	// The completed migrations.
+	// +optional
+	Completed []MigrationRecord `json:"completed,omitempty"`
+	// The IDs of the migrations to be run, reported when `spec.upgrade.migrationsDryRun` is enabled.
+	// +optional
+	Pending []string `json:"pending,omitempty"`
+}
+
+// MigrationRecord is a completed migration.
+type MigrationRecord struct {
+	// The migration ID.
+	ID string `json:"id"`
+	// The migration result:
+	// `Applied` if the migration changed anything, `NotRequired` if there was nothing to change,
+	// `Skipped` if the migration does not apply to the installed version,
+	// `Imported` if the migration was completed before migrations were recorded in the status.
+	Result string `json:"result"`
+	// The operator version that completed the migration.
+	OperatorVersion string `json:"operatorVersion"`
+	// The time the migration completed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// UpgradeRecord describes an upgrade to a new Che version.
+type UpgradeRecord struct {
+	// The Che version before the upgrade.
+	// +optional
+	FromVersion string `json:"fromVersion,omitempty"`
+	// The Che version to upgrade to.
+	ToVersion string `json:"toVersion"`
+	// The upgrade phase: `PreflightCheckFailed`, `InProgress`, `Succeeded`, `Failed`, `RollingBack` or `RolledBack`.
+	Phase string `json:"phase"`
+	// The time the upgrade started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	// The time the upgrade completed.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	// A human readable message indicating details about the upgrade.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// UpgradeStatus is the status of the last upgrade to a new Che version.
+type UpgradeStatus struct {
+	UpgradeRecord `json:",inline"`
+	// The previous upgrades, the most recent first.
+	// +optional
+	History []UpgradeRecord `json:"history,omitempty"`
+}
+
+// ExternalRegistryStatus is the health of an external devfile or plug-in registry.
+type ExternalRegistryStatus struct {
+	// The registry URL.
+	Url string `json:"url"`
+	// The registry type: `DevfileRegistry` or `PluginRegistry`.
+	Type string `json:"type"`
+	// Whether the registry is healthy.
+	// A registry becomes unhealthy after several consecutive failed probes, and healthy again after a successful one.
+	Healthy bool `json:"healthy"`
+	// Whether the registry is hidden from the dashboard.
+	// +optional
+	Hidden bool `json:"hidden,omitempty"`
+	// A human readable message indicating details about the last failed probe.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// The time of the last probe.
+	// +optional
+	LastProbeTime *metav1.Time `json:"lastProbeTime,omitempty"`
+}
+
+// ScheduledShutdownStatus is the status of the scheduled shutdown of workspaces.
+type ScheduledShutdownStatus struct {
+	// The time until which workspaces are stopped, set while a shutdown window is active.
+	// +optional
+	ActiveUntil *metav1.Time `json:"activeUntil,omitempty"`
+	// A human readable message about the current or upcoming shutdown window, shown to users by the dashboard.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// OpenVSXBackupStatus is the status of the internal OpenVSX registry backups.
+type OpenVSXBackupStatus struct {
+	// The name of the last backup.
+	// +optional
+	LastBackup string `json:"lastBackup,omitempty"`
+	// The phase of the last backup: `Running`, `Succeeded` or `Failed`.
+	// +optional
+	LastBackupPhase string `json:"lastBackupPhase,omitempty"`
+	// The name of the last successful backup.
+	// +optional
+	LastSuccessfulBackup string `json:"lastSuccessfulBackup,omitempty"`
+	// The completion time of the last successful backup.
+	// +optional
+	LastSuccessfulBackupTime *metav1.Time `json:"lastSuccessfulBackupTime,omitempty"`
+	// The name of the backup being restored or restored last.
+	// +optional
+	RestoredBackup string `json:"restoredBackup,omitempty"`
+	// The phase of the restore: `Running`, `Succeeded` or `Failed`.
+	// +optional
+	RestorePhase string `json:"restorePhase,omitempty"`
+	// A human readable message indicating details about the failure.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// OpenVSXExtensionStatus is the status of an extension mirrored into the internal OpenVSX registry.
+type OpenVSXExtensionStatus struct {
+	// The extension as defined in the `spec.components.openVSXRegistry.extensionsMirror.extensions`.
+	Extension string `json:"extension"`
+	// The resolved version of the extension.
+	// +optional
+	Version string `json:"version,omitempty"`
+	// The phase of the extension mirroring: `Pending`, `Published` or `Failed`.
+	Phase string `json:"phase"`
+	// A human readable message indicating details about the failure.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ResolvedImage is an image rewritten by the image mirrors.
+type ResolvedImage struct {
+	// The original image.
+	Source string `json:"source"`
+	// The image pulled instead.
+	Image string `json:"image"`
 }
 
 // The `CheCluster` custom resource allows defining and managing Eclipse Che server installation.
@@ -1263,6 +2035,19 @@ func (c *CheCluster) GetDefaultNamespace() string {
 	return "<username>-" + defaults.GetCheFlavor()
 }
 
+// GetUserNamespaceName returns the name of the user namespace resolved from the namespace template.
+// The template is expected not to contain the `<userid>` placeholder, since the user id is unknown to the Operator.
+func (c *CheCluster) GetUserNamespaceName(username string) string {
+	name := strings.ReplaceAll(c.GetDefaultNamespace(), "<username>", username)
+	return invalidNamespaceChars.ReplaceAllString(strings.ToLower(name), "-")
+}
+
+// IsNamespaceProvisioningEnabled returns true if the Operator creates the user namespaces in advance.
+func (c *CheCluster) IsNamespaceProvisioningEnabled() bool {
+	provisioning := c.Spec.DevEnvironments.DefaultNamespace.Provisioning
+	return provisioning != nil && (len(provisioning.Users) > 0 || len(provisioning.Groups) > 0)
+}
+
 func (c *CheCluster) GetIdentityToken() string {
 	if len(c.Spec.Networking.Auth.IdentityToken) > 0 {
 		return c.Spec.Networking.Auth.IdentityToken
@@ -1274,6 +2059,12 @@ func (c *CheCluster) GetIdentityToken() string {
 	return constants.IdToken
 }
 
+// IsOAuthProxySecureModeEnabled returns true if oauth-proxy verifies the TLS certificate of the Identity Provider
+// and reads the secrets from a Kubernetes secret.
+func (c *CheCluster) IsOAuthProxySecureModeEnabled() bool {
+	return c.Spec.Networking.Auth.Gateway.OAuthProxy != nil && c.Spec.Networking.Auth.Gateway.OAuthProxy.SecureMode
+}
+
 func (c *CheCluster) IsAccessTokenConfigured() bool {
 	return c.GetIdentityToken() == constants.AccessToken
 }
@@ -1325,6 +2116,20 @@ func (c *CheCluster) IsCheBeingInstalled() bool {
 	return c.Status.CheVersion == ""
 }
 
+// IsUpgradeRolledBack returns true if the components of the previous Che version are deployed
+// because the upgrade failed.
+func (c *CheCluster) IsUpgradeRolledBack() bool {
+	return c.Status.Upgrade != nil &&
+		(c.Status.Upgrade.Phase == UpgradePhaseRollingBack || c.Status.Upgrade.Phase == UpgradePhaseRolledBack)
+}
+
+// IsPreviousCheVersionKept returns true if the components of the previous Che version are kept deployed
+// because the upgrade pre-flight checks failed or the upgrade was rolled back.
+func (c *CheCluster) IsPreviousCheVersionKept() bool {
+	return c.IsUpgradeRolledBack() ||
+		(c.Status.Upgrade != nil && c.Status.Upgrade.Phase == UpgradePhasePreflightCheckFailed)
+}
+
 func (c *CheCluster) IsDisableWorkspaceCaBundleMount() bool {
 	return c.Spec.DevEnvironments.TrustedCerts != nil &&
 		c.Spec.DevEnvironments.TrustedCerts.DisableWorkspaceCaBundleMount != nil &&
@@ -151,3 +151,19 @@ func TestGetDefaultIdentityToken(t *testing.T) {
 		}
 	}
 }
+
+func TestGetUserNamespaceName(t *testing.T) {
+	cheCluster := &CheCluster{
+		Spec: CheClusterSpec{
+			DevEnvironments: CheClusterDevEnvironments{
+				DefaultNamespace: DefaultNamespace{
+					Template: "che-<username>",
+				},
+			},
+		},
+	}
+
+	assert.Equal(t, "che-john", cheCluster.GetUserNamespaceName("john"))
+	assert.Equal(t, "che-john-doe", cheCluster.GetUserNamespaceName("John.Doe"))
+	assert.Equal(t, "che-john-example-com", cheCluster.GetUserNamespaceName("john@example.com"))
+}
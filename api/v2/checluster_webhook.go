@@ -15,19 +15,24 @@ package v2
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/google/cel-go/cel"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/eclipse-che/che-operator/pkg/common/constants"
+	"github.com/eclipse-che/che-operator/pkg/common/cron"
 	"github.com/eclipse-che/che-operator/pkg/common/infrastructure"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/json"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	k8shelper "github.com/eclipse-che/che-operator/pkg/common/k8s-helper"
 	corev1 "k8s.io/api/core/v1"
@@ -66,15 +71,23 @@ func (r *CheClusterDefaulter) Default(_ context.Context, cheCluster *CheCluster)
 	return nil
 }
 
+// On Kubernetes, nothing grants the workspace pods the security context required by the container capabilities,
+// so the capabilities are disabled. The exception is when the Pod Security Admission levels are managed: the user namespaces
+// are then labeled with the level admitting the security context of the capabilities enabled for the profile of the namespace.
+// The capabilities are still disabled unless they are enabled explicitly.
 func (r *CheClusterDefaulter) setDisableContainerBuildCapabilities(cheCluster *CheCluster) {
 	if !infrastructure.IsOpenShift() {
-		cheCluster.Spec.DevEnvironments.DisableContainerBuildCapabilities = ptr.To(true)
+		if cheCluster.Spec.DevEnvironments.PodSecurityAdmission == nil || cheCluster.Spec.DevEnvironments.DisableContainerBuildCapabilities == nil {
+			cheCluster.Spec.DevEnvironments.DisableContainerBuildCapabilities = ptr.To(true)
+		}
 	}
 }
 
 func (r *CheClusterDefaulter) setDisableContainerRunCapabilities(cheCluster *CheCluster) {
 	if !infrastructure.IsOpenShift() {
-		cheCluster.Spec.DevEnvironments.DisableContainerRunCapabilities = ptr.To(true)
+		if cheCluster.Spec.DevEnvironments.PodSecurityAdmission == nil || cheCluster.Spec.DevEnvironments.DisableContainerRunCapabilities == nil {
+			cheCluster.Spec.DevEnvironments.DisableContainerRunCapabilities = ptr.To(true)
+		}
 	}
 }
 
@@ -145,6 +158,42 @@ func (r *CheClusterValidator) validate(checluster *CheCluster) error {
 		return err
 	}
 
+	if err := r.validateDevEnvironmentProfiles(checluster); err != nil {
+		return err
+	}
+
+	if err := r.validateScheduledShutdown(checluster); err != nil {
+		return err
+	}
+
+	if err := r.validateRetentionPolicy(checluster); err != nil {
+		return err
+	}
+
+	if err := r.validateStorageBackup(checluster); err != nil {
+		return err
+	}
+
+	if err := r.validateClaimMappings(checluster); err != nil {
+		return err
+	}
+
+	if err := r.validateGroupRoles(checluster); err != nil {
+		return err
+	}
+
+	if err := r.validateNamespaceProvisioning(checluster); err != nil {
+		return err
+	}
+
+	if err := r.validateNamespaceMetadata(checluster); err != nil {
+		return err
+	}
+
+	if err := r.validatePodSecurityAdmission(checluster); err != nil {
+		return err
+	}
+
 	for _, github := range checluster.Spec.GitServices.GitHub {
 		if err := r.validateOAuthSecret(github.SecretName, "github", github.Endpoint, github.DisableSubdomainIsolation, checluster.Namespace); err != nil {
 			return err
@@ -329,6 +378,284 @@ func (r *CheClusterValidator) validateOpenVSXRegistry(checluster *CheCluster) er
 	return nil
 }
 
+func (r *CheClusterValidator) validateDevEnvironmentProfiles(checluster *CheCluster) error {
+	for _, profile := range checluster.Spec.DevEnvironments.Profiles {
+		if profile.NamespaceSelector == nil && len(profile.Groups) == 0 {
+			return fmt.Errorf("development environment profile %s must define either a namespace selector or groups", profile.Name)
+		}
+
+		if len(profile.Groups) > 0 && !infrastructure.IsOpenShiftOAuthEnabled() {
+			return fmt.Errorf("development environment profile %s can select groups only on OpenShift with OpenShift OAuth", profile.Name)
+		}
+
+		if profile.NamespaceSelector != nil {
+			if _, err := metav1.LabelSelectorAsSelector(profile.NamespaceSelector); err != nil {
+				return fmt.Errorf("invalid namespace selector in development environment profile %s: %w", profile.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *CheClusterValidator) validateScheduledShutdown(checluster *CheCluster) error {
+	scheduledShutdown := checluster.Spec.DevEnvironments.ScheduledShutdown
+	if scheduledShutdown == nil {
+		return nil
+	}
+
+	loc := time.UTC
+	if scheduledShutdown.TimeZone != "" {
+		var err error
+		if loc, err = time.LoadLocation(scheduledShutdown.TimeZone); err != nil {
+			return fmt.Errorf("invalid scheduled shutdown time zone %s: %w", scheduledShutdown.TimeZone, err)
+		}
+	}
+
+	windows := make([]cron.Window, 0, len(scheduledShutdown.Windows))
+	for _, window := range scheduledShutdown.Windows {
+		schedule, err := cron.Parse(window.Schedule)
+		if err != nil {
+			return fmt.Errorf("invalid scheduled shutdown window schedule %s: %w", window.Schedule, err)
+		}
+
+		if window.Duration.Duration <= 0 {
+			return fmt.Errorf("scheduled shutdown window %s must have a positive duration", window.Schedule)
+		}
+
+		windows = append(windows, cron.Window{Schedule: schedule, Duration: window.Duration.Duration})
+	}
+
+	if len(windows) > 0 && cron.IsAlwaysActive(windows, time.Now().In(loc)) {
+		return fmt.Errorf("scheduled shutdown windows cover all time, workspaces would never be allowed to run")
+	}
+
+	return nil
+}
+
+func (r *CheClusterValidator) validateRetentionPolicy(checluster *CheCluster) error {
+	policy := checluster.Spec.DevEnvironments.RetentionPolicy
+	if policy == nil {
+		return nil
+	}
+
+	warning := ptr.Deref(policy.DaysOfInactivityBeforeWarning, 0)
+	deletion := ptr.Deref(policy.DaysOfInactivityBeforeDeletion, 0)
+
+	if deletion > 0 && warning == 0 {
+		return fmt.Errorf("retention policy warning period must be set to delete workspaces")
+	}
+
+	if warning > 0 && deletion > 0 && warning >= deletion {
+		return fmt.Errorf("retention policy warning period (%d days) must be shorter than the deletion period (%d days)", warning, deletion)
+	}
+
+	if ptr.Deref(policy.DeleteNamespaces, false) && deletion == 0 {
+		return fmt.Errorf("retention policy deletion period must be set to delete user namespaces")
+	}
+
+	return nil
+}
+
+func (r *CheClusterValidator) validateStorageBackup(checluster *CheCluster) error {
+	backup := checluster.Spec.DevEnvironments.Storage.Backup
+	if backup == nil || backup.Schedule == "" {
+		return nil
+	}
+
+	if _, err := cron.Parse(backup.Schedule); err != nil {
+		return fmt.Errorf("invalid workspace backup schedule %s: %w", backup.Schedule, err)
+	}
+
+	return nil
+}
+
+func (r *CheClusterValidator) validateGroupRoles(checluster *CheCluster) error {
+	if checluster.Spec.DevEnvironments.User == nil {
+		return nil
+	}
+
+	for _, groupRoles := range checluster.Spec.DevEnvironments.User.GroupRoles {
+		if len(groupRoles.ClusterRoles) == 0 && len(groupRoles.Roles) == 0 {
+			return fmt.Errorf("group %s must be granted at least one ClusterRole or Role", groupRoles.Group)
+		}
+
+		for _, roleName := range append(slices.Clone(groupRoles.ClusterRoles), groupRoles.Roles...) {
+			if err := validateRoleName(roleName); err != nil {
+				return fmt.Errorf("invalid role %q granted to group %s: %w", roleName, groupRoles.Group, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateRoleName checks the role name the way the API server validates the names of the RBAC resources.
+func validateRoleName(roleName string) error {
+	if roleName == "" {
+		return fmt.Errorf("name is empty")
+	}
+
+	if roleName == "." || roleName == ".." {
+		return fmt.Errorf("name may not be '.' or '..'")
+	}
+
+	if strings.ContainsAny(roleName, "/%") {
+		return fmt.Errorf("name may not contain '/' or '%%'")
+	}
+
+	return nil
+}
+
+// validateNamespaceProvisioning checks that the namespaces of the provisioned users
+// can be resolved from the namespace template.
+func (r *CheClusterValidator) validateNamespaceProvisioning(checluster *CheCluster) error {
+	provisioning := checluster.Spec.DevEnvironments.DefaultNamespace.Provisioning
+	if provisioning == nil {
+		return nil
+	}
+
+	if strings.Contains(checluster.GetDefaultNamespace(), "<userid>") {
+		return fmt.Errorf("namespace template %s cannot contain the <userid> placeholder when namespaces are provisioned", checluster.GetDefaultNamespace())
+	}
+
+	if len(provisioning.Groups) > 0 && !infrastructure.IsOpenShiftOAuthEnabled() {
+		return fmt.Errorf("namespaces can be provisioned for groups only on OpenShift with OpenShift OAuth")
+	}
+
+	for _, username := range provisioning.Users {
+		name := checluster.GetUserNamespaceName(username)
+		if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+			return fmt.Errorf("invalid namespace name %s for user %s: %s", name, username, strings.Join(errs, ", "))
+		}
+	}
+
+	return nil
+}
+
+// validateNamespaceMetadata checks the labels and annotations set on the user namespaces.
+// The keys used by Che itself to recognize and configure the user namespaces are reserved.
+func (r *CheClusterValidator) validateNamespaceMetadata(checluster *CheCluster) error {
+	defaultNamespace := checluster.Spec.DevEnvironments.DefaultNamespace
+
+	for key, value := range defaultNamespace.Labels {
+		if err := validateNamespaceMetadataKey(key); err != nil {
+			return fmt.Errorf("invalid user namespace label %s: %w", key, err)
+		}
+
+		// the actual value depends on the username, it is validated for each namespace by the Operator
+		if errs := validation.IsValidLabelValue(strings.ReplaceAll(value, "<username>", "user")); len(errs) > 0 {
+			return fmt.Errorf("invalid value of the user namespace label %s: %s", key, strings.Join(errs, ", "))
+		}
+	}
+
+	for key := range defaultNamespace.Annotations {
+		if err := validateNamespaceMetadataKey(key); err != nil {
+			return fmt.Errorf("invalid user namespace annotation %s: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+func validateNamespaceMetadataKey(key string) error {
+	if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, ", "))
+	}
+
+	if key == constants.KubernetesPartOfLabelKey ||
+		key == constants.KubernetesComponentLabelKey ||
+		strings.HasPrefix(key, "che.eclipse.org/") ||
+		strings.HasPrefix(key, "controller.devfile.io/") {
+		return fmt.Errorf("the key is reserved")
+	}
+
+	return nil
+}
+
+func (r *CheClusterValidator) validatePodSecurityAdmission(checluster *CheCluster) error {
+	if checluster.Spec.DevEnvironments.PodSecurityAdmission == nil {
+		return nil
+	}
+
+	if infrastructure.IsOpenShift() {
+		return fmt.Errorf("pod security admission levels can be managed only on Kubernetes, OpenShift uses SecurityContextConstraints")
+	}
+
+	if _, ok := checluster.Spec.DevEnvironments.DefaultNamespace.Labels[constants.PodSecurityEnforceLabelKey]; ok {
+		return fmt.Errorf("user namespace label %s is managed by the pod security admission levels", constants.PodSecurityEnforceLabelKey)
+	}
+
+	return nil
+}
+
+func (r *CheClusterValidator) validateClaimMappings(checluster *CheCluster) error {
+	claimMappings := checluster.Spec.Networking.Auth.ClaimMappings
+	if claimMappings == nil {
+		return nil
+	}
+
+	if claimMappings.Username != nil {
+		if err := validateClaimMapping(claimMappings.Username, cel.StringType); err != nil {
+			return fmt.Errorf("invalid username claim mapping: %w", err)
+		}
+	}
+
+	if claimMappings.Groups != nil {
+		if err := validateClaimMapping(claimMappings.Groups, cel.StringType, cel.ListType(cel.StringType)); err != nil {
+			return fmt.Errorf("invalid groups claim mapping: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// validateClaimMapping checks that either a claim or an expression is defined
+// and that the expression evaluates to one of the allowed types.
+func validateClaimMapping(claimMapping *ClaimMapping, allowedTypes ...*cel.Type) error {
+	if claimMapping.Claim != "" && claimMapping.Expression != "" {
+		return fmt.Errorf("claim and expression are mutually exclusive")
+	}
+
+	if strings.TrimSpace(claimMapping.Claim) != claimMapping.Claim {
+		return fmt.Errorf("claim %q must not contain leading or trailing spaces", claimMapping.Claim)
+	}
+
+	if claimMapping.Expression == "" {
+		if claimMapping.Claim == "" && claimMapping.Prefix != "" {
+			return fmt.Errorf("prefix requires a claim")
+		}
+		return nil
+	}
+
+	// The API server does not apply prefixes to expressions
+	if claimMapping.Prefix != "" {
+		return fmt.Errorf("prefix can not be used with an expression, include it in the expression instead")
+	}
+
+	env, err := cel.NewEnv(cel.Variable("claims", cel.MapType(cel.StringType, cel.DynType)))
+	if err != nil {
+		return err
+	}
+
+	ast, issues := env.Compile(claimMapping.Expression)
+	if issues != nil && issues.Err() != nil {
+		return fmt.Errorf("failed to compile expression %s: %w", claimMapping.Expression, issues.Err())
+	}
+
+	outputType := ast.OutputType()
+	if outputType.IsExactType(cel.DynType) {
+		return nil
+	}
+	for _, allowedType := range allowedTypes {
+		if outputType.IsExactType(allowedType) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("expression %s evaluates to %s", claimMapping.Expression, outputType.String())
+}
+
 func (r *CheClusterValidator) validateSecretDataKeys(secret *corev1.Secret, keys []string) error {
 	for _, key := range keys {
 		if value, ok := secret.Data[key]; !ok || len(value) == 0 {
@@ -15,12 +15,15 @@ package v2
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/eclipse-che/che-operator/pkg/common/constants"
+	"github.com/eclipse-che/che-operator/pkg/common/infrastructure"
 	k8shelper "github.com/eclipse-che/che-operator/pkg/common/k8s-helper"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 )
 
 func TestValidateScmSecrets(t *testing.T) {
@@ -308,3 +311,571 @@ func TestValidateScmSecretsShouldThrowError(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, "mandatory keys [id, secret] not found in secret github-scm-secret-with-errors", err.Error())
 }
+
+func TestValidateDevEnvironmentProfiles(t *testing.T) {
+	type testCase struct {
+		name           string
+		infrastructure infrastructure.Type
+		profiles       []DevEnvironmentProfile
+		valid          bool
+	}
+
+	testCases := []testCase{
+		{
+			name:           "Profile with namespace selector",
+			infrastructure: infrastructure.OpenShiftV4,
+			profiles: []DevEnvironmentProfile{
+				{
+					Name:              "data-science",
+					NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "data-science"}},
+				},
+			},
+			valid: true,
+		},
+		{
+			name:           "Profile with groups",
+			infrastructure: infrastructure.OpenShiftV4,
+			profiles: []DevEnvironmentProfile{
+				{
+					Name:   "contractors",
+					Groups: []string{"contractors"},
+				},
+			},
+			valid: true,
+		},
+		{
+			name:           "Profile with groups without OpenShift OAuth",
+			infrastructure: infrastructure.Kubernetes,
+			profiles: []DevEnvironmentProfile{
+				{
+					Name:   "contractors",
+					Groups: []string{"contractors"},
+				},
+			},
+			valid: false,
+		},
+		{
+			name:           "Profile without selector",
+			infrastructure: infrastructure.OpenShiftV4,
+			profiles: []DevEnvironmentProfile{
+				{
+					Name: "empty",
+				},
+			},
+			valid: false,
+		},
+		{
+			name:           "Profile with invalid namespace selector",
+			infrastructure: infrastructure.OpenShiftV4,
+			profiles: []DevEnvironmentProfile{
+				{
+					Name: "invalid",
+					NamespaceSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{Key: "team", Operator: "Unknown"},
+						},
+					},
+				},
+			},
+			valid: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			cheClusterValidator := CheClusterValidator{}
+			infrastructure.InitializeForTesting(testCase.infrastructure)
+			defer infrastructure.InitializeForTesting(infrastructure.OpenShiftV4)
+
+			checluster := &CheCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "eclipse-che",
+					Namespace: "eclipse-che",
+				},
+				Spec: CheClusterSpec{
+					DevEnvironments: CheClusterDevEnvironments{
+						Profiles: testCase.profiles,
+					},
+				},
+			}
+
+			err := cheClusterValidator.validate(checluster)
+			if testCase.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateScheduledShutdown(t *testing.T) {
+	type testCase struct {
+		name              string
+		scheduledShutdown *WorkspaceScheduledShutdown
+		valid             bool
+	}
+
+	testCases := []testCase{
+		{
+			name: "Weekday evenings and weekends",
+			scheduledShutdown: &WorkspaceScheduledShutdown{
+				TimeZone: "Europe/Berlin",
+				Windows: []WorkspaceShutdownWindow{
+					{Schedule: "0 20 * * 1-5", Duration: metav1.Duration{Duration: 12 * time.Hour}},
+					{Schedule: "0 0 * * 6", Duration: metav1.Duration{Duration: 48 * time.Hour}},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "Invalid time zone",
+			scheduledShutdown: &WorkspaceScheduledShutdown{
+				TimeZone: "Mars/Olympus",
+				Windows: []WorkspaceShutdownWindow{
+					{Schedule: "0 20 * * 1-5", Duration: metav1.Duration{Duration: 12 * time.Hour}},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "Invalid schedule",
+			scheduledShutdown: &WorkspaceScheduledShutdown{
+				Windows: []WorkspaceShutdownWindow{
+					{Schedule: "0 25 * * *", Duration: metav1.Duration{Duration: time.Hour}},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "Zero duration",
+			scheduledShutdown: &WorkspaceScheduledShutdown{
+				Windows: []WorkspaceShutdownWindow{
+					{Schedule: "0 20 * * *"},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "Daily windows covering all time",
+			scheduledShutdown: &WorkspaceScheduledShutdown{
+				Windows: []WorkspaceShutdownWindow{
+					{Schedule: "0 0 * * *", Duration: metav1.Duration{Duration: 24 * time.Hour}},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "Every minute windows covering all time",
+			scheduledShutdown: &WorkspaceScheduledShutdown{
+				Windows: []WorkspaceShutdownWindow{
+					{Schedule: "* * * * *", Duration: metav1.Duration{Duration: time.Minute}},
+				},
+			},
+			valid: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			cheClusterValidator := CheClusterValidator{}
+
+			checluster := &CheCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "eclipse-che",
+					Namespace: "eclipse-che",
+				},
+				Spec: CheClusterSpec{
+					DevEnvironments: CheClusterDevEnvironments{
+						ScheduledShutdown: testCase.scheduledShutdown,
+					},
+				},
+			}
+
+			err := cheClusterValidator.validate(checluster)
+			if testCase.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateRetentionPolicy(t *testing.T) {
+	type testCase struct {
+		name   string
+		policy *WorkspaceRetentionPolicy
+		valid  bool
+	}
+
+	testCases := []testCase{
+		{
+			name: "Warn, stop and delete",
+			policy: &WorkspaceRetentionPolicy{
+				DaysOfInactivityBeforeWarning:  ptr.To(int32(30)),
+				DaysOfUptimeBeforeStop:         ptr.To(int32(45)),
+				DaysOfInactivityBeforeDeletion: ptr.To(int32(90)),
+				DeleteNamespaces:               ptr.To(true),
+			},
+			valid: true,
+		},
+		{
+			name: "Warning after deletion",
+			policy: &WorkspaceRetentionPolicy{
+				DaysOfInactivityBeforeWarning:  ptr.To(int32(90)),
+				DaysOfInactivityBeforeDeletion: ptr.To(int32(30)),
+			},
+			valid: false,
+		},
+		{
+			name: "Maximum uptime longer than the inactivity periods",
+			policy: &WorkspaceRetentionPolicy{
+				DaysOfInactivityBeforeWarning:  ptr.To(int32(7)),
+				DaysOfUptimeBeforeStop:         ptr.To(int32(90)),
+				DaysOfInactivityBeforeDeletion: ptr.To(int32(30)),
+			},
+			valid: true,
+		},
+		{
+			name: "Deletion without warning",
+			policy: &WorkspaceRetentionPolicy{
+				DaysOfInactivityBeforeDeletion: ptr.To(int32(90)),
+			},
+			valid: false,
+		},
+		{
+			name: "Namespace deletion without deletion period",
+			policy: &WorkspaceRetentionPolicy{
+				DaysOfInactivityBeforeWarning: ptr.To(int32(30)),
+				DeleteNamespaces:              ptr.To(true),
+			},
+			valid: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			cheClusterValidator := CheClusterValidator{}
+
+			checluster := &CheCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "eclipse-che",
+					Namespace: "eclipse-che",
+				},
+				Spec: CheClusterSpec{
+					DevEnvironments: CheClusterDevEnvironments{
+						RetentionPolicy: testCase.policy,
+					},
+				},
+			}
+
+			err := cheClusterValidator.validate(checluster)
+			if testCase.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateStorageBackup(t *testing.T) {
+	cheClusterValidator := CheClusterValidator{}
+
+	checluster := &CheCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "eclipse-che",
+			Namespace: "eclipse-che",
+		},
+		Spec: CheClusterSpec{
+			DevEnvironments: CheClusterDevEnvironments{
+				Storage: WorkspaceStorage{
+					Backup: &WorkspaceStorageBackup{
+						VolumeSnapshotClassName: "csi-snapclass",
+						Schedule:                "0 1 * * *",
+					},
+				},
+			},
+		},
+	}
+	assert.NoError(t, cheClusterValidator.validate(checluster))
+
+	checluster.Spec.DevEnvironments.Storage.Backup.Schedule = "every day"
+	assert.Error(t, cheClusterValidator.validate(checluster))
+}
+
+func TestValidateClaimMappings(t *testing.T) {
+	type testCase struct {
+		name          string
+		claimMappings *ClaimMappings
+		valid         bool
+	}
+
+	testCases := []testCase{
+		{
+			name: "Claims",
+			claimMappings: &ClaimMappings{
+				Username: &ClaimMapping{Claim: "preferred_username", Prefix: "oidc:"},
+				Groups:   &ClaimMapping{Claim: "groups"},
+			},
+			valid: true,
+		},
+		{
+			name: "Expressions",
+			claimMappings: &ClaimMappings{
+				Username: &ClaimMapping{Expression: "has(claims.upn) ? claims.upn : claims.email"},
+				Groups:   &ClaimMapping{Expression: "claims.roles.map(r, 'role-' + r)"},
+			},
+			valid: true,
+		},
+		{
+			name: "Claim and expression",
+			claimMappings: &ClaimMappings{
+				Username: &ClaimMapping{Claim: "email", Expression: "claims.email"},
+			},
+			valid: false,
+		},
+		{
+			name: "Prefix with expression",
+			claimMappings: &ClaimMappings{
+				Username: &ClaimMapping{Expression: "claims.email", Prefix: "oidc:"},
+			},
+			valid: false,
+		},
+		{
+			name: "Invalid expression",
+			claimMappings: &ClaimMappings{
+				Username: &ClaimMapping{Expression: "claims.email.("},
+			},
+			valid: false,
+		},
+		{
+			name: "Username expression does not evaluate to string",
+			claimMappings: &ClaimMappings{
+				Username: &ClaimMapping{Expression: "size(claims)"},
+			},
+			valid: false,
+		},
+		{
+			name: "Claim with spaces",
+			claimMappings: &ClaimMappings{
+				Username: &ClaimMapping{Claim: " email"},
+			},
+			valid: false,
+		},
+		{
+			name: "Prefix without claim",
+			claimMappings: &ClaimMappings{
+				Groups: &ClaimMapping{Prefix: "oidc:"},
+			},
+			valid: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			cheClusterValidator := CheClusterValidator{}
+			checluster := &CheCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "eclipse-che",
+					Namespace: "eclipse-che",
+				},
+				Spec: CheClusterSpec{
+					Networking: CheClusterSpecNetworking{
+						Auth: Auth{
+							ClaimMappings: testCase.claimMappings,
+						},
+					},
+				},
+			}
+
+			err := cheClusterValidator.validate(checluster)
+			if testCase.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateGroupRoles(t *testing.T) {
+	cheClusterValidator := CheClusterValidator{}
+
+	checluster := &CheCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "eclipse-che",
+			Namespace: "eclipse-che",
+		},
+		Spec: CheClusterSpec{
+			DevEnvironments: CheClusterDevEnvironments{
+				User: &UserConfiguration{
+					GroupRoles: []GroupRoles{
+						{Group: "developers", ClusterRoles: []string{"view", "system:aggregate-to-edit"}},
+					},
+				},
+			},
+		},
+	}
+	assert.NoError(t, cheClusterValidator.validate(checluster))
+
+	infrastructure.InitializeForTesting(infrastructure.Kubernetes)
+	assert.NoError(t, cheClusterValidator.validate(checluster))
+	infrastructure.InitializeForTesting(infrastructure.OpenShiftV4)
+
+	checluster.Spec.DevEnvironments.User.GroupRoles[0].Roles = []string{"secrets/reader"}
+	assert.ErrorContains(t, cheClusterValidator.validate(checluster), `invalid role "secrets/reader" granted to group developers`)
+
+	checluster.Spec.DevEnvironments.User.GroupRoles[0].Roles = []string{".."}
+	assert.Error(t, cheClusterValidator.validate(checluster))
+
+	checluster.Spec.DevEnvironments.User.GroupRoles[0].Roles = nil
+	checluster.Spec.DevEnvironments.User.GroupRoles = append(checluster.Spec.DevEnvironments.User.GroupRoles, GroupRoles{Group: "admins"})
+	assert.Error(t, cheClusterValidator.validate(checluster))
+}
+
+func TestValidateNamespaceProvisioning(t *testing.T) {
+	cheClusterValidator := CheClusterValidator{}
+
+	checluster := &CheCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "eclipse-che",
+			Namespace: "eclipse-che",
+		},
+		Spec: CheClusterSpec{
+			DevEnvironments: CheClusterDevEnvironments{
+				DefaultNamespace: DefaultNamespace{
+					Template: "<username>-che",
+					Provisioning: &NamespaceProvisioning{
+						Users:  []string{"John.Doe"},
+						Groups: []string{"developers"},
+					},
+				},
+			},
+		},
+	}
+
+	infrastructure.InitializeForTesting(infrastructure.OpenShiftV4)
+	assert.NoError(t, cheClusterValidator.validate(checluster))
+
+	infrastructure.InitializeForTesting(infrastructure.Kubernetes)
+	assert.Error(t, cheClusterValidator.validate(checluster))
+
+	checluster.Spec.DevEnvironments.DefaultNamespace.Provisioning.Groups = nil
+	assert.NoError(t, cheClusterValidator.validate(checluster))
+
+	checluster.Spec.DevEnvironments.DefaultNamespace.Provisioning.Users = []string{"-john"}
+	assert.Error(t, cheClusterValidator.validate(checluster))
+
+	checluster.Spec.DevEnvironments.DefaultNamespace.Provisioning.Users = []string{"john"}
+	checluster.Spec.DevEnvironments.DefaultNamespace.Template = "<userid>-che"
+	assert.Error(t, cheClusterValidator.validate(checluster))
+}
+
+func TestValidateNamespaceMetadata(t *testing.T) {
+	cheClusterValidator := CheClusterValidator{}
+
+	checluster := &CheCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "eclipse-che",
+			Namespace: "eclipse-che",
+		},
+		Spec: CheClusterSpec{
+			DevEnvironments: CheClusterDevEnvironments{
+				DefaultNamespace: DefaultNamespace{
+					Labels: map[string]string{
+						"cost-center":                        "che-<username>",
+						"pod-security.kubernetes.io/enforce": "restricted",
+					},
+					Annotations: map[string]string{
+						"sidecar.istio.io/inject": "false",
+						"example.com/owner":       "<username>@example.com",
+					},
+				},
+			},
+		},
+	}
+	assert.NoError(t, cheClusterValidator.validate(checluster))
+
+	checluster.Spec.DevEnvironments.DefaultNamespace.Labels["cost-center"] = "<username>@example.com"
+	assert.Error(t, cheClusterValidator.validate(checluster))
+
+	checluster.Spec.DevEnvironments.DefaultNamespace.Labels["cost-center"] = "che"
+	checluster.Spec.DevEnvironments.DefaultNamespace.Labels["app.kubernetes.io/component"] = "other"
+	assert.Error(t, cheClusterValidator.validate(checluster))
+
+	delete(checluster.Spec.DevEnvironments.DefaultNamespace.Labels, "app.kubernetes.io/component")
+	checluster.Spec.DevEnvironments.DefaultNamespace.Annotations["che.eclipse.org/username"] = "admin"
+	assert.Error(t, cheClusterValidator.validate(checluster))
+
+	delete(checluster.Spec.DevEnvironments.DefaultNamespace.Annotations, "che.eclipse.org/username")
+	checluster.Spec.DevEnvironments.DefaultNamespace.Annotations["invalid key"] = "value"
+	assert.Error(t, cheClusterValidator.validate(checluster))
+}
+
+func TestValidatePodSecurityAdmission(t *testing.T) {
+	cheClusterValidator := CheClusterValidator{}
+	defer infrastructure.InitializeForTesting(infrastructure.OpenShiftV4)
+
+	checluster := &CheCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "eclipse-che",
+			Namespace: "eclipse-che",
+		},
+		Spec: CheClusterSpec{
+			DevEnvironments: CheClusterDevEnvironments{
+				PodSecurityAdmission: &PodSecurityAdmission{},
+			},
+		},
+	}
+
+	infrastructure.InitializeForTesting(infrastructure.Kubernetes)
+	assert.NoError(t, cheClusterValidator.validate(checluster))
+
+	checluster.Spec.DevEnvironments.DefaultNamespace.Labels = map[string]string{constants.PodSecurityEnforceLabelKey: "baseline"}
+	assert.Error(t, cheClusterValidator.validate(checluster))
+
+	checluster.Spec.DevEnvironments.DefaultNamespace.Labels = nil
+	checluster.Spec.DevEnvironments.Profiles = []DevEnvironmentProfile{
+		{
+			Name:                            "no-container-run",
+			NamespaceSelector:               &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+			DisableContainerRunCapabilities: ptr.To(true),
+		},
+	}
+	assert.NoError(t, cheClusterValidator.validate(checluster))
+
+	checluster.Spec.DevEnvironments.Profiles = nil
+	infrastructure.InitializeForTesting(infrastructure.OpenShiftV4)
+	assert.Error(t, cheClusterValidator.validate(checluster))
+}
+
+func TestDefaultContainerCapabilitiesWithPodSecurityAdmission(t *testing.T) {
+	cheClusterDefaulter := CheClusterDefaulter{}
+	infrastructure.InitializeForTesting(infrastructure.Kubernetes)
+	defer infrastructure.InitializeForTesting(infrastructure.OpenShiftV4)
+
+	checluster := &CheCluster{
+		Spec: CheClusterSpec{
+			DevEnvironments: CheClusterDevEnvironments{
+				DisableContainerBuildCapabilities: ptr.To(false),
+			},
+		},
+	}
+
+	// capabilities are always disabled without the pod security admission levels
+	cheClusterDefaulter.setDisableContainerBuildCapabilities(checluster)
+	cheClusterDefaulter.setDisableContainerRunCapabilities(checluster)
+	assert.True(t, *checluster.Spec.DevEnvironments.DisableContainerBuildCapabilities)
+	assert.True(t, *checluster.Spec.DevEnvironments.DisableContainerRunCapabilities)
+
+	checluster.Spec.DevEnvironments.PodSecurityAdmission = &PodSecurityAdmission{}
+	checluster.Spec.DevEnvironments.DisableContainerBuildCapabilities = ptr.To(false)
+	checluster.Spec.DevEnvironments.DisableContainerRunCapabilities = nil
+
+	cheClusterDefaulter.setDisableContainerBuildCapabilities(checluster)
+	cheClusterDefaulter.setDisableContainerRunCapabilities(checluster)
+	assert.False(t, *checluster.Spec.DevEnvironments.DisableContainerBuildCapabilities)
+	assert.True(t, *checluster.Spec.DevEnvironments.DisableContainerRunCapabilities)
+}
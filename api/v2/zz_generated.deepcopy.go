@@ -20,7 +20,9 @@ import (
 	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
 	"github.com/devfile/devworkspace-operator/apis/controller/v1alpha1"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -97,6 +99,11 @@ func (in *Auth) DeepCopyInto(out *Auth) {
 		*out = new(AdvancedAuthorization)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ClaimMappings != nil {
+		in, out := &in.ClaimMappings, &out.ClaimMappings
+		*out = new(ClaimMappings)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Auth.
@@ -159,13 +166,47 @@ func (in *Branding) DeepCopy() *Branding {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BuiltInImagePuller) DeepCopyInto(out *BuiltInImagePuller) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuiltInImagePuller.
+func (in *BuiltInImagePuller) DeepCopy() *BuiltInImagePuller {
+	if in == nil {
+		return nil
+	}
+	out := new(BuiltInImagePuller)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CheCluster) DeepCopyInto(out *CheCluster) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheCluster.
@@ -195,7 +236,7 @@ func (in *CheClusterComponents) DeepCopyInto(out *CheClusterComponents) {
 	in.DevfileRegistry.DeepCopyInto(&out.DevfileRegistry)
 	in.Dashboard.DeepCopyInto(&out.Dashboard)
 	in.OpenVSXRegistry.DeepCopyInto(&out.OpenVSXRegistry)
-	out.ImagePuller = in.ImagePuller
+	in.ImagePuller.DeepCopyInto(&out.ImagePuller)
 	out.Metrics = in.Metrics
 }
 
@@ -212,6 +253,11 @@ func (in *CheClusterComponents) DeepCopy() *CheClusterComponents {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CheClusterContainerRegistry) DeepCopyInto(out *CheClusterContainerRegistry) {
 	*out = *in
+	if in.Mirrors != nil {
+		in, out := &in.Mirrors, &out.Mirrors
+		*out = make([]ImageMirror, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheClusterContainerRegistry.
@@ -284,6 +330,16 @@ func (in *CheClusterDevEnvironments) DeepCopyInto(out *CheClusterDevEnvironments
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ResourceQuota != nil {
+		in, out := &in.ResourceQuota, &out.ResourceQuota
+		*out = new(v1.ResourceQuotaSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LimitRange != nil {
+		in, out := &in.LimitRange, &out.LimitRange
+		*out = new(v1.LimitRangeSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	in.DefaultNamespace.DeepCopyInto(&out.DefaultNamespace)
 	if in.TrustedCerts != nil {
 		in, out := &in.TrustedCerts, &out.TrustedCerts
@@ -338,6 +394,11 @@ func (in *CheClusterDevEnvironments) DeepCopyInto(out *CheClusterDevEnvironments
 		*out = new(ContainerRunConfiguration)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PodSecurityAdmission != nil {
+		in, out := &in.PodSecurityAdmission, &out.PodSecurityAdmission
+		*out = new(PodSecurityAdmission)
+		**out = **in
+	}
 	if in.ServiceAccountTokens != nil {
 		in, out := &in.ServiceAccountTokens, &out.ServiceAccountTokens
 		*out = make([]v1alpha1.ServiceAccountToken, len(*in))
@@ -407,6 +468,23 @@ func (in *CheClusterDevEnvironments) DeepCopyInto(out *CheClusterDevEnvironments
 		*out = new(CliActivityTrackerConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Profiles != nil {
+		in, out := &in.Profiles, &out.Profiles
+		*out = make([]DevEnvironmentProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ScheduledShutdown != nil {
+		in, out := &in.ScheduledShutdown, &out.ScheduledShutdown
+		*out = new(WorkspaceScheduledShutdown)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RetentionPolicy != nil {
+		in, out := &in.RetentionPolicy, &out.RetentionPolicy
+		*out = new(WorkspaceRetentionPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheClusterDevEnvironments.
@@ -495,7 +573,12 @@ func (in *CheClusterSpec) DeepCopyInto(out *CheClusterSpec) {
 	in.Components.DeepCopyInto(&out.Components)
 	in.GitServices.DeepCopyInto(&out.GitServices)
 	in.Networking.DeepCopyInto(&out.Networking)
-	out.ContainerRegistry = in.ContainerRegistry
+	in.ContainerRegistry.DeepCopyInto(&out.ContainerRegistry)
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(CheClusterUpgrade)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheClusterSpec.
@@ -546,6 +629,60 @@ func (in *CheClusterSpecNetworking) DeepCopy() *CheClusterSpecNetworking {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CheClusterStatus) DeepCopyInto(out *CheClusterStatus) {
 	*out = *in
+	if in.ResolvedImages != nil {
+		in, out := &in.ResolvedImages, &out.ResolvedImages
+		*out = make([]ResolvedImage, len(*in))
+		copy(*out, *in)
+	}
+	if in.ImageDigestMirrors != nil {
+		in, out := &in.ImageDigestMirrors, &out.ImageDigestMirrors
+		*out = make([]ImageMirror, len(*in))
+		copy(*out, *in)
+	}
+	if in.OpenVSXExtensions != nil {
+		in, out := &in.OpenVSXExtensions, &out.OpenVSXExtensions
+		*out = make([]OpenVSXExtensionStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.OpenVSXBackup != nil {
+		in, out := &in.OpenVSXBackup, &out.OpenVSXBackup
+		*out = new(OpenVSXBackupStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExternalRegistries != nil {
+		in, out := &in.ExternalRegistries, &out.ExternalRegistries
+		*out = make([]ExternalRegistryStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ScheduledShutdown != nil {
+		in, out := &in.ScheduledShutdown, &out.ScheduledShutdown
+		*out = new(ScheduledShutdownStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Upgrade != nil {
+		in, out := &in.Upgrade, &out.Upgrade
+		*out = new(UpgradeStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Migrations != nil {
+		in, out := &in.Migrations, &out.Migrations
+		*out = new(MigrationsStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.UserNamespacesReconciliation != nil {
+		in, out := &in.UserNamespacesReconciliation, &out.UserNamespacesReconciliation
+		*out = new(UserNamespacesReconciliationStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheClusterStatus.
@@ -558,6 +695,21 @@ func (in *CheClusterStatus) DeepCopy() *CheClusterStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CheClusterUpgrade) DeepCopyInto(out *CheClusterUpgrade) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheClusterUpgrade.
+func (in *CheClusterUpgrade) DeepCopy() *CheClusterUpgrade {
+	if in == nil {
+		return nil
+	}
+	out := new(CheClusterUpgrade)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CheClusterValidator) DeepCopyInto(out *CheClusterValidator) {
 	*out = *in
@@ -615,6 +767,46 @@ func (in *CheServer) DeepCopy() *CheServer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClaimMapping) DeepCopyInto(out *ClaimMapping) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClaimMapping.
+func (in *ClaimMapping) DeepCopy() *ClaimMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(ClaimMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClaimMappings) DeepCopyInto(out *ClaimMappings) {
+	*out = *in
+	if in.Username != nil {
+		in, out := &in.Username, &out.Username
+		*out = new(ClaimMapping)
+		**out = **in
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = new(ClaimMapping)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClaimMappings.
+func (in *ClaimMappings) DeepCopy() *ClaimMappings {
+	if in == nil {
+		return nil
+	}
+	out := new(ClaimMappings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CliActivityTrackerConfig) DeepCopyInto(out *CliActivityTrackerConfig) {
 	*out = *in
@@ -799,6 +991,25 @@ func (in *DefaultNamespace) DeepCopyInto(out *DefaultNamespace) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Provisioning != nil {
+		in, out := &in.Provisioning, &out.Provisioning
+		*out = new(NamespaceProvisioning)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultNamespace.
@@ -879,6 +1090,75 @@ func (in *DevEnvironmentNetworking) DeepCopy() *DevEnvironmentNetworking {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DevEnvironmentProfile) DeepCopyInto(out *DevEnvironmentProfile) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ResourceQuota != nil {
+		in, out := &in.ResourceQuota, &out.ResourceQuota
+		*out = new(v1.ResourceQuotaSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LimitRange != nil {
+		in, out := &in.LimitRange, &out.LimitRange
+		*out = new(v1.LimitRangeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DefaultContainerResources != nil {
+		in, out := &in.DefaultContainerResources, &out.DefaultContainerResources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ContainerResourceCaps != nil {
+		in, out := &in.ContainerResourceCaps, &out.ContainerResourceCaps
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DisableContainerBuildCapabilities != nil {
+		in, out := &in.DisableContainerBuildCapabilities, &out.DisableContainerBuildCapabilities
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DisableContainerRunCapabilities != nil {
+		in, out := &in.DisableContainerRunCapabilities, &out.DisableContainerRunCapabilities
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DevEnvironmentProfile.
+func (in *DevEnvironmentProfile) DeepCopy() *DevEnvironmentProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(DevEnvironmentProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DevWorkspace) DeepCopyInto(out *DevWorkspace) {
 	*out = *in
@@ -937,6 +1217,7 @@ func (in *EditorDownloadUrl) DeepCopy() *EditorDownloadUrl {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalDevfileRegistry) DeepCopyInto(out *ExternalDevfileRegistry) {
 	*out = *in
+	out.ExternalRegistryOptions = in.ExternalRegistryOptions
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalDevfileRegistry.
@@ -952,6 +1233,7 @@ func (in *ExternalDevfileRegistry) DeepCopy() *ExternalDevfileRegistry {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalPluginRegistry) DeepCopyInto(out *ExternalPluginRegistry) {
 	*out = *in
+	out.ExternalRegistryOptions = in.ExternalRegistryOptions
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalPluginRegistry.
@@ -964,6 +1246,40 @@ func (in *ExternalPluginRegistry) DeepCopy() *ExternalPluginRegistry {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalRegistryOptions) DeepCopyInto(out *ExternalRegistryOptions) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalRegistryOptions.
+func (in *ExternalRegistryOptions) DeepCopy() *ExternalRegistryOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalRegistryOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalRegistryStatus) DeepCopyInto(out *ExternalRegistryStatus) {
+	*out = *in
+	if in.LastProbeTime != nil {
+		in, out := &in.LastProbeTime, &out.LastProbeTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalRegistryStatus.
+func (in *ExternalRegistryStatus) DeepCopy() *ExternalRegistryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalRegistryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalTLSConfig) DeepCopyInto(out *ExternalTLSConfig) {
 	*out = *in
@@ -1076,96 +1392,311 @@ func (in *GitLabService) DeepCopy() *GitLabService {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Icon) DeepCopyInto(out *Icon) {
+func (in *GroupRoles) DeepCopyInto(out *GroupRoles) {
 	*out = *in
+	if in.ClusterRoles != nil {
+		in, out := &in.ClusterRoles, &out.ClusterRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Icon.
-func (in *Icon) DeepCopy() *Icon {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GroupRoles.
+func (in *GroupRoles) DeepCopy() *GroupRoles {
 	if in == nil {
 		return nil
 	}
-	out := new(Icon)
+	out := new(GroupRoles)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImagePuller) DeepCopyInto(out *ImagePuller) {
+func (in *Icon) DeepCopyInto(out *Icon) {
 	*out = *in
-	out.Spec = in.Spec
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePuller.
-func (in *ImagePuller) DeepCopy() *ImagePuller {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Icon.
+func (in *Icon) DeepCopy() *Icon {
 	if in == nil {
 		return nil
 	}
-	out := new(ImagePuller)
+	out := new(Icon)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeRbacProxy) DeepCopyInto(out *KubeRbacProxy) {
+func (in *ImageMirror) DeepCopyInto(out *ImageMirror) {
 	*out = *in
-	if in.LogLevel != nil {
-		in, out := &in.LogLevel, &out.LogLevel
-		*out = new(int32)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeRbacProxy.
-func (in *KubeRbacProxy) DeepCopy() *KubeRbacProxy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageMirror.
+func (in *ImageMirror) DeepCopy() *ImageMirror {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeRbacProxy)
+	out := new(ImageMirror)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkPolicy) DeepCopyInto(out *NetworkPolicy) {
+func (in *ImagePuller) DeepCopyInto(out *ImagePuller) {
 	*out = *in
-	if in.Enabled != nil {
-		in, out := &in.Enabled, &out.Enabled
-		*out = new(bool)
-		**out = **in
+	out.Spec = in.Spec
+	if in.WorkspaceImages != nil {
+		in, out := &in.WorkspaceImages, &out.WorkspaceImages
+		*out = new(ImagePullerWorkspaceImages)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BuiltIn != nil {
+		in, out := &in.BuiltIn, &out.BuiltIn
+		*out = new(BuiltInImagePuller)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicy.
-func (in *NetworkPolicy) DeepCopy() *NetworkPolicy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePuller.
+func (in *ImagePuller) DeepCopy() *ImagePuller {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkPolicy)
+	out := new(ImagePuller)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OAuthProxy) DeepCopyInto(out *OAuthProxy) {
+func (in *ImagePullerWorkspaceImages) DeepCopyInto(out *ImagePullerWorkspaceImages) {
 	*out = *in
-	if in.CookieExpireSeconds != nil {
-		in, out := &in.CookieExpireSeconds, &out.CookieExpireSeconds
+	if in.MaxImages != nil {
+		in, out := &in.MaxImages, &out.MaxImages
 		*out = new(int32)
 		**out = **in
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OAuthProxy.
-func (in *OAuthProxy) DeepCopy() *OAuthProxy {
-	if in == nil {
-		return nil
+	if in.MaxTotalSize != nil {
+		in, out := &in.MaxTotalSize, &out.MaxTotalSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.UnknownImageSize != nil {
+		in, out := &in.UnknownImageSize, &out.UnknownImageSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.DaysSinceLastStart != nil {
+		in, out := &in.DaysSinceLastStart, &out.DaysSinceLastStart
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePullerWorkspaceImages.
+func (in *ImagePullerWorkspaceImages) DeepCopy() *ImagePullerWorkspaceImages {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePullerWorkspaceImages)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeRbacProxy) DeepCopyInto(out *KubeRbacProxy) {
+	*out = *in
+	if in.LogLevel != nil {
+		in, out := &in.LogLevel, &out.LogLevel
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeRbacProxy.
+func (in *KubeRbacProxy) DeepCopy() *KubeRbacProxy {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeRbacProxy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MigrationRecord) DeepCopyInto(out *MigrationRecord) {
+	*out = *in
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationRecord.
+func (in *MigrationRecord) DeepCopy() *MigrationRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(MigrationRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MigrationsStatus) DeepCopyInto(out *MigrationsStatus) {
+	*out = *in
+	if in.Completed != nil {
+		in, out := &in.Completed, &out.Completed
+		*out = make([]MigrationRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Pending != nil {
+		in, out := &in.Pending, &out.Pending
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MigrationsStatus.
+func (in *MigrationsStatus) DeepCopy() *MigrationsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MigrationsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceProvisioning) DeepCopyInto(out *NamespaceProvisioning) {
+	*out = *in
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceProvisioning.
+func (in *NamespaceProvisioning) DeepCopy() *NamespaceProvisioning {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceProvisioning)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicy) DeepCopyInto(out *NetworkPolicy) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicy.
+func (in *NetworkPolicy) DeepCopy() *NetworkPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OAuthProxy) DeepCopyInto(out *OAuthProxy) {
+	*out = *in
+	if in.CookieExpireSeconds != nil {
+		in, out := &in.CookieExpireSeconds, &out.CookieExpireSeconds
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OAuthProxy.
+func (in *OAuthProxy) DeepCopy() *OAuthProxy {
+	if in == nil {
+		return nil
 	}
 	out := new(OAuthProxy)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenVSXBackup) DeepCopyInto(out *OpenVSXBackup) {
+	*out = *in
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(PVC)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.S3 != nil {
+		in, out := &in.S3, &out.S3
+		*out = new(OpenVSXBackupS3)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenVSXBackup.
+func (in *OpenVSXBackup) DeepCopy() *OpenVSXBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenVSXBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenVSXBackupS3) DeepCopyInto(out *OpenVSXBackupS3) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenVSXBackupS3.
+func (in *OpenVSXBackupS3) DeepCopy() *OpenVSXBackupS3 {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenVSXBackupS3)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenVSXBackupStatus) DeepCopyInto(out *OpenVSXBackupStatus) {
+	*out = *in
+	if in.LastSuccessfulBackupTime != nil {
+		in, out := &in.LastSuccessfulBackupTime, &out.LastSuccessfulBackupTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenVSXBackupStatus.
+func (in *OpenVSXBackupStatus) DeepCopy() *OpenVSXBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenVSXBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpenVSXDatabase) DeepCopyInto(out *OpenVSXDatabase) {
 	*out = *in
@@ -1179,6 +1710,11 @@ func (in *OpenVSXDatabase) DeepCopyInto(out *OpenVSXDatabase) {
 		*out = new(PVC)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ExternalConnectionSecretName != nil {
+		in, out := &in.ExternalConnectionSecretName, &out.ExternalConnectionSecretName
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenVSXDatabase.
@@ -1191,6 +1727,41 @@ func (in *OpenVSXDatabase) DeepCopy() *OpenVSXDatabase {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenVSXExtensionStatus) DeepCopyInto(out *OpenVSXExtensionStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenVSXExtensionStatus.
+func (in *OpenVSXExtensionStatus) DeepCopy() *OpenVSXExtensionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenVSXExtensionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenVSXExtensionsMirror) DeepCopyInto(out *OpenVSXExtensionsMirror) {
+	*out = *in
+	if in.Extensions != nil {
+		in, out := &in.Extensions, &out.Extensions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenVSXExtensionsMirror.
+func (in *OpenVSXExtensionsMirror) DeepCopy() *OpenVSXExtensionsMirror {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenVSXExtensionsMirror)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpenVSXRegistry) DeepCopyInto(out *OpenVSXRegistry) {
 	*out = *in
@@ -1209,6 +1780,16 @@ func (in *OpenVSXRegistry) DeepCopyInto(out *OpenVSXRegistry) {
 		*out = new(OpenVSXDatabase)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ExtensionsMirror != nil {
+		in, out := &in.ExtensionsMirror, &out.ExtensionsMirror
+		*out = new(OpenVSXExtensionsMirror)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(OpenVSXBackup)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenVSXRegistry.
@@ -1321,6 +1902,21 @@ func (in *PluginRegistry) DeepCopy() *PluginRegistry {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSecurityAdmission) DeepCopyInto(out *PodSecurityAdmission) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSecurityAdmission.
+func (in *PodSecurityAdmission) DeepCopy() *PodSecurityAdmission {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSecurityAdmission)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodSecurityContext) DeepCopyInto(out *PodSecurityContext) {
 	*out = *in
@@ -1366,6 +1962,21 @@ func (in *Proxy) DeepCopy() *Proxy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResolvedImage) DeepCopyInto(out *ResolvedImage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResolvedImage.
+func (in *ResolvedImage) DeepCopy() *ResolvedImage {
+	if in == nil {
+		return nil
+	}
+	out := new(ResolvedImage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceList) DeepCopyInto(out *ResourceList) {
 	*out = *in
@@ -1416,6 +2027,25 @@ func (in *ResourceRequirements) DeepCopy() *ResourceRequirements {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduledShutdownStatus) DeepCopyInto(out *ScheduledShutdownStatus) {
+	*out = *in
+	if in.ActiveUntil != nil {
+		in, out := &in.ActiveUntil, &out.ActiveUntil
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledShutdownStatus.
+func (in *ScheduledShutdownStatus) DeepCopy() *ScheduledShutdownStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduledShutdownStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServerMetrics) DeepCopyInto(out *ServerMetrics) {
 	*out = *in
@@ -1466,6 +2096,52 @@ func (in *TrustedCerts) DeepCopy() *TrustedCerts {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeRecord) DeepCopyInto(out *UpgradeRecord) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeRecord.
+func (in *UpgradeRecord) DeepCopy() *UpgradeRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeStatus) DeepCopyInto(out *UpgradeStatus) {
+	*out = *in
+	in.UpgradeRecord.DeepCopyInto(&out.UpgradeRecord)
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]UpgradeRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeStatus.
+func (in *UpgradeStatus) DeepCopy() *UpgradeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserConfiguration) DeepCopyInto(out *UserConfiguration) {
 	*out = *in
@@ -1474,6 +2150,13 @@ func (in *UserConfiguration) DeepCopyInto(out *UserConfiguration) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.GroupRoles != nil {
+		in, out := &in.GroupRoles, &out.GroupRoles
+		*out = make([]GroupRoles, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserConfiguration.
@@ -1486,6 +2169,29 @@ func (in *UserConfiguration) DeepCopy() *UserConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserNamespacesReconciliationStatus) DeepCopyInto(out *UserNamespacesReconciliationStatus) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserNamespacesReconciliationStatus.
+func (in *UserNamespacesReconciliationStatus) DeepCopy() *UserNamespacesReconciliationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UserNamespacesReconciliationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkspaceDefaultPlugins) DeepCopyInto(out *WorkspaceDefaultPlugins) {
 	*out = *in
@@ -1506,6 +2212,71 @@ func (in *WorkspaceDefaultPlugins) DeepCopy() *WorkspaceDefaultPlugins {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceRetentionPolicy) DeepCopyInto(out *WorkspaceRetentionPolicy) {
+	*out = *in
+	if in.DaysOfInactivityBeforeWarning != nil {
+		in, out := &in.DaysOfInactivityBeforeWarning, &out.DaysOfInactivityBeforeWarning
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DaysOfUptimeBeforeStop != nil {
+		in, out := &in.DaysOfUptimeBeforeStop, &out.DaysOfUptimeBeforeStop
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DaysOfInactivityBeforeDeletion != nil {
+		in, out := &in.DaysOfInactivityBeforeDeletion, &out.DaysOfInactivityBeforeDeletion
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DaysOfSnapshotRetention != nil {
+		in, out := &in.DaysOfSnapshotRetention, &out.DaysOfSnapshotRetention
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DeleteNamespaces != nil {
+		in, out := &in.DeleteNamespaces, &out.DeleteNamespaces
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceRetentionPolicy.
+func (in *WorkspaceRetentionPolicy) DeepCopy() *WorkspaceRetentionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceRetentionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceScheduledShutdown) DeepCopyInto(out *WorkspaceScheduledShutdown) {
+	*out = *in
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = make([]WorkspaceShutdownWindow, len(*in))
+		copy(*out, *in)
+	}
+	if in.MinutesOfWarningBeforeShutdown != nil {
+		in, out := &in.MinutesOfWarningBeforeShutdown, &out.MinutesOfWarningBeforeShutdown
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceScheduledShutdown.
+func (in *WorkspaceScheduledShutdown) DeepCopy() *WorkspaceScheduledShutdown {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceScheduledShutdown)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkspaceSecurityConfig) DeepCopyInto(out *WorkspaceSecurityConfig) {
 	*out = *in
@@ -1531,6 +2302,22 @@ func (in *WorkspaceSecurityConfig) DeepCopy() *WorkspaceSecurityConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceShutdownWindow) DeepCopyInto(out *WorkspaceShutdownWindow) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceShutdownWindow.
+func (in *WorkspaceShutdownWindow) DeepCopy() *WorkspaceShutdownWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceShutdownWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkspaceStorage) DeepCopyInto(out *WorkspaceStorage) {
 	*out = *in
@@ -1544,6 +2331,11 @@ func (in *WorkspaceStorage) DeepCopyInto(out *WorkspaceStorage) {
 		*out = new(PVC)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(WorkspaceStorageBackup)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceStorage.
@@ -1555,3 +2347,23 @@ func (in *WorkspaceStorage) DeepCopy() *WorkspaceStorage {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceStorageBackup) DeepCopyInto(out *WorkspaceStorageBackup) {
+	*out = *in
+	if in.MaxSnapshots != nil {
+		in, out := &in.MaxSnapshots, &out.MaxSnapshots
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceStorageBackup.
+func (in *WorkspaceStorageBackup) DeepCopy() *WorkspaceStorageBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceStorageBackup)
+	in.DeepCopyInto(out)
+	return out
+}
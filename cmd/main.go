@@ -16,6 +16,8 @@ import (
 	"flag"
 	"os"
 	"time"
+	// Time zones of the scheduled workspaces shutdown must be resolvable regardless of the image content
+	_ "time/tzdata"
 
 	dwInfra "github.com/devfile/devworkspace-operator/pkg/infrastructure"
 	"github.com/eclipse-che/che-operator/pkg/common/infrastructure"
@@ -34,13 +36,18 @@ import (
 
 	"github.com/eclipse-che/che-operator/controllers/devworkspace/solver"
 	"github.com/eclipse-che/che-operator/controllers/usernamespace"
+	"github.com/eclipse-che/che-operator/controllers/workspacebackup"
+	"github.com/eclipse-che/che-operator/controllers/workspaceretention"
+	"github.com/eclipse-che/che-operator/controllers/workspaceshutdown"
 
 	securityv1 "github.com/openshift/api/security/v1"
 
+	devfileApi "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
 	dwoApi "github.com/devfile/devworkspace-operator/apis/controller/v1alpha1"
 	"go.uber.org/zap/zapcore"
 
 	"github.com/eclipse-che/che-operator/pkg/common/constants"
+	k8sclient "github.com/eclipse-che/che-operator/pkg/common/k8s-client"
 	defaults "github.com/eclipse-che/che-operator/pkg/common/operator-defaults"
 	"github.com/eclipse-che/che-operator/pkg/common/signal"
 	"github.com/eclipse-che/che-operator/pkg/common/test"
@@ -57,6 +64,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	osruntime "runtime"
@@ -215,6 +223,9 @@ func main() {
 	}
 
 	config := ctrl.GetConfigOrDie()
+	// The warnings returned by the API server are logged, unless they are collected by the caller,
+	// for example to report the pods violating the Pod Security Admission level of a user namespace
+	config.WarningHandlerWithContext = k8sclient.NewWarningHandler(log.NewKubeAPIWarningLogger(log.KubeAPIWarningLoggerOptions{}))
 
 	discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
 	if err != nil {
@@ -232,6 +243,10 @@ func main() {
 		setupLog.Error(err, "Dev Workspace Operator is not installed")
 		os.Exit(1)
 	}
+	if err := devfileApi.AddToScheme(scheme); err != nil {
+		setupLog.Error(err, "Dev Workspace API is not installed")
+		os.Exit(1)
+	}
 
 	cacheFunction, err := getCacheFunc()
 	if err != nil {
@@ -286,9 +301,14 @@ func main() {
 		os.Exit(1)
 	}
 
-	namespacecache := namespacecache.NewNamespaceCache(nonCachingClient)
+	// Namespaces are read from the manager cache, the entries are kept up to date by a Namespace informer
+	namespacecache := namespacecache.NewNamespaceCache(mgr.GetClient())
+	if err = namespacecache.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to set up namespace cache")
+		os.Exit(1)
+	}
 
-	userNamespaceReconciler := usernamespace.NewCheUserNamespaceReconciler(mgr.GetClient(), nonCachingClient, mgr.GetScheme(), namespacecache)
+	userNamespaceReconciler := usernamespace.NewCheUserNamespaceReconciler(mgr.GetClient(), nonCachingClient, mgr.GetScheme(), namespacecache, mgr.GetEventRecorderFor("usernamespace"))
 	if err = userNamespaceReconciler.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to set up controller", "controller", "CheUserReconciler")
 		os.Exit(1)
@@ -300,6 +320,24 @@ func main() {
 		os.Exit(1)
 	}
 
+	workspaceShutdownReconciler := workspaceshutdown.NewWorkspaceShutdownReconciler(mgr.GetClient(), namespacecache, mgr.GetEventRecorderFor("workspace-shutdown"))
+	if err = workspaceShutdownReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to set up controller", "controller", "WorkspaceShutdownReconciler")
+		os.Exit(1)
+	}
+
+	workspaceRetentionReconciler := workspaceretention.NewWorkspaceRetentionReconciler(mgr.GetClient(), nonCachingClient, namespacecache, mgr.GetEventRecorderFor("workspace-retention"))
+	if err = workspaceRetentionReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to set up controller", "controller", "WorkspaceRetentionReconciler")
+		os.Exit(1)
+	}
+
+	workspaceBackupReconciler := workspacebackup.NewWorkspaceBackupReconciler(mgr.GetClient(), nonCachingClient, namespacecache, mgr.GetEventRecorderFor("workspace-backup"))
+	if err = workspaceBackupReconciler.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to set up controller", "controller", "WorkspaceBackupReconciler")
+		os.Exit(1)
+	}
+
 	terminationPeriod := int64(20)
 	if !test.IsTestMode() {
 		namespace, err := infrastructure.GetOperatorNamespace()
@@ -314,6 +352,12 @@ func main() {
 			setupLog.Error(err, "unable to create webhook", "webhook", "CheCluster")
 			os.Exit(1)
 		}
+
+		// The groups of the users are recorded for the group roles, see usernamespace.UserGroupsRecorder
+		mgr.GetWebhookServer().Register(
+			usernamespace.UserGroupsWebhookPath,
+			&webhook.Admission{Handler: usernamespace.NewUserGroupsRecorder(mgr.GetClient(), namespacecache)},
+		)
 	}
 
 	// +kubebuilder:scaffold:builder
@@ -351,12 +395,18 @@ func getCacheFunc() (cache.NewCacheFunc, error) {
 		&appsv1.Deployment{}: {
 			Label: partOfEclipseChe,
 		},
+		&appsv1.DaemonSet{}: {
+			Label: partOfEclipseChe,
+		},
 		&corev1.Pod{}: {
 			Label: labels.NewSelector().Add(*partOfCheOrDWO),
 		},
 		&batchv1.Job{}: {
 			Label: partOfEclipseChe,
 		},
+		&batchv1.CronJob{}: {
+			Label: partOfEclipseChe,
+		},
 		&corev1.Service{}: {
 			Label: partOfEclipseChe,
 		},
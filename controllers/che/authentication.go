@@ -16,7 +16,9 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"strings"
 
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
 	"github.com/eclipse-che/che-operator/pkg/common/chetypes"
 	"github.com/eclipse-che/che-operator/pkg/common/infrastructure"
 	configv1 "github.com/openshift/api/config/v1"
@@ -46,6 +48,9 @@ func ResolveAuthentication(ctx *chetypes.DeployContext) (*chetypes.Authenticatio
 		ClientId: ctx.CheCluster.Spec.Networking.Auth.OAuthClientName,
 	}
 
+	// typed claim mappings take precedence over the extra properties
+	applyClaimMappings(authentication, ctx.CheCluster.Spec.Networking.Auth.ClaimMappings)
+
 	// must be outside main `if` condition
 	if ctx.CheCluster.Spec.Networking.Auth.OAuthSecret != "" {
 		// `OAuthSecret` can be a Kubernetes Secret name in CheCluster namespace
@@ -72,8 +77,13 @@ func ResolveAuthentication(ctx *chetypes.DeployContext) (*chetypes.Authenticatio
 			return nil, fmt.Errorf("no OIDC providers configured")
 		}
 
+		// Che server and the gateway accept tokens of a single issuer,
+		// several identity providers must be federated by a single brokering one.
 		if len(clusterAuthentication.Spec.OIDCProviders) != 1 {
-			return nil, fmt.Errorf("multiple OIDC providers configured, expected exactly one")
+			return nil, fmt.Errorf(
+				"multiple OIDC providers configured, expected exactly one: %s",
+				strings.Join(getOIDCProviderNames(clusterAuthentication.Spec.OIDCProviders), ", "),
+			)
 		}
 
 		oidcProvider := clusterAuthentication.Spec.OIDCProviders[0]
@@ -95,14 +105,14 @@ func ResolveAuthentication(ctx *chetypes.DeployContext) (*chetypes.Authenticatio
 		}
 
 		// username/groups claim mappings
-		if authentication.GroupsClaim == "" {
+		if authentication.GroupsClaim == "" && authentication.GroupsExpression == "" {
 			authentication.GroupsClaim = oidcProvider.ClaimMappings.Groups.Claim
 		}
 		if authentication.GroupsClaim != "" && authentication.GroupsPrefix == "" {
 			authentication.GroupsPrefix = oidcProvider.ClaimMappings.Groups.Prefix
 		}
 
-		if authentication.UsernameClaim == "" {
+		if authentication.UsernameClaim == "" && authentication.UsernameExpression == "" {
 			authentication.UsernameClaim = oidcProvider.ClaimMappings.Username.Claim
 		}
 		if authentication.UsernameClaim != "" && authentication.UsernamePrefix == "" {
@@ -141,6 +151,42 @@ func ResolveAuthentication(ctx *chetypes.DeployContext) (*chetypes.Authenticatio
 	return authentication, nil
 }
 
+// applyClaimMappings overrides the claim mappings with the ones defined in `spec.networking.auth.claimMappings`.
+// A claim and an expression are mutually exclusive, so setting one of them resets the other.
+func applyClaimMappings(authentication *chetypes.Authentication, claimMappings *chev2.ClaimMappings) {
+	if claimMappings == nil {
+		return
+	}
+
+	if claimMappings.Username != nil {
+		if claimMappings.Username.Claim != "" || claimMappings.Username.Expression != "" {
+			authentication.UsernameClaim = claimMappings.Username.Claim
+			authentication.UsernameExpression = claimMappings.Username.Expression
+		}
+		if claimMappings.Username.Prefix != "" {
+			authentication.UsernamePrefix = claimMappings.Username.Prefix
+		}
+	}
+
+	if claimMappings.Groups != nil {
+		if claimMappings.Groups.Claim != "" || claimMappings.Groups.Expression != "" {
+			authentication.GroupsClaim = claimMappings.Groups.Claim
+			authentication.GroupsExpression = claimMappings.Groups.Expression
+		}
+		if claimMappings.Groups.Prefix != "" {
+			authentication.GroupsPrefix = claimMappings.Groups.Prefix
+		}
+	}
+}
+
+func getOIDCProviderNames(oidcProviders []configv1.OIDCProvider) []string {
+	names := make([]string, 0, len(oidcProviders))
+	for _, oidcProvider := range oidcProviders {
+		names = append(names, oidcProvider.Name)
+	}
+	return names
+}
+
 func resolveClientSecretInOpenShiftConfigNamespace(secretName string, ctx *chetypes.DeployContext) ([]byte, error) {
 	secret := &corev1.Secret{}
 	err := ctx.ClusterAPI.NonCachingClient.Get(
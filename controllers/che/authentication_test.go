@@ -20,20 +20,23 @@ import (
 	"github.com/eclipse-che/che-operator/pkg/common/test"
 	"github.com/google/go-cmp/cmp"
 	configv1 "github.com/openshift/api/config/v1"
+	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type oidcAuthResult struct {
-	IssuerURL        string
-	IssuerCA         string
-	OIDCClientId     string
-	OIDCClientSecret string
-	UsernameClaim    string
-	UsernamePrefix   string
-	GroupsClaim      string
-	GroupsPrefix     string
+	IssuerURL          string
+	IssuerCA           string
+	OIDCClientId       string
+	OIDCClientSecret   string
+	UsernameClaim      string
+	UsernameExpression string
+	UsernamePrefix     string
+	GroupsClaim        string
+	GroupsExpression   string
+	GroupsPrefix       string
 }
 
 func TestResolveOIDCAuthentication(t *testing.T) {
@@ -506,14 +509,16 @@ func TestResolveOIDCAuthentication(t *testing.T) {
 			}
 
 			got := &oidcAuthResult{
-				IssuerURL:        auth.IssuerURL,
-				IssuerCA:         auth.IssuerCA,
-				OIDCClientId:     auth.ClientId,
-				OIDCClientSecret: string(auth.ClientSecret),
-				UsernameClaim:    auth.UsernameClaim,
-				UsernamePrefix:   auth.UsernamePrefix,
-				GroupsClaim:      auth.GroupsClaim,
-				GroupsPrefix:     auth.GroupsPrefix,
+				IssuerURL:          auth.IssuerURL,
+				IssuerCA:           auth.IssuerCA,
+				OIDCClientId:       auth.ClientId,
+				OIDCClientSecret:   string(auth.ClientSecret),
+				UsernameClaim:      auth.UsernameClaim,
+				UsernameExpression: auth.UsernameExpression,
+				UsernamePrefix:     auth.UsernamePrefix,
+				GroupsClaim:        auth.GroupsClaim,
+				GroupsExpression:   auth.GroupsExpression,
+				GroupsPrefix:       auth.GroupsPrefix,
 			}
 
 			if diff := cmp.Diff(tc.expectedAuth, got); diff != "" {
@@ -522,3 +527,30 @@ func TestResolveOIDCAuthentication(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveOIDCAuthenticationWithMultipleProviders(t *testing.T) {
+	infrastructure.InitializeForTesting(infrastructure.OpenShiftV4)
+	infrastructure.SetOpenShiftOAuthEnabledForTesting(false)
+	defer func() {
+		infrastructure.InitializeForTesting(infrastructure.OpenShiftV4)
+		infrastructure.SetOpenShiftOAuthEnabledForTesting(true)
+	}()
+
+	clusterAuthentication := &configv1.Authentication{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cluster",
+		},
+		Spec: configv1.AuthenticationSpec{
+			Type: configv1.AuthenticationTypeOIDC,
+			OIDCProviders: []configv1.OIDCProvider{
+				{Name: "employees", Issuer: configv1.TokenIssuer{URL: "https://employees.example.com"}},
+				{Name: "partners", Issuer: configv1.TokenIssuer{URL: "https://partners.example.com"}},
+			},
+		},
+	}
+
+	ctx := test.NewCtxBuilder().WithObjects(clusterAuthentication).Build()
+
+	_, err := ResolveAuthentication(ctx)
+	assert.ErrorContains(t, err, "multiple OIDC providers configured, expected exactly one: employees, partners")
+}
@@ -21,14 +21,18 @@ import (
 	k8sclient "github.com/eclipse-che/che-operator/pkg/common/k8s-client"
 	"github.com/eclipse-che/che-operator/pkg/common/reconciler"
 	"github.com/eclipse-che/che-operator/pkg/deploy/metrics"
+	namespaceprovisioning "github.com/eclipse-che/che-operator/pkg/deploy/namespace-provisioning"
 	"github.com/eclipse-che/che-operator/pkg/deploy/networkpolicies"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/eclipse-che/che-operator/pkg/deploy/devworkspace"
+	imagemirrors "github.com/eclipse-che/che-operator/pkg/deploy/image-mirrors"
 	imagepuller "github.com/eclipse-che/che-operator/pkg/deploy/image-puller"
 
 	editorsdefinitions "github.com/eclipse-che/che-operator/pkg/deploy/editors-definitions"
@@ -42,6 +46,7 @@ import (
 	containerbuild "github.com/eclipse-che/che-operator/pkg/deploy/container-capabilities"
 	"github.com/eclipse-che/che-operator/pkg/deploy/dashboard"
 	"github.com/eclipse-che/che-operator/pkg/deploy/devfileregistry"
+	externalregistries "github.com/eclipse-che/che-operator/pkg/deploy/external-registries"
 	"github.com/eclipse-che/che-operator/pkg/deploy/gateway"
 	identityprovider "github.com/eclipse-che/che-operator/pkg/deploy/identity-provider"
 	"github.com/eclipse-che/che-operator/pkg/deploy/migration"
@@ -88,6 +93,8 @@ type CheClusterReconciler struct {
 	reconcilerManager *reconciler.ReconcilerManager
 	// the namespace to which to limit the reconciliation. If empty, all namespaces are considered
 	namespace string
+	// requeueEvents triggers the reconciliation of the reconcilers that asked to be requeued
+	requeueEvents chan event.GenericEvent
 }
 
 // NewReconciler returns a new CheClusterReconciler
@@ -103,14 +110,18 @@ func NewReconciler(
 	// order does matter
 	if !test.IsTestMode() {
 		reconcilerManager.AddReconciler(migration.NewMigrator())
-		reconcilerManager.AddReconciler(migration.NewCheClusterDefaultsCleaner())
 		reconcilerManager.AddReconciler(NewCheClusterValidator())
+		// pod templates of the previous version must be recorded before any component is upgraded
+		reconcilerManager.AddReconciler(deploy.NewUpgradeReconciler())
 	}
 
+	// image mirrors must be loaded before syncing any component
+	reconcilerManager.AddReconciler(imagemirrors.NewImageMirrorsReconciler())
 	reconcilerManager.AddReconciler(tls.NewCertificatesReconciler())
 	reconcilerManager.AddReconciler(tls.NewTlsSecretReconciler())
 	reconcilerManager.AddReconciler(devworkspace.NewDevWorkspaceConfigReconciler())
 	reconcilerManager.AddReconciler(rbac.NewGatewayPermissionsReconciler())
+	reconcilerManager.AddReconciler(namespaceprovisioning.NewNamespaceProvisioningReconciler())
 
 	if infrastructure.IsOpenShift() {
 		reconcilerManager.AddReconciler(networkpolicies.NewNetworkPoliciesReconciler())
@@ -126,8 +137,12 @@ func NewReconciler(
 	}
 	reconcilerManager.AddReconciler(devfileregistry.NewDevfileRegistryReconciler())
 	reconcilerManager.AddReconciler(pluginregistry.NewPluginRegistryReconciler())
+	// probes the external registries, including the default ones added by the devfile registry reconciler
+	reconcilerManager.AddReconciler(externalregistries.NewExternalRegistriesReconciler())
 	reconcilerManager.AddReconciler(openvsx.NewOpenVSXSecretReconciler())
 	reconcilerManager.AddReconciler(openvsxdatabase.NewOpenVSXDatabaseReconciler())
+	// the server is scaled down while a backup is being restored, so the backup must be synced before it
+	reconcilerManager.AddReconciler(openvsx.NewOpenVSXBackupReconciler())
 	reconcilerManager.AddReconciler(openvsxserver.NewOpenVSXServerReconciler())
 	reconcilerManager.AddReconciler(editorsdefinitions.NewEditorsDefinitionsReconciler())
 	reconcilerManager.AddReconciler(dashboard.NewDashboardReconciler())
@@ -153,6 +168,7 @@ func NewReconciler(
 		discoveryClient:   discoveryClient,
 		namespace:         namespace,
 		reconcilerManager: reconcilerManager,
+		requeueEvents:     make(chan event.GenericEvent, 1),
 	}
 }
 
@@ -209,11 +225,24 @@ func (r *CheClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	}
 
 	// Use controller.TypedOptions to allow to configure 2 controllers for same object being reconciled
-	return bld.WithOptions(
+	if err := bld.WithOptions(
 		controller.TypedOptions[reconcile.Request]{
 			SkipNameValidation: ptr.To(true),
 			UsePriorityQueue:   ptr.To(false),
-		}).Complete(r)
+		}).Complete(r); err != nil {
+		return err
+	}
+
+	// The reconcilers that asked to be requeued are reconciled by a separate controller,
+	// so that the whole chain is not reconciled periodically
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("checluster-requeue").
+		WatchesRawSource(source.Channel(r.requeueEvents, &handler.EnqueueRequestForObject{})).
+		WithOptions(
+			controller.TypedOptions[reconcile.Request]{
+				SkipNameValidation: ptr.To(true),
+			}).
+		Complete(reconcile.Func(r.reconcileRequeued))
 }
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -224,6 +253,81 @@ func (r *CheClusterReconciler) SetupWithManager(mgr ctrl.Manager) error {
 func (r *CheClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	_ = r.Log.WithValues("checluster", req.NamespacedName)
 
+	deployContext, result, err := r.getDeployContext(ctx, req)
+	if deployContext == nil {
+		return result, err
+	}
+
+	if deployContext.CheCluster.DeletionTimestamp.IsZero() {
+		result, done, err := r.reconcilerManager.ReconcileAll(deployContext)
+		if done {
+			// Clean up status if so
+			if err := deploy.SetStatusDetails(deployContext, "", ""); err != nil {
+				return ctrl.Result{}, err
+			}
+
+			if r.reconcilerManager.HasRequeued() {
+				r.triggerRequeue(deployContext.CheCluster)
+			}
+
+			r.Log.Info("Successfully reconciled.")
+			return ctrl.Result{}, nil
+		} else {
+			if err != nil {
+				errMsg := "Failed to reconcile CheCluster resources. The installation is not completed. Check operator logs for details."
+				r.Log.Error(err, errMsg)
+
+				if err := deploy.SetStatusDetails(deployContext, constants.InstallOrUpdateFailed, errMsg); err != nil {
+					return ctrl.Result{}, err
+				}
+			}
+
+			return result, err
+		}
+	} else {
+		deployContext.CheCluster.Status.ChePhase = chev2.ClusterPhasePendingDeletion
+		if err = deploy.UpdateCheCRStatus(deployContext, "ChePhase", chev2.ClusterPhasePendingDeletion); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		done := r.reconcilerManager.FinalizeAll(deployContext)
+		if done {
+			// Removes remaining finalizers, which prevent CheCluster from deletion
+			if err := deploy.CleanUpAllFinalizers(deployContext); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{}, nil
+		} else {
+			return ctrl.Result{RequeueAfter: time.Second}, nil
+		}
+	}
+}
+
+// reconcileRequeued reconciles only the reconcilers that asked to be requeued once their time has come.
+func (r *CheClusterReconciler) reconcileRequeued(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	deployContext, result, err := r.getDeployContext(ctx, req)
+	if deployContext == nil {
+		return result, err
+	}
+
+	if !deployContext.CheCluster.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	return r.reconcilerManager.ReconcileRequeued(deployContext)
+}
+
+func (r *CheClusterReconciler) triggerRequeue(checluster *chev2.CheCluster) {
+	select {
+	case r.requeueEvents <- event.GenericEvent{Object: checluster}:
+	default:
+		// Already triggered
+	}
+}
+
+// getDeployContext returns the context to reconcile the CheCluster with,
+// or nil along with the result to return if the CheCluster cannot be reconciled yet.
+func (r *CheClusterReconciler) getDeployContext(ctx context.Context, req ctrl.Request) (*chetypes.DeployContext, ctrl.Result, error) {
 	clusterAPI := chetypes.ClusterAPI{
 		Client:                  r.client,
 		NonCachingClient:        r.nonCachedClient,
@@ -237,10 +341,10 @@ func (r *CheClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	checluster, err := deploy.FindCheClusterCRInNamespace(r.client, req.Namespace)
 	if checluster == nil {
 		r.Log.Info("CheCluster Custom Resource not found.")
-		return ctrl.Result{}, nil
+		return nil, ctrl.Result{}, nil
 	} else if err != nil {
 		// Error reading the object - requeue the request.
-		return ctrl.Result{}, err
+		return nil, ctrl.Result{}, err
 	}
 
 	deployContext := &chetypes.DeployContext{
@@ -253,69 +357,31 @@ func (r *CheClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	deployContext.Proxy, err = GetProxyConfiguration(deployContext)
 	if err != nil {
 		r.Log.Error(err, "Error on reading proxy configuration")
-		return ctrl.Result{}, err
+		return nil, ctrl.Result{}, err
 	}
 
 	// Resolve authentication configuration
 	deployContext.Authentication, err = ResolveAuthentication(deployContext)
 	if err != nil {
 		r.Log.Error(err, "Error on resolving authentication")
-		return ctrl.Result{}, err
+		return nil, ctrl.Result{}, err
 	}
 
 	deployContext.DWONamespace, err = devworkspace.GetDevWorkspaceOperatorNamespace(ctx, clusterAPI.ClientWrapper)
 	if err != nil {
-		return ctrl.Result{}, fmt.Errorf("failed to get DevWorkspaceOperator namespace: %w", err)
+		return nil, ctrl.Result{}, fmt.Errorf("failed to get DevWorkspaceOperator namespace: %w", err)
 	}
 	if deployContext.DWONamespace == "" {
 		r.Log.Info("DevWorkspaceOperator namespace not found, requeuing.")
-		return ctrl.Result{RequeueAfter: 10 * time.Second}, nil
+		return nil, ctrl.Result{RequeueAfter: 10 * time.Second}, nil
 	}
 
 	// Detect whether self-signed certificate is used
 	deployContext.IsSelfSignedCertificate, err = tls.IsSelfSignedCertificateUsed(deployContext)
 	if err != nil {
 		r.Log.Error(err, "Failed to detect if self-signed certificate used.")
-		return ctrl.Result{}, err
+		return nil, ctrl.Result{}, err
 	}
 
-	if deployContext.CheCluster.DeletionTimestamp.IsZero() {
-		result, done, err := r.reconcilerManager.ReconcileAll(deployContext)
-		if done {
-			// Clean up status if so
-			if err := deploy.SetStatusDetails(deployContext, "", ""); err != nil {
-				return ctrl.Result{}, err
-			}
-
-			r.Log.Info("Successfully reconciled.")
-			return ctrl.Result{}, nil
-		} else {
-			if err != nil {
-				errMsg := "Failed to reconcile CheCluster resources. The installation is not completed. Check operator logs for details."
-				r.Log.Error(err, errMsg)
-
-				if err := deploy.SetStatusDetails(deployContext, constants.InstallOrUpdateFailed, errMsg); err != nil {
-					return ctrl.Result{}, err
-				}
-			}
-
-			return result, err
-		}
-	} else {
-		deployContext.CheCluster.Status.ChePhase = chev2.ClusterPhasePendingDeletion
-		if err = deploy.UpdateCheCRStatus(deployContext, "ChePhase", chev2.ClusterPhasePendingDeletion); err != nil {
-			return ctrl.Result{}, err
-		}
-
-		done := r.reconcilerManager.FinalizeAll(deployContext)
-		if done {
-			// Removes remaining finalizers, which prevent CheCluster from deletion
-			if err := deploy.CleanUpAllFinalizers(deployContext); err != nil {
-				return ctrl.Result{}, err
-			}
-			return ctrl.Result{}, nil
-		} else {
-			return ctrl.Result{RequeueAfter: time.Second}, nil
-		}
-	}
+	return deployContext, ctrl.Result{}, nil
 }
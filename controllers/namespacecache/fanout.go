@@ -0,0 +1,154 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package namespacecache
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	dw "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var (
+	logger = ctrl.Log.WithName("namespacecache")
+)
+
+const (
+	// MaxConcurrentReconciles bounds the number of user namespaces reconciled at the same time by a controller.
+	MaxConcurrentReconciles = 5
+
+	// The first fanOutBurst namespaces of a fan-out are enqueued at once,
+	// the remaining ones are spread at fanOutInterval to avoid throttling the API server.
+	fanOutBurst    = 50
+	fanOutInterval = 50 * time.Millisecond
+)
+
+// FanOut enqueues the reconcile requests of the user namespaces.
+// When an event concerns several namespaces, such as a change of the CheCluster,
+// the namespaces with running workspaces are reconciled first and the other ones
+// are enqueued with a low priority and at a limited rate.
+type FanOut struct {
+	client client.Client
+}
+
+type fanOutEventHandler struct {
+	fanOut  *FanOut
+	mapFunc handler.MapFunc
+}
+
+var _ handler.EventHandler = (*fanOutEventHandler)(nil)
+
+// NewFanOut returns the FanOut listing the DevWorkspaces with the given client, which is expected to be cached.
+func NewFanOut(client client.Client) *FanOut {
+	return &FanOut{client: client}
+}
+
+// EnqueueRequestsFromMapFunc is a drop-in replacement of handler.EnqueueRequestsFromMapFunc.
+func (f *FanOut) EnqueueRequestsFromMapFunc(fn handler.MapFunc) handler.EventHandler {
+	return &fanOutEventHandler{fanOut: f, mapFunc: fn}
+}
+
+func (e *fanOutEventHandler) Create(ctx context.Context, evt event.CreateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	e.fanOut.Enqueue(ctx, e.mapFunc(ctx, evt.Object), q)
+}
+
+func (e *fanOutEventHandler) Update(ctx context.Context, evt event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	e.fanOut.Enqueue(ctx, append(e.mapFunc(ctx, evt.ObjectOld), e.mapFunc(ctx, evt.ObjectNew)...), q)
+}
+
+func (e *fanOutEventHandler) Delete(ctx context.Context, evt event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	e.fanOut.Enqueue(ctx, e.mapFunc(ctx, evt.Object), q)
+}
+
+func (e *fanOutEventHandler) Generic(ctx context.Context, evt event.GenericEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	e.fanOut.Enqueue(ctx, e.mapFunc(ctx, evt.Object), q)
+}
+
+// Enqueue adds the requests to the queue, the namespaces with running workspaces first.
+func (f *FanOut) Enqueue(ctx context.Context, requests []reconcile.Request, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	requests = slices.CompactFunc(slices.SortedFunc(slices.Values(requests), func(a, b reconcile.Request) int {
+		return strings.Compare(a.Name, b.Name)
+	}), func(a, b reconcile.Request) bool {
+		return a.Name == b.Name
+	})
+
+	if len(requests) <= 1 {
+		for _, request := range requests {
+			q.Add(request)
+		}
+		return
+	}
+
+	activeNamespaces, err := f.GetActiveNamespaces(ctx)
+	if err != nil {
+		logger.Error(err, "Failed to get the namespaces with running workspaces")
+	}
+
+	// namespaces with running workspaces first
+	slices.SortStableFunc(requests, func(a, b reconcile.Request) int {
+		return cmp.Compare(getRank(activeNamespaces, a), getRank(activeNamespaces, b))
+	})
+
+	pq, isPriorityQueue := q.(priorityqueue.PriorityQueue[reconcile.Request])
+	for i, request := range requests {
+		after := time.Duration(max(0, i-fanOutBurst)) * fanOutInterval
+		priority := handler.LowPriority
+		if activeNamespaces[request.Name] {
+			after = 0
+			priority = 0
+		}
+
+		if isPriorityQueue {
+			pq.AddWithOpts(priorityqueue.AddOpts{After: after, Priority: &priority}, request)
+		} else if after > 0 {
+			q.AddAfter(request, after)
+		} else {
+			q.Add(request)
+		}
+	}
+}
+
+// GetActiveNamespaces returns the namespaces with started workspaces.
+// The DevWorkspaces are watched by the user namespace controller, so they are listed from the cache.
+func (f *FanOut) GetActiveNamespaces(ctx context.Context) (map[string]bool, error) {
+	workspaces := &dw.DevWorkspaceList{}
+	if err := f.client.List(ctx, workspaces); err != nil {
+		return map[string]bool{}, err
+	}
+
+	activeNamespaces := map[string]bool{}
+	for i := range workspaces.Items {
+		if workspaces.Items[i].Spec.Started {
+			activeNamespaces[workspaces.Items[i].Namespace] = true
+		}
+	}
+
+	return activeNamespaces, nil
+}
+
+func getRank(activeNamespaces map[string]bool, request reconcile.Request) int {
+	if activeNamespaces[request.Name] {
+		return 0
+	}
+	return 1
+}
@@ -0,0 +1,83 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package namespacecache
+
+import (
+	"context"
+	"testing"
+
+	dw "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/eclipse-che/che-operator/pkg/common/test"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestFanOutEnqueuesActiveNamespacesFirst(t *testing.T) {
+	workspaces := []*dw.DevWorkspace{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "running", Namespace: "ns-2"},
+			Spec:       dw.DevWorkspaceSpec{Started: true},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "stopped", Namespace: "ns-3"},
+			Spec:       dw.DevWorkspaceSpec{Started: false},
+		},
+	}
+
+	ctx := test.NewCtxBuilder().WithObjects(workspaces[0], workspaces[1]).Build()
+	fanOut := NewFanOut(ctx.ClusterAPI.Client)
+
+	q := priorityqueue.New[reconcile.Request]("test")
+	defer q.ShutDown()
+
+	fanOut.Enqueue(context.TODO(), asRequests("ns-1", "ns-2", "ns-3", "ns-1"), q)
+	assert.Equal(t, 3, q.Len())
+
+	request, priority, _ := q.GetWithPriority()
+	assert.Equal(t, "ns-2", request.Name)
+	assert.Equal(t, 0, priority)
+	q.Done(request)
+
+	for range 2 {
+		request, priority, _ = q.GetWithPriority()
+		assert.NotEqual(t, "ns-2", request.Name)
+		assert.Equal(t, handler.LowPriority, priority)
+		q.Done(request)
+	}
+}
+
+func TestFanOutEnqueuesSingleRequest(t *testing.T) {
+	ctx := test.NewCtxBuilder().Build()
+	fanOut := NewFanOut(ctx.ClusterAPI.Client)
+
+	q := priorityqueue.New[reconcile.Request]("test")
+	defer q.ShutDown()
+
+	fanOut.Enqueue(context.TODO(), asRequests("ns-1"), q)
+
+	request, priority, _ := q.GetWithPriority()
+	assert.Equal(t, "ns-1", request.Name)
+	assert.Equal(t, 0, priority)
+}
+
+func asRequests(namespaces ...string) []reconcile.Request {
+	requests := make([]reconcile.Request, 0, len(namespaces))
+	for _, ns := range namespaces {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: ns}})
+	}
+	return requests
+}
@@ -1,5 +1,5 @@
 //
-// Copyright (c) 2019-2023 Red Hat, Inc.
+// Copyright (c) 2019-2026 Red Hat, Inc.
 // This program and the accompanying materials are made
 // available under the terms of the Eclipse Public License 2.0
 // which is available at https://www.eclipse.org/legal/epl-2.0/
@@ -14,21 +14,32 @@ package namespacecache
 
 import (
 	"context"
+	"slices"
 	"sync"
 
 	"github.com/eclipse-che/che-operator/pkg/common/constants"
-	"github.com/eclipse-che/che-operator/pkg/common/infrastructure"
-	projectv1 "github.com/openshift/api/project/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	toolscache "k8s.io/client-go/tools/cache"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// NamespaceCache keeps the information about the user namespaces.
+// The entries are kept up to date by a Namespace informer, see SetupWithManager:
+// they are updated when the namespace labels or annotations change and evicted
+// when the namespace is deleted.
+// Reads are lock-free, writes are serialized to keep the user index consistent.
 type NamespaceCache struct {
-	Client          client.Client
-	KnownNamespaces map[string]NamespaceInfo
-	Lock            sync.Mutex
+	client client.Client
+
+	// knownNamespaces maps the namespace name to its NamespaceInfo
+	knownNamespaces sync.Map
+	// userNamespaces maps the username to the sorted names of its workspace namespaces.
+	// The slices are never modified in place, but replaced.
+	userNamespaces sync.Map
+	lock           sync.Mutex
 }
 
 type NamespaceInfo struct {
@@ -38,74 +49,177 @@ type NamespaceInfo struct {
 
 func NewNamespaceCache(client client.Client) *NamespaceCache {
 	return &NamespaceCache{
-		Client:          client,
-		KnownNamespaces: map[string]NamespaceInfo{},
-		Lock:            sync.Mutex{},
+		client: client,
 	}
 }
 
+// SetupWithManager registers the handlers of the Namespace informer
+// which keep the known namespaces up to date.
+func (c *NamespaceCache) SetupWithManager(mgr ctrl.Manager) error {
+	informer, err := mgr.GetCache().GetInformer(context.Background(), &corev1.Namespace{})
+	if err != nil {
+		return err
+	}
+
+	_, err = informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc:    c.onNamespaceChanged,
+		UpdateFunc: func(_, newObj interface{}) { c.onNamespaceChanged(newObj) },
+		DeleteFunc: c.onNamespaceDeleted,
+	})
+	return err
+}
+
+// GetNamespaceInfo returns the cached information about the namespace
+// or examines the namespace if it is not known yet.
 func (c *NamespaceCache) GetNamespaceInfo(ctx context.Context, namespace string) (*NamespaceInfo, error) {
-	c.Lock.Lock()
-	defer c.Lock.Unlock()
-
-	for {
-		val, contains := c.KnownNamespaces[namespace]
-		if contains {
-			return &val, nil
-		} else {
-			existing, err := c.examineNamespaceUnsafe(ctx, namespace)
-			if err != nil {
-				return nil, err
-			} else if existing == nil {
-				return nil, nil
-			}
-		}
+	if info, contains := c.load(namespace); contains {
+		return &info, nil
 	}
+
+	return c.ExamineNamespace(ctx, namespace)
 }
+
+// ExamineNamespace reads the namespace and updates the cache.
+// Returns nil if the namespace doesn't exist or is being deleted.
 func (c *NamespaceCache) ExamineNamespace(ctx context.Context, ns string) (*NamespaceInfo, error) {
-	c.Lock.Lock()
-	defer c.Lock.Unlock()
+	c.lock.Lock()
+	defer c.lock.Unlock()
 
 	return c.examineNamespaceUnsafe(ctx, ns)
 }
 
 func (c *NamespaceCache) GetAllKnownNamespaces() []string {
-	c.Lock.Lock()
-	defer c.Lock.Unlock()
-
-	ret := make([]string, len(c.KnownNamespaces))
-	i := 0
-	for k := range c.KnownNamespaces {
-		ret[i] = k
-		i = i + 1
-	}
+	ret := []string{}
+	c.knownNamespaces.Range(func(key, _ any) bool {
+		ret = append(ret, key.(string))
+		return true
+	})
 
 	return ret
 }
 
-func (c *NamespaceCache) examineNamespaceUnsafe(ctx context.Context, ns string) (*NamespaceInfo, error) {
-	var obj client.Object
-	if infrastructure.IsOpenShift() {
-		obj = &projectv1.Project{}
-	} else {
-		obj = &corev1.Namespace{}
+// GetUserNamespaces returns the workspace namespaces of the user.
+func (c *NamespaceCache) GetUserNamespaces(username string) []string {
+	namespaces, ok := c.userNamespaces.Load(username)
+	if !ok {
+		return []string{}
 	}
 
-	if err := c.Client.Get(ctx, client.ObjectKey{Name: ns}, obj); err != nil {
+	return slices.Clone(namespaces.([]string))
+}
+
+// examineNamespaceUnsafe reads the namespace from the Namespace informer, see SetupWithManager.
+// On OpenShift, the Project has the labels and annotations of its Namespace.
+func (c *NamespaceCache) examineNamespaceUnsafe(ctx context.Context, ns string) (*NamespaceInfo, error) {
+	namespace := &corev1.Namespace{}
+	if err := c.client.Get(ctx, client.ObjectKey{Name: ns}, namespace); err != nil {
 		if errors.IsNotFound(err) {
-			delete(c.KnownNamespaces, ns)
+			c.deleteUnsafe(ns)
 			return nil, nil
 		}
 		return nil, err
 	}
 
-	var namespace = obj.(metav1.Object)
-
 	if namespace.GetDeletionTimestamp() != nil {
-		delete(c.KnownNamespaces, ns)
+		c.deleteUnsafe(ns)
 		return nil, nil
 	}
 
+	ret := getNamespaceInfo(namespace)
+	c.storeUnsafe(ns, ret)
+
+	return &ret, nil
+}
+
+func (c *NamespaceCache) onNamespaceChanged(obj interface{}) {
+	namespace, ok := obj.(metav1.Object)
+	if !ok {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if namespace.GetDeletionTimestamp() != nil {
+		c.deleteUnsafe(namespace.GetName())
+		return
+	}
+
+	// Other namespaces are added when they are looked up,
+	// so that the cache doesn't hold every namespace of the cluster.
+	info := getNamespaceInfo(namespace)
+	if _, known := c.load(namespace.GetName()); known || info.IsWorkspaceNamespace {
+		c.storeUnsafe(namespace.GetName(), info)
+	}
+}
+
+func (c *NamespaceCache) onNamespaceDeleted(obj interface{}) {
+	if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
+	namespace, ok := obj.(metav1.Object)
+	if !ok {
+		return
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.deleteUnsafe(namespace.GetName())
+}
+
+func (c *NamespaceCache) load(ns string) (NamespaceInfo, bool) {
+	info, ok := c.knownNamespaces.Load(ns)
+	if !ok {
+		return NamespaceInfo{}, false
+	}
+	return info.(NamespaceInfo), true
+}
+
+func (c *NamespaceCache) storeUnsafe(ns string, info NamespaceInfo) {
+	if old, ok := c.load(ns); ok {
+		if old == info {
+			return
+		}
+		c.unindexUnsafe(ns, old)
+	}
+
+	c.knownNamespaces.Store(ns, info)
+	c.indexUnsafe(ns, info)
+}
+
+func (c *NamespaceCache) deleteUnsafe(ns string) {
+	if old, ok := c.load(ns); ok {
+		c.unindexUnsafe(ns, old)
+		c.knownNamespaces.Delete(ns)
+	}
+}
+
+func (c *NamespaceCache) indexUnsafe(ns string, info NamespaceInfo) {
+	if !info.IsWorkspaceNamespace || info.Username == "" {
+		return
+	}
+
+	namespaces := append(c.GetUserNamespaces(info.Username), ns)
+	slices.Sort(namespaces)
+	c.userNamespaces.Store(info.Username, slices.Compact(namespaces))
+}
+
+func (c *NamespaceCache) unindexUnsafe(ns string, info NamespaceInfo) {
+	if !info.IsWorkspaceNamespace || info.Username == "" {
+		return
+	}
+
+	namespaces := slices.DeleteFunc(c.GetUserNamespaces(info.Username), func(n string) bool { return n == ns })
+	if len(namespaces) == 0 {
+		c.userNamespaces.Delete(info.Username)
+	} else {
+		c.userNamespaces.Store(info.Username, namespaces)
+	}
+}
+
+func getNamespaceInfo(namespace metav1.Object) NamespaceInfo {
 	labels := namespace.GetLabels()
 	if labels == nil {
 		labels = map[string]string{}
@@ -123,12 +237,8 @@ func (c *NamespaceCache) examineNamespaceUnsafe(ctx context.Context, ns string)
 	componentLabel := labels[constants.KubernetesComponentLabelKey]
 	username := annotations[constants.CheEclipseOrgUsername]
 
-	ret := NamespaceInfo{
+	return NamespaceInfo{
 		IsWorkspaceNamespace: ownerUid != "" || (partOfLabel == constants.CheEclipseOrg && componentLabel == constants.WorkspacesNamespaceComponentName),
 		Username:             username,
 	}
-
-	c.KnownNamespaces[ns] = ret
-
-	return &ret, nil
 }
@@ -14,7 +14,6 @@ package namespacecache
 
 import (
 	"context"
-	"sync"
 	"testing"
 
 	"github.com/eclipse-che/che-operator/pkg/common/constants"
@@ -23,9 +22,9 @@ import (
 	"github.com/eclipse-che/che-operator/pkg/common/infrastructure"
 	"github.com/stretchr/testify/assert"
 
-	projectv1 "github.com/openshift/api/project/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	toolscache "k8s.io/client-go/tools/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -37,15 +36,11 @@ func TestGetNamespaceInfoReadsFromCache(t *testing.T) {
 		ctx := test.NewCtxBuilder().WithObjects(namespace.(client.Object)).Build()
 		cl := ctx.ClusterAPI.Client
 
-		nsc := NamespaceCache{
-			Client:          cl,
-			KnownNamespaces: map[string]NamespaceInfo{},
-			Lock:            sync.Mutex{},
-		}
+		nsc := NewNamespaceCache(cl)
 
 		_, err := nsc.GetNamespaceInfo(context.TODO(), ns)
 		assert.NoError(t, err)
-		assert.Contains(t, nsc.KnownNamespaces, ns, "The namespace info should have been cached")
+		assert.Contains(t, nsc.GetAllKnownNamespaces(), ns, "The namespace info should have been cached")
 	}
 
 	test(infrastructure.Kubernetes, &corev1.Namespace{
@@ -54,7 +49,7 @@ func TestGetNamespaceInfoReadsFromCache(t *testing.T) {
 		},
 	})
 
-	test(infrastructure.OpenShiftV4, &projectv1.Project{
+	test(infrastructure.OpenShiftV4, &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "prj",
 		},
@@ -68,18 +63,14 @@ func TestExamineUpdatesCache(t *testing.T) {
 		cl := ctx.ClusterAPI.Client
 		infrastructure.InitializeForTesting(infraType)
 
-		nsc := NamespaceCache{
-			Client:          cl,
-			KnownNamespaces: map[string]NamespaceInfo{},
-			Lock:            sync.Mutex{},
-		}
+		nsc := NewNamespaceCache(cl)
 
 		nsi, err := nsc.GetNamespaceInfo(context.TODO(), nsName)
 		assert.NoError(t, err)
 
 		assert.False(t, nsi.IsWorkspaceNamespace, "The namespace should not be found as managed")
 
-		assert.Contains(t, nsc.KnownNamespaces, nsName, "The namespace info should have been cached")
+		assert.Contains(t, nsc.GetAllKnownNamespaces(), nsName, "The namespace info should have been cached")
 
 		ns := namespace.(client.Object)
 		assert.NoError(t, cl.Get(context.TODO(), client.ObjectKey{Name: nsName}, ns))
@@ -114,9 +105,62 @@ func TestExamineUpdatesCache(t *testing.T) {
 		},
 	})
 
-	test(infrastructure.OpenShiftV4, &projectv1.Project{
+	test(infrastructure.OpenShiftV4, &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "prj",
 		},
 	})
 }
+
+func TestNamespaceInformerUpdatesCache(t *testing.T) {
+	infrastructure.InitializeForTesting(infrastructure.Kubernetes)
+	defer infrastructure.InitializeForTesting(infrastructure.OpenShiftV4)
+
+	ctx := test.NewCtxBuilder().Build()
+	nsc := NewNamespaceCache(ctx.ClusterAPI.Client)
+
+	userNamespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "user-che",
+			Labels: map[string]string{
+				constants.KubernetesPartOfLabelKey:    constants.CheEclipseOrg,
+				constants.KubernetesComponentLabelKey: constants.WorkspacesNamespaceComponentName,
+			},
+			Annotations: map[string]string{constants.CheEclipseOrgUsername: "user"},
+		},
+	}
+	otherNamespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "other",
+		},
+	}
+
+	nsc.onNamespaceChanged(userNamespace)
+	nsc.onNamespaceChanged(otherNamespace)
+
+	assert.ElementsMatch(t, []string{"user-che"}, nsc.GetAllKnownNamespaces(), "Only workspace namespaces should be added by the informer")
+	assert.Equal(t, []string{"user-che"}, nsc.GetUserNamespaces("user"))
+
+	// the namespace is given to another user
+	userNamespace = userNamespace.DeepCopy()
+	userNamespace.Annotations[constants.CheEclipseOrgUsername] = "another-user"
+	nsc.onNamespaceChanged(userNamespace)
+
+	assert.Empty(t, nsc.GetUserNamespaces("user"))
+	assert.Equal(t, []string{"user-che"}, nsc.GetUserNamespaces("another-user"))
+
+	// the namespace is not a workspace namespace anymore
+	userNamespace = userNamespace.DeepCopy()
+	userNamespace.Labels = map[string]string{}
+	nsc.onNamespaceChanged(userNamespace)
+
+	nsi, err := nsc.GetNamespaceInfo(context.TODO(), "user-che")
+	assert.NoError(t, err)
+	assert.False(t, nsi.IsWorkspaceNamespace)
+	assert.Empty(t, nsc.GetUserNamespaces("another-user"))
+
+	// the namespace is deleted
+	nsc.onNamespaceDeleted(toolscache.DeletedFinalStateUnknown{Key: "user-che", Obj: userNamespace})
+
+	assert.NotContains(t, nsc.GetAllKnownNamespaces(), "user-che", "The namespace should have been evicted")
+}
@@ -38,11 +38,13 @@ import (
 	"github.com/eclipse-che/che-operator/pkg/common/constants"
 	"github.com/eclipse-che/che-operator/pkg/deploy/tls"
 
+	dw "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
 	dwconstants "github.com/devfile/devworkspace-operator/pkg/constants"
 	chev2 "github.com/eclipse-che/che-operator/api/v2"
 	"github.com/eclipse-che/che-operator/controllers/che"
 	"github.com/eclipse-che/che-operator/pkg/deploy"
 	projectv1 "github.com/openshift/api/project/v1"
+	userv1 "github.com/openshift/api/user/v1"
 	"github.com/sirupsen/logrus"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
@@ -50,9 +52,15 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -75,6 +83,9 @@ type CheUserNamespaceReconciler struct {
 	clientWrapper          *k8sclient.K8sClientWrapper
 	nonCachedClientWrapper *k8sclient.K8sClientWrapper
 	namespaceCache         *namespacecache.NamespaceCache
+	recorder               record.EventRecorder
+	fanOut                 *namespacecache.FanOut
+	tracker                *namespacesTracker
 
 	dwoNamespace   string
 	dwoNamespaceMu sync.RWMutex
@@ -86,7 +97,8 @@ func NewCheUserNamespaceReconciler(
 	client client.Client,
 	noncachedClient client.Client,
 	scheme *runtime.Scheme,
-	namespaceCache *namespacecache.NamespaceCache) *CheUserNamespaceReconciler {
+	namespaceCache *namespacecache.NamespaceCache,
+	recorder record.EventRecorder) *CheUserNamespaceReconciler {
 
 	return &CheUserNamespaceReconciler{
 		scheme:                 scheme,
@@ -95,6 +107,9 @@ func NewCheUserNamespaceReconciler(
 		clientWrapper:          k8sclient.NewK8sClient(client, scheme),
 		nonCachedClientWrapper: k8sclient.NewK8sClient(noncachedClient, scheme),
 		namespaceCache:         namespaceCache,
+		recorder:               recorder,
+		fanOut:                 namespacecache.NewFanOut(client),
+		tracker:                newNamespacesTracker(),
 	}
 }
 
@@ -115,20 +130,39 @@ func (r *CheUserNamespaceReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Watches(&corev1.Pod{}, r.watchRuleForDevWorkspacePod()).
 		Watches(&corev1.Secret{}, r.watchRulesForSecrets(ctx)).
 		Watches(&corev1.ConfigMap{}, r.watchRulesForConfigMaps(ctx)).
-		Watches(&chev2.CheCluster{}, r.triggerAllNamespaces()).
-		Watches(&networkingv1.NetworkPolicy{}, r.watchRulesForNetworkPolicies(ctx))
+		// The CheCluster status changes don't affect the user namespaces
+		Watches(&chev2.CheCluster{}, r.triggerAllNamespaces(), builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// Operator managed objects in the user namespaces are reverted when they are changed
+		Watches(&networkingv1.NetworkPolicy{}, r.watchRulesForOperatorManagedObjects(ctx)).
+		Watches(&corev1.ResourceQuota{}, r.watchRulesForOperatorManagedObjects(ctx)).
+		Watches(&corev1.LimitRange{}, r.watchRulesForOperatorManagedObjects(ctx)).
+		// The DevWorkspaces are referred to the DevWorkspaceOperatorConfig of the profile when created or stopped
+		Watches(&dw.DevWorkspace{}, r.watchRulesForDevWorkspaces(), builder.WithPredicates(devWorkspaceCreatedOrStopped()))
+
+	if infrastructure.IsOpenShiftOAuthEnabled() {
+		// The group roles are bound to the members of the groups, see reconcileGroupRoles
+		bld.Watches(&userv1.Group{}, r.watchRulesForGroups())
+	}
+
+	if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		wait.UntilWithContext(ctx, r.syncReconciliationStatus, reconciliationStatusSyncPeriod)
+		return nil
+	})); err != nil {
+		return err
+	}
 
 	// Use controller.TypedOptions to allow to configure 2 controllers for same object being reconciled
 	return bld.WithOptions(
 		controller.TypedOptions[reconcile.Request]{
-			SkipNameValidation: ptr.To(true),
-			UsePriorityQueue:   ptr.To(false),
+			SkipNameValidation:      ptr.To(true),
+			UsePriorityQueue:        ptr.To(true),
+			MaxConcurrentReconciles: namespacecache.MaxConcurrentReconciles,
 		}).Complete(r)
 }
 
 func (r *CheUserNamespaceReconciler) watchRulesForSecrets(ctx context.Context) handler.EventHandler {
 	rules := r.commonRules(ctx, constants.DefaultSelfSignedCertificateSecretName)
-	return handler.EnqueueRequestsFromMapFunc(
+	return r.fanOut.EnqueueRequestsFromMapFunc(
 		handler.MapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
 			return namespacecache.AsReconcileRequestsForNamespaces(obj, rules)
 		}))
@@ -146,12 +180,12 @@ func (r *CheUserNamespaceReconciler) commonRules(ctx context.Context, namesInChe
 			Check: func(o metav1.Object) bool {
 				return r.hasNameAndIsCollocatedWithCheCluster(ctx, o, namesInCheClusterNamespace...)
 			},
-			Namespaces: func(o metav1.Object) []string { return r.namespaceCache.GetAllKnownNamespaces() },
+			Namespaces: func(o metav1.Object) []string { return r.getNamespacesToReconcile(ctx) },
 		},
 	}
 }
 
-func (r *CheUserNamespaceReconciler) watchRulesForNetworkPolicies(_ context.Context) handler.EventHandler {
+func (r *CheUserNamespaceReconciler) watchRulesForOperatorManagedObjects(_ context.Context) handler.EventHandler {
 	return handler.EnqueueRequestsFromMapFunc(
 		func(ctx context.Context, obj client.Object) []reconcile.Request {
 			workspaceInfo, err := r.namespaceCache.GetNamespaceInfo(ctx, obj.GetNamespace())
@@ -177,9 +211,53 @@ func (r *CheUserNamespaceReconciler) watchRulesForNetworkPolicies(_ context.Cont
 		})
 }
 
+// watchRulesForGroups reconciles the namespaces of the users added to or removed from the group.
+func (r *CheUserNamespaceReconciler) watchRulesForGroups() handler.EventHandler {
+	return r.fanOut.EnqueueRequestsFromMapFunc(
+		handler.MapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+			group, ok := obj.(*userv1.Group)
+			if !ok {
+				return []reconcile.Request{}
+			}
+
+			var namespaces []string
+			for _, username := range group.Users {
+				namespaces = append(namespaces, r.namespaceCache.GetUserNamespaces(username)...)
+			}
+
+			return asReconcileRequests(namespaces)
+		}))
+}
+
+func (r *CheUserNamespaceReconciler) watchRulesForDevWorkspaces() handler.EventHandler {
+	return r.fanOut.EnqueueRequestsFromMapFunc(
+		handler.MapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+			return asReconcileRequests([]string{obj.GetNamespace()})
+		}))
+}
+
+func devWorkspaceCreatedOrStopped() predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return true
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldWorkspace, okOld := e.ObjectOld.(*dw.DevWorkspace)
+			newWorkspace, okNew := e.ObjectNew.(*dw.DevWorkspace)
+			return okOld && okNew && oldWorkspace.Spec.Started && !newWorkspace.Spec.Started
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
 func (r *CheUserNamespaceReconciler) watchRulesForConfigMaps(ctx context.Context) handler.EventHandler {
 	rules := r.commonRules(ctx, tls.CheMergedCABundleCertsCMName)
-	return handler.EnqueueRequestsFromMapFunc(
+	return r.fanOut.EnqueueRequestsFromMapFunc(
 		handler.MapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
 			return namespacecache.AsReconcileRequestsForNamespaces(obj, rules)
 		}))
@@ -205,22 +283,24 @@ func (r *CheUserNamespaceReconciler) isInManagedNamespace(ctx context.Context, o
 }
 
 func (r *CheUserNamespaceReconciler) triggerAllNamespaces() handler.EventHandler {
-	return handler.EnqueueRequestsFromMapFunc(
+	return r.fanOut.EnqueueRequestsFromMapFunc(
 		handler.MapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
-			nss := r.namespaceCache.GetAllKnownNamespaces()
-			ret := make([]reconcile.Request, 0, len(nss))
-
-			for _, ns := range nss {
-				ret = append(ret, reconcile.Request{
-					NamespacedName: types.NamespacedName{Name: ns},
-				})
-			}
-
-			return ret
+			return asReconcileRequests(r.getNamespacesToReconcile(ctx))
 		}),
 	)
 }
 
+func asReconcileRequests(namespaces []string) []reconcile.Request {
+	ret := make([]reconcile.Request, 0, len(namespaces))
+	for _, ns := range namespaces {
+		ret = append(ret, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: ns},
+		})
+	}
+
+	return ret
+}
+
 func (r *CheUserNamespaceReconciler) hasCheCluster(ctx context.Context, namespace string) bool {
 	list := chev2.CheClusterList{}
 	if err := r.client.List(ctx, &list, client.InNamespace(namespace)); err != nil {
@@ -243,6 +323,7 @@ func (r *CheUserNamespaceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 
 	if info == nil || !info.IsWorkspaceNamespace {
 		// we're not handling this namespace
+		r.tracker.forget(req.Name)
 		return ctrl.Result{}, nil
 	}
 
@@ -252,6 +333,28 @@ func (r *CheUserNamespaceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{}, err
 	}
 
+	// the inputs are read before reconciling, so that a change during the reconciliation is not missed
+	inputsHash, err := r.getInputsHash(ctx, checluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	result, err := r.reconcileNamespace(ctx, req, info, checluster)
+	if err == nil {
+		r.tracker.setReconciled(req.Name, inputsHash)
+	}
+
+	return result, err
+}
+
+func (r *CheUserNamespaceReconciler) reconcileNamespace(
+	ctx context.Context,
+	req ctrl.Request,
+	info *namespacecache.NamespaceInfo,
+	checluster *chev2.CheCluster,
+) (ctrl.Result, error) {
+	var err error
+
 	// let's construct the deployContext to be able to use methods from v1 operator
 	deployContext := &chetypes.DeployContext{
 		CheCluster: checluster,
@@ -297,16 +400,37 @@ func (r *CheUserNamespaceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{}, err
 	}
 
-	if err = r.reconcileNodeSelectorAndTolerations(ctx, req.Name, checluster, deployContext); err != nil {
+	ns := &corev1.Namespace{}
+	if err = r.client.Get(ctx, client.ObjectKey{Name: req.Name}, ns); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// The labels and annotations of the namespace are updated on a copy and patched at once
+	originalNs := ns.DeepCopy()
+
+	profile, err := r.getDevEnvironmentProfile(ctx, ns, info.Username, checluster)
+	if err != nil {
+		logrus.Errorf("Failed to resolve the development environment profile for namespace '%s': %v", req.Name, err)
+		return ctrl.Result{}, err
+	}
+
+	if err = setNodeSelectorAndTolerations(ns, checluster, profile); err != nil {
 		logrus.Errorf("Failed to reconcile the workspace pod node selector and tolerations in namespace '%s': %v", req.Name, err)
 		return ctrl.Result{}, err
 	}
 
+	r.setNamespaceMetadata(ns, info.Username, checluster, profile)
+
+	if err = r.reconcileDevWorkspacesConfig(ctx, req.Name, checluster, profile); err != nil {
+		logrus.Errorf("Failed to reconcile the DevWorkspaceOperatorConfig of the workspaces in namespace '%s': %v", req.Name, err)
+		return ctrl.Result{}, err
+	}
+
 	if err = r.reconcileSCCPrivileges(
 		info.Username,
 		req.Name,
 		containercapabilties.NewContainerBuild(),
-		checluster.IsContainerBuildCapabilitiesEnabled(),
+		isContainerBuildCapabilitiesEnabled(checluster, profile),
 	); err != nil {
 		logrus.Errorf("Failed to reconcile the SCC privileges in namespace '%s': %v", req.Name, err)
 		return ctrl.Result{}, err
@@ -316,18 +440,53 @@ func (r *CheUserNamespaceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		info.Username,
 		req.Name,
 		containercapabilties.NewContainerRun(),
-		checluster.IsContainerRunCapabilitiesEnabled(),
+		isContainerRunCapabilitiesEnabled(checluster, profile),
 	); err != nil {
 		logrus.Errorf("Failed to reconcile the SCC privileges in namespace '%s': %v", req.Name, err)
 		return ctrl.Result{}, err
 	}
 
+	if err = r.reconcileGroupRoles(ctx, ns, info.Username, checluster); err != nil {
+		logrus.Errorf("Failed to reconcile the group roles in namespace '%s': %v", req.Name, err)
+		return ctrl.Result{}, err
+	}
+
+	if err = r.reconcileResourceGuardrails(ctx, req.Name, checluster, profile); err != nil {
+		logrus.Errorf("Failed to reconcile the ResourceQuota and LimitRange in namespace '%s': %v", req.Name, err)
+		return ctrl.Result{}, err
+	}
+
 	if infrastructure.IsOpenShift() {
 		if err = r.reconcileNetworkPolicies(deployContext, req.Name); err != nil {
 			return ctrl.Result{}, fmt.Errorf("failed to reconcile network policies in namespace %s: %w", req.Name, err)
 		}
 	}
 
+	restoreCheckPeriod, err := r.reconcileVolumeRestore(ctx, ns, checluster)
+	if err != nil {
+		logrus.Errorf("Failed to restore the workspace volume in namespace '%s': %v", req.Name, err)
+		return ctrl.Result{}, err
+	}
+
+	if err = r.patchNamespace(ctx, originalNs, ns); err != nil {
+		logrus.Errorf("Failed to update the labels and annotations of namespace '%s': %v", req.Name, err)
+		return ctrl.Result{}, err
+	}
+
+	if restoreCheckPeriod > 0 {
+		return ctrl.Result{RequeueAfter: restoreCheckPeriod}, nil
+	}
+
+	resizeInProgress, err := r.reconcileWorkspacePVCSize(ctx, ns, checluster)
+	if err != nil {
+		logrus.Errorf("Failed to resize the workspace PVCs in namespace '%s': %v", req.Name, err)
+		return ctrl.Result{}, err
+	}
+
+	if resizeInProgress {
+		return ctrl.Result{RequeueAfter: pvcResizeCheckPeriod}, nil
+	}
+
 	return ctrl.Result{}, nil
 }
 
@@ -571,17 +730,12 @@ func (r *CheUserNamespaceReconciler) reconcileGitTlsCertificate(ctx context.Cont
 	return err
 }
 
-func (r *CheUserNamespaceReconciler) reconcileNodeSelectorAndTolerations(ctx context.Context, targetNs string, checluster *chev2.CheCluster, deployContext *chetypes.DeployContext) error {
-	ns := &corev1.Namespace{}
-	if err := r.client.Get(ctx, client.ObjectKey{Name: targetNs}, ns); err != nil {
-		return err
-	}
-
+func setNodeSelectorAndTolerations(ns *corev1.Namespace, checluster *chev2.CheCluster, profile *chev2.DevEnvironmentProfile) error {
 	nodeSelector := ""
 	tolerations := ""
 
-	if len(checluster.Spec.DevEnvironments.NodeSelector) != 0 {
-		serialized, err := json.Marshal(checluster.Spec.DevEnvironments.NodeSelector)
+	if nodeSelectorMap := getNodeSelector(checluster, profile); len(nodeSelectorMap) != 0 {
+		serialized, err := json.Marshal(nodeSelectorMap)
 		if err != nil {
 			return err
 		}
@@ -589,8 +743,8 @@ func (r *CheUserNamespaceReconciler) reconcileNodeSelectorAndTolerations(ctx con
 		nodeSelector = string(serialized)
 	}
 
-	if len(checluster.Spec.DevEnvironments.Tolerations) != 0 {
-		serialized, err := json.Marshal(checluster.Spec.DevEnvironments.Tolerations)
+	if tolerationsList := getTolerations(checluster, profile); len(tolerationsList) != 0 {
+		serialized, err := json.Marshal(tolerationsList)
 		if err != nil {
 			return err
 		}
@@ -616,8 +770,7 @@ func (r *CheUserNamespaceReconciler) reconcileNodeSelectorAndTolerations(ctx con
 	}
 
 	ns.SetAnnotations(annos)
-
-	return r.client.Update(ctx, ns)
+	return nil
 }
 
 func (r *CheUserNamespaceReconciler) reconcileSCCPrivileges(
@@ -19,7 +19,6 @@ import (
 	"github.com/eclipse-che/che-operator/pkg/deploy"
 	"github.com/eclipse-che/che-operator/pkg/deploy/devworkspace"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
@@ -54,11 +53,7 @@ func (r *CheUserNamespaceReconciler) watchRuleForDevWorkspacePod() handler.Event
 
 			r.setDWONamespace(newNamespace)
 
-			for _, namespace := range r.namespaceCache.GetAllKnownNamespaces() {
-				q.Add(reconcile.Request{
-					NamespacedName: types.NamespacedName{Name: namespace},
-				})
-			}
+			r.fanOut.Enqueue(ctx, asReconcileRequests(r.getNamespacesToReconcile(ctx)), q)
 		},
 		// Handle the delete event to resolve an invalid configuration where
 		// multiple DevWorkspace Operators are running in different namespaces.
@@ -92,11 +87,7 @@ func (r *CheUserNamespaceReconciler) watchRuleForDevWorkspacePod() handler.Event
 
 			r.setDWONamespace(newDWONamespace)
 
-			for _, namespace := range r.namespaceCache.GetAllKnownNamespaces() {
-				q.Add(reconcile.Request{
-					NamespacedName: types.NamespacedName{Name: namespace},
-				})
-			}
+			r.fanOut.Enqueue(ctx, asReconcileRequests(r.getNamespacesToReconcile(ctx)), q)
 		},
 	}
 }
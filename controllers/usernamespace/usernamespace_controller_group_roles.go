@@ -0,0 +1,208 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package usernamespace
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"slices"
+	"strings"
+
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/eclipse-che/che-operator/pkg/common/constants"
+	"github.com/eclipse-che/che-operator/pkg/common/diffs"
+	"github.com/eclipse-che/che-operator/pkg/common/infrastructure"
+	k8sclient "github.com/eclipse-che/che-operator/pkg/common/k8s-client"
+	userv1 "github.com/openshift/api/user/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	groupRoleBindingComponentLabelValue = "group-role-binding"
+	// maxRoleNameLength keeps the RoleBinding name within the object name length limit
+	maxRoleNameLength = 200
+)
+
+var invalidRoleBindingNameChars = regexp.MustCompile(`[^a-z0-9.-]+`)
+
+// reconcileGroupRoles binds the roles granted to the groups of the user in the user namespace
+// and deletes the bindings of the roles the user is no longer granted.
+func (r *CheUserNamespaceReconciler) reconcileGroupRoles(
+	ctx context.Context,
+	ns *corev1.Namespace,
+	username string,
+	checluster *chev2.CheCluster,
+) error {
+	targetNs := ns.GetName()
+	desired := map[string]*rbacv1.RoleBinding{}
+
+	if username != "" && checluster.Spec.DevEnvironments.User != nil && len(checluster.Spec.DevEnvironments.User.GroupRoles) > 0 {
+		groups, err := r.getUserGroups(ctx, ns, username, checluster.Spec.DevEnvironments.User.GroupRoles)
+		if err != nil {
+			return err
+		}
+
+		for _, groupRoles := range checluster.Spec.DevEnvironments.User.GroupRoles {
+			if !slices.Contains(groups, groupRoles.Group) {
+				continue
+			}
+
+			for _, clusterRole := range groupRoles.ClusterRoles {
+				addGroupRoleBinding(desired, targetNs, username, "ClusterRole", clusterRole)
+			}
+			for _, role := range groupRoles.Roles {
+				addGroupRoleBinding(desired, targetNs, username, "Role", role)
+			}
+		}
+	}
+
+	for _, rb := range desired {
+		if err := r.clientWrapper.Sync(
+			ctx,
+			rb,
+			&k8sclient.SyncOptions{DiffOpts: diffs.RoleBinding},
+		); err != nil {
+			return err
+		}
+	}
+
+	// revoke the roles the user is no longer granted
+	existing := &rbacv1.RoleBindingList{}
+	if err := r.client.List(
+		ctx,
+		existing,
+		client.InNamespace(targetNs),
+		client.MatchingLabels{
+			constants.KubernetesPartOfLabelKey:    constants.CheEclipseOrg,
+			constants.KubernetesComponentLabelKey: groupRoleBindingComponentLabelValue,
+		},
+	); err != nil {
+		return err
+	}
+
+	for i := range existing.Items {
+		if _, ok := desired[existing.Items[i].Name]; !ok {
+			if err := r.client.Delete(ctx, &existing.Items[i]); client.IgnoreNotFound(err) != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// getUserGroups returns the groups of the user.
+// The groups the cluster API server authenticated the user with are recorded in the user namespace annotation
+// when the user creates or updates a DevWorkspace, see UserGroupsRecorder.
+// With OpenShift built-in OAuth, the members of the given OpenShift `Group` resources are added,
+// groups are watched by the controller, so they are read from the cache.
+func (r *CheUserNamespaceReconciler) getUserGroups(
+	ctx context.Context,
+	ns *corev1.Namespace,
+	username string,
+	groupRoles []chev2.GroupRoles,
+) ([]string, error) {
+	var groups []string
+	for _, group := range strings.Split(ns.GetAnnotations()[constants.CheEclipseOrgGroups], ",") {
+		if group = strings.TrimSpace(group); group != "" {
+			groups = append(groups, group)
+		}
+	}
+
+	if !infrastructure.IsOpenShiftOAuthEnabled() {
+		return groups, nil
+	}
+
+	for _, groupRole := range groupRoles {
+		if slices.Contains(groups, groupRole.Group) {
+			continue
+		}
+
+		group := &userv1.Group{}
+		exists, err := r.clientWrapper.GetIgnoreNotFound(ctx, client.ObjectKey{Name: groupRole.Group}, group)
+		if err != nil {
+			return nil, err
+		}
+
+		if exists && slices.Contains(group.Users, username) {
+			groups = append(groups, groupRole.Group)
+		}
+	}
+	return groups, nil
+}
+
+// addGroupRoleBinding adds the binding of the role to the user,
+// a role granted by several groups is bound once.
+func addGroupRoleBinding(
+	desired map[string]*rbacv1.RoleBinding,
+	targetNs string,
+	username string,
+	roleKind string,
+	roleName string,
+) {
+	name := getGroupRoleBindingName(roleKind, roleName)
+	if _, ok := desired[name]; ok {
+		return
+	}
+
+	desired[name] = &rbacv1.RoleBinding{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "RoleBinding",
+			APIVersion: rbacv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: targetNs,
+			Labels: map[string]string{
+				constants.KubernetesPartOfLabelKey:    constants.CheEclipseOrg,
+				constants.KubernetesComponentLabelKey: groupRoleBindingComponentLabelValue,
+			},
+		},
+		RoleRef: rbacv1.RoleRef{
+			Name:     roleName,
+			Kind:     roleKind,
+			APIGroup: rbacv1.GroupName,
+		},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:     rbacv1.UserKind,
+				APIGroup: rbacv1.GroupName,
+				Name:     username,
+			},
+		},
+	}
+}
+
+// getGroupRoleBindingName returns the name of the binding of the role.
+// Role names, such as `system:aggregate-to-edit`, may contain characters not allowed in object names,
+// such names are sanitized and suffixed with a hash of the role name to avoid collisions.
+func getGroupRoleBindingName(roleKind string, roleName string) string {
+	prefix := "group-" + strings.ToLower(roleKind) + "-"
+	if len(validation.IsDNS1123Subdomain(prefixedName(prefix+roleName))) == 0 {
+		return prefixedName(prefix + roleName)
+	}
+
+	sanitized := strings.Trim(invalidRoleBindingNameChars.ReplaceAllString(strings.ToLower(roleName), "-"), "-.")
+	if len(sanitized) > maxRoleNameLength {
+		sanitized = sanitized[:maxRoleNameLength]
+	}
+
+	hash := sha256.Sum256([]byte(roleName))
+	return prefixedName(prefix + sanitized + "-" + hex.EncodeToString(hash[:])[:8])
+}
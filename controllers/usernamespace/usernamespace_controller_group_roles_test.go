@@ -0,0 +1,231 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package usernamespace
+
+import (
+	"context"
+	"testing"
+
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/eclipse-che/che-operator/pkg/common/constants"
+	"github.com/eclipse-che/che-operator/pkg/common/infrastructure"
+	userv1 "github.com/openshift/api/user/v1"
+	"github.com/stretchr/testify/assert"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func getCheClusterWithGroupRoles() *chev2.CheCluster {
+	return &chev2.CheCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "eclipse-che",
+			Namespace: "eclipse-che",
+		},
+		Spec: chev2.CheClusterSpec{
+			DevEnvironments: chev2.CheClusterDevEnvironments{
+				User: &chev2.UserConfiguration{
+					GroupRoles: []chev2.GroupRoles{
+						{
+							Group:        "developers",
+							ClusterRoles: []string{"view"},
+						},
+						{
+							Group:        "admins",
+							ClusterRoles: []string{"view", "edit"},
+							Roles:        []string{"secrets-reader"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestGroupRolesFromOpenShiftGroups(t *testing.T) {
+	ns, project := getUserNamespace("ns1", "user_1", nil)
+	developers := &userv1.Group{
+		ObjectMeta: metav1.ObjectMeta{Name: "developers"},
+		Users:      userv1.OptionalNames{"user_1"},
+	}
+	admins := &userv1.Group{
+		ObjectMeta: metav1.ObjectMeta{Name: "admins"},
+		Users:      userv1.OptionalNames{"user_1", "user_2"},
+	}
+
+	_, cl, r := setup(infrastructure.OpenShiftV4, ns, project, developers, admins, getCheClusterWithGroupRoles())
+
+	_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "ns1"}})
+	assert.NoError(t, err)
+
+	rb := &rbacv1.RoleBinding{}
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: "che-group-clusterrole-view", Namespace: "ns1"}, rb))
+	assert.Equal(t, rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "view"}, rb.RoleRef)
+	assert.Equal(t, []rbacv1.Subject{{Kind: rbacv1.UserKind, APIGroup: rbacv1.GroupName, Name: "user_1"}}, rb.Subjects)
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: "che-group-clusterrole-edit", Namespace: "ns1"}, rb))
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: "che-group-role-secrets-reader", Namespace: "ns1"}, rb))
+	assert.Equal(t, rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: "secrets-reader"}, rb.RoleRef)
+
+	// user is no longer a member of the admins group
+	admins.Users = userv1.OptionalNames{"user_2"}
+	assert.NoError(t, cl.Update(context.TODO(), admins))
+
+	_, err = r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "ns1"}})
+	assert.NoError(t, err)
+
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: "che-group-clusterrole-view", Namespace: "ns1"}, rb))
+	err = cl.Get(context.TODO(), types.NamespacedName{Name: "che-group-clusterrole-edit", Namespace: "ns1"}, rb)
+	assert.True(t, errors.IsNotFound(err))
+	err = cl.Get(context.TODO(), types.NamespacedName{Name: "che-group-role-secrets-reader", Namespace: "ns1"}, rb)
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestGroupRolesFromGroupsClaim(t *testing.T) {
+	ns, _ := getUserNamespace("ns1", "user_1", map[string]string{
+		constants.KubernetesPartOfLabelKey:    constants.CheEclipseOrg,
+		constants.KubernetesComponentLabelKey: constants.WorkspacesNamespaceComponentName,
+	})
+
+	_, cl, r := setup(infrastructure.Kubernetes, ns, getCheClusterWithGroupRoles())
+	recorder := NewUserGroupsRecorder(cl, r.namespaceCache)
+
+	// The user creates a DevWorkspace, the API server authenticated the groups from the groups claim
+	response := recorder.Handle(context.TODO(), getDevWorkspaceAdmissionRequest("ns1", "user_1", "developers", "admins", "system:authenticated"))
+	assert.True(t, response.Allowed)
+
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: "ns1"}, ns))
+	assert.Equal(t, "admins,developers", ns.Annotations[constants.CheEclipseOrgGroups])
+
+	_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "ns1"}})
+	assert.NoError(t, err)
+
+	rb := &rbacv1.RoleBinding{}
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: "che-group-clusterrole-view", Namespace: "ns1"}, rb))
+	assert.Equal(t, []rbacv1.Subject{{Kind: rbacv1.UserKind, APIGroup: rbacv1.GroupName, Name: "user_1"}}, rb.Subjects)
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: "che-group-clusterrole-edit", Namespace: "ns1"}, rb))
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: "che-group-role-secrets-reader", Namespace: "ns1"}, rb))
+
+	// Requests of other users are ignored
+	response = recorder.Handle(context.TODO(), getDevWorkspaceAdmissionRequest("ns1", "system:serviceaccount:devworkspace-controller:devworkspace-controller-serviceaccount"))
+	assert.True(t, response.Allowed)
+
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: "ns1"}, ns))
+	assert.Equal(t, "admins,developers", ns.Annotations[constants.CheEclipseOrgGroups])
+
+	// user is no longer a member of the admins group
+	response = recorder.Handle(context.TODO(), getDevWorkspaceAdmissionRequest("ns1", "user_1", "developers", "system:authenticated"))
+	assert.True(t, response.Allowed)
+
+	_, err = r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "ns1"}})
+	assert.NoError(t, err)
+
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: "che-group-clusterrole-view", Namespace: "ns1"}, rb))
+	err = cl.Get(context.TODO(), types.NamespacedName{Name: "che-group-clusterrole-edit", Namespace: "ns1"}, rb)
+	assert.True(t, errors.IsNotFound(err))
+	err = cl.Get(context.TODO(), types.NamespacedName{Name: "che-group-role-secrets-reader", Namespace: "ns1"}, rb)
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestGroupRoleBindingName(t *testing.T) {
+	ns, _ := getUserNamespace("ns1", "user_1", nil)
+	ns.Annotations[constants.CheEclipseOrgGroups] = "developers"
+
+	checluster := getCheClusterWithGroupRoles()
+	checluster.Spec.DevEnvironments.User.GroupRoles[0].ClusterRoles = []string{"system:aggregate-to-edit", "system-aggregate-to-edit"}
+
+	_, cl, r := setup(infrastructure.Kubernetes, ns, checluster)
+
+	_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "ns1"}})
+	assert.NoError(t, err)
+
+	name := getGroupRoleBindingName("ClusterRole", "system:aggregate-to-edit")
+	assert.Regexp(t, "^che-group-clusterrole-system-aggregate-to-edit-[0-9a-f]{8}$", name)
+
+	rb := &rbacv1.RoleBinding{}
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: "ns1"}, rb))
+	assert.Equal(t, "system:aggregate-to-edit", rb.RoleRef.Name)
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: "che-group-clusterrole-system-aggregate-to-edit", Namespace: "ns1"}, rb))
+	assert.Equal(t, "system-aggregate-to-edit", rb.RoleRef.Name)
+}
+
+func TestGroupRolesFromGroupsClaimAndOpenShiftGroups(t *testing.T) {
+	ns, project := getUserNamespace("ns1", "user_1", nil)
+	ns.Annotations[constants.CheEclipseOrgGroups] = "developers"
+	admins := &userv1.Group{
+		ObjectMeta: metav1.ObjectMeta{Name: "admins"},
+		Users:      userv1.OptionalNames{"user_1"},
+	}
+
+	_, cl, r := setup(infrastructure.OpenShiftV4, ns, project, admins, getCheClusterWithGroupRoles())
+
+	_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "ns1"}})
+	assert.NoError(t, err)
+
+	rb := &rbacv1.RoleBinding{}
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: "che-group-clusterrole-view", Namespace: "ns1"}, rb))
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: "che-group-clusterrole-edit", Namespace: "ns1"}, rb))
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: "che-group-role-secrets-reader", Namespace: "ns1"}, rb))
+}
+
+func TestWatchRulesForGroups(t *testing.T) {
+	ns1, _ := getUserNamespace("ns1", "user_1", nil)
+	ns2, _ := getUserNamespace("ns2", "user_2", nil)
+	ns3, _ := getUserNamespace("ns3", "user_3", nil)
+
+	_, _, r := setup(infrastructure.Kubernetes, ns1, ns2, ns3, getCheClusterWithGroupRoles())
+
+	ctx := context.TODO()
+	for _, ns := range []string{"ns1", "ns2", "ns3"} {
+		_, _ = r.namespaceCache.ExamineNamespace(ctx, ns)
+	}
+
+	oldGroup := &userv1.Group{
+		ObjectMeta: metav1.ObjectMeta{Name: "developers"},
+		Users:      []string{"user_1"},
+	}
+	newGroup := &userv1.Group{
+		ObjectMeta: metav1.ObjectMeta{Name: "developers"},
+		Users:      []string{"user_2"},
+	}
+
+	h := r.watchRulesForGroups()
+	rlq := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+	h.Update(ctx, event.UpdateEvent{ObjectOld: oldGroup, ObjectNew: newGroup}, rlq)
+
+	assert.Equal(t, 2, rlq.Len())
+	rs1, _ := rlq.Get()
+	rs2, _ := rlq.Get()
+	reconciles := []reconcile.Request{rs1, rs2}
+	assert.Contains(t, reconciles, reconcile.Request{NamespacedName: types.NamespacedName{Name: "ns1"}})
+	assert.Contains(t, reconciles, reconcile.Request{NamespacedName: types.NamespacedName{Name: "ns2"}})
+}
+
+func getDevWorkspaceAdmissionRequest(namespace string, username string, groups ...string) admission.Request {
+	return admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: admissionv1.Create,
+			Namespace: namespace,
+			UserInfo: authenticationv1.UserInfo{
+				Username: username,
+				Groups:   groups,
+			},
+		},
+	}
+}
@@ -0,0 +1,148 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package usernamespace
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/eclipse-che/che-operator/pkg/common/constants"
+	"github.com/eclipse-che/che-operator/pkg/common/infrastructure"
+	k8sclient "github.com/eclipse-che/che-operator/pkg/common/k8s-client"
+	"github.com/eclipse-che/che-operator/pkg/common/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	usernamePlaceholder = "<username>"
+)
+
+// setNamespaceMetadata sets the labels and annotations defined in `spec.devEnvironments.defaultNamespace`
+// and the Pod Security Admission level on the user namespace. The keys set by the Operator are recorded in the namespace annotations,
+// so that the labels and annotations removed from the CheCluster are removed from the namespace as well.
+func (r *CheUserNamespaceReconciler) setNamespaceMetadata(
+	ns *corev1.Namespace,
+	username string,
+	checluster *chev2.CheCluster,
+	profile *chev2.DevEnvironmentProfile,
+) {
+	labels := maps.Clone(ns.GetLabels())
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	annotations := maps.Clone(ns.GetAnnotations())
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	desiredLabels := resolveNamespaceMetadata(checluster.Spec.DevEnvironments.DefaultNamespace.Labels, username)
+	for key, value := range desiredLabels {
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			r.recorder.Eventf(ns, corev1.EventTypeWarning, "InvalidNamespaceLabel",
+				"Label %s is not set, value %s is invalid: %s", key, value, strings.Join(errs, ", "))
+			delete(desiredLabels, key)
+		}
+	}
+
+	if podSecurityLevel := getPodSecurityEnforceLevel(checluster, profile); podSecurityLevel != "" {
+		desiredLabels[constants.PodSecurityEnforceLabelKey] = podSecurityLevel
+	}
+
+	desiredAnnotations := resolveNamespaceMetadata(checluster.Spec.DevEnvironments.DefaultNamespace.Annotations, username)
+
+	syncManagedKeys(labels, annotations, constants.CheEclipseOrgManagedLabels, desiredLabels)
+	syncManagedKeys(annotations, annotations, constants.CheEclipseOrgManagedAnnotations, desiredAnnotations)
+
+	ns.SetLabels(labels)
+	ns.SetAnnotations(annotations)
+}
+
+// patchNamespace patches the labels and annotations of the user namespace changed during the reconciliation at once.
+func (r *CheUserNamespaceReconciler) patchNamespace(ctx context.Context, originalNs *corev1.Namespace, ns *corev1.Namespace) error {
+	if maps.Equal(originalNs.GetLabels(), ns.GetLabels()) && maps.Equal(originalNs.GetAnnotations(), ns.GetAnnotations()) {
+		return nil
+	}
+
+	patch := client.MergeFrom(originalNs)
+	if originalNs.GetLabels()[constants.PodSecurityEnforceLabelKey] == ns.GetLabels()[constants.PodSecurityEnforceLabelKey] {
+		return r.client.Patch(ctx, ns, patch)
+	}
+
+	// The API server checks the existing pods against the new level and returns the violations as warnings
+	ctx, getWarnings := k8sclient.CollectWarnings(ctx)
+	if err := r.client.Patch(ctx, ns, patch); err != nil {
+		return err
+	}
+
+	for _, warning := range getWarnings() {
+		r.recorder.Eventf(ns, corev1.EventTypeWarning, "PodSecurityViolation", "%s", warning)
+	}
+	return nil
+}
+
+// getPodSecurityEnforceLevel returns the Pod Security Admission level enforced in the user namespace
+// depending on the container capabilities enabled for the profile, or an empty string if the levels are not managed.
+// The workspaces of the profile use a dedicated DevWorkspaceOperatorConfig without the security context
+// of the disabled capabilities, so they are admitted by the lower level.
+func getPodSecurityEnforceLevel(checluster *chev2.CheCluster, profile *chev2.DevEnvironmentProfile) string {
+	podSecurityAdmission := checluster.Spec.DevEnvironments.PodSecurityAdmission
+	if podSecurityAdmission == nil || infrastructure.IsOpenShift() {
+		return ""
+	}
+
+	if isContainerRunCapabilitiesEnabled(checluster, profile) {
+		return utils.GetValue(podSecurityAdmission.ContainerRunEnforce, constants.DefaultContainerRunPodSecurityLevel)
+	} else if isContainerBuildCapabilitiesEnabled(checluster, profile) {
+		return utils.GetValue(podSecurityAdmission.ContainerBuildEnforce, constants.DefaultContainerBuildPodSecurityLevel)
+	}
+	return utils.GetValue(podSecurityAdmission.Enforce, constants.DefaultPodSecurityLevel)
+}
+
+// resolveNamespaceMetadata replaces the `<username>` placeholder in the values.
+// The values with the placeholder are omitted if the username is unknown.
+func resolveNamespaceMetadata(values map[string]string, username string) map[string]string {
+	resolved := map[string]string{}
+	for key, value := range values {
+		if strings.Contains(value, usernamePlaceholder) {
+			if username == "" {
+				continue
+			}
+			value = strings.ReplaceAll(value, usernamePlaceholder, username)
+		}
+		resolved[key] = value
+	}
+	return resolved
+}
+
+// syncManagedKeys sets the desired keys in the target map and removes the keys set previously,
+// which are not desired anymore. The set keys are recorded in the annotations under the given key.
+func syncManagedKeys(target map[string]string, annotations map[string]string, managedKeysAnnotation string, desired map[string]string) {
+	for _, key := range strings.Split(annotations[managedKeysAnnotation], ",") {
+		if _, ok := desired[key]; !ok {
+			delete(target, key)
+		}
+	}
+
+	maps.Copy(target, desired)
+
+	if len(desired) == 0 {
+		delete(annotations, managedKeysAnnotation)
+	} else {
+		annotations[managedKeysAnnotation] = strings.Join(slices.Sorted(maps.Keys(desired)), ",")
+	}
+}
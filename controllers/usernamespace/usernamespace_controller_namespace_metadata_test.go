@@ -0,0 +1,235 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package usernamespace
+
+import (
+	"context"
+	"testing"
+
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/eclipse-che/che-operator/pkg/common/constants"
+	"github.com/eclipse-che/che-operator/pkg/common/infrastructure"
+	k8sclient "github.com/eclipse-che/che-operator/pkg/common/k8s-client"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestNamespaceMetadata(t *testing.T) {
+	ns, _ := getUserNamespace("ns1", "user_1", map[string]string{"team": "a"})
+	checluster := &chev2.CheCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "eclipse-che",
+			Namespace: "eclipse-che",
+		},
+		Spec: chev2.CheClusterSpec{
+			DevEnvironments: chev2.CheClusterDevEnvironments{
+				DefaultNamespace: chev2.DefaultNamespace{
+					Labels: map[string]string{
+						"cost-center":     "che-<username>",
+						"istio-injection": "disabled",
+					},
+					Annotations: map[string]string{
+						"example.com/owner": "<username>@example.com",
+					},
+				},
+			},
+		},
+	}
+
+	_, cl, r := setup(infrastructure.Kubernetes, ns, checluster)
+
+	_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "ns1"}})
+	assert.NoError(t, err)
+
+	updatedNs := &corev1.Namespace{}
+	assert.NoError(t, cl.Get(context.TODO(), client.ObjectKey{Name: "ns1"}, updatedNs))
+	assert.Equal(t, "che-user_1", updatedNs.Labels["cost-center"])
+	assert.Equal(t, "disabled", updatedNs.Labels["istio-injection"])
+	assert.Equal(t, "a", updatedNs.Labels["team"])
+	assert.Equal(t, "user_1@example.com", updatedNs.Annotations["example.com/owner"])
+	assert.Equal(t, "cost-center,istio-injection", updatedNs.Annotations[constants.CheEclipseOrgManagedLabels])
+
+	// manual changes are reverted
+	updatedNs.Labels["cost-center"] = "other"
+	assert.NoError(t, cl.Update(context.TODO(), updatedNs))
+
+	_, err = r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "ns1"}})
+	assert.NoError(t, err)
+
+	assert.NoError(t, cl.Get(context.TODO(), client.ObjectKey{Name: "ns1"}, updatedNs))
+	assert.Equal(t, "che-user_1", updatedNs.Labels["cost-center"])
+
+	// labels and annotations are removed from the CheCluster
+	assert.NoError(t, cl.Get(context.TODO(), client.ObjectKeyFromObject(checluster), checluster))
+	checluster.Spec.DevEnvironments.DefaultNamespace.Labels = map[string]string{"istio-injection": "disabled"}
+	checluster.Spec.DevEnvironments.DefaultNamespace.Annotations = nil
+	assert.NoError(t, cl.Update(context.TODO(), checluster))
+
+	_, err = r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "ns1"}})
+	assert.NoError(t, err)
+
+	assert.NoError(t, cl.Get(context.TODO(), client.ObjectKey{Name: "ns1"}, updatedNs))
+	assert.NotContains(t, updatedNs.Labels, "cost-center")
+	assert.Equal(t, "disabled", updatedNs.Labels["istio-injection"])
+	assert.Equal(t, "a", updatedNs.Labels["team"])
+	assert.NotContains(t, updatedNs.Annotations, "example.com/owner")
+	assert.NotContains(t, updatedNs.Annotations, constants.CheEclipseOrgManagedAnnotations)
+	assert.Equal(t, "istio-injection", updatedNs.Annotations[constants.CheEclipseOrgManagedLabels])
+}
+
+func TestNamespaceMetadataSkipsInvalidLabel(t *testing.T) {
+	ns, _ := getUserNamespace("ns1", "john@example.com", nil)
+	checluster := &chev2.CheCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "eclipse-che",
+			Namespace: "eclipse-che",
+		},
+		Spec: chev2.CheClusterSpec{
+			DevEnvironments: chev2.CheClusterDevEnvironments{
+				DefaultNamespace: chev2.DefaultNamespace{
+					Labels: map[string]string{
+						"owner":       "<username>",
+						"cost-center": "che",
+					},
+				},
+			},
+		},
+	}
+
+	_, cl, r := setup(infrastructure.Kubernetes, ns, checluster)
+
+	_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "ns1"}})
+	assert.NoError(t, err)
+
+	updatedNs := &corev1.Namespace{}
+	assert.NoError(t, cl.Get(context.TODO(), client.ObjectKey{Name: "ns1"}, updatedNs))
+	assert.NotContains(t, updatedNs.Labels, "owner")
+	assert.Equal(t, "che", updatedNs.Labels["cost-center"])
+
+	recorder := r.recorder.(*record.FakeRecorder)
+	select {
+	case event := <-recorder.Events:
+		assert.Contains(t, event, "InvalidNamespaceLabel")
+	default:
+		t.Fatal("expected an InvalidNamespaceLabel event")
+	}
+}
+
+func TestPodSecurityEnforceLevel(t *testing.T) {
+	ns, _ := getUserNamespace("ns1", "user_1", nil)
+	checluster := &chev2.CheCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "eclipse-che",
+			Namespace: "eclipse-che",
+		},
+		Spec: chev2.CheClusterSpec{
+			DevEnvironments: chev2.CheClusterDevEnvironments{
+				DisableContainerBuildCapabilities: ptr.To(true),
+				DisableContainerRunCapabilities:   ptr.To(true),
+				PodSecurityAdmission:              &chev2.PodSecurityAdmission{},
+			},
+		},
+	}
+
+	_, cl, r := setup(infrastructure.Kubernetes, ns, checluster)
+	defer infrastructure.InitializeForTesting(infrastructure.OpenShiftV4)
+
+	// the API server reports the existing pods violating the new level
+	r.client = interceptor.NewClient(cl.(client.WithWatch), interceptor.Funcs{
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			if _, ok := obj.(*corev1.Namespace); ok {
+				k8sclient.NewWarningHandler(nil).HandleWarningHeaderWithContext(ctx, 299, "", "existing pods in namespace \"ns1\" violate the new PodSecurity enforce level")
+			}
+			return c.Patch(ctx, obj, patch, opts...)
+		},
+	})
+
+	assertPodSecurityEnforceLevel := func(expected string) {
+		_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "ns1"}})
+		assert.NoError(t, err)
+
+		updatedNs := &corev1.Namespace{}
+		assert.NoError(t, cl.Get(context.TODO(), client.ObjectKey{Name: "ns1"}, updatedNs))
+		assert.Equal(t, expected, updatedNs.Labels[constants.PodSecurityEnforceLabelKey])
+	}
+
+	assertPodSecurityEnforceLevel("restricted")
+
+	recorder := r.recorder.(*record.FakeRecorder)
+	select {
+	case event := <-recorder.Events:
+		assert.Contains(t, event, "PodSecurityViolation")
+	default:
+		t.Fatal("expected a PodSecurityViolation event")
+	}
+
+	assert.NoError(t, cl.Get(context.TODO(), client.ObjectKeyFromObject(checluster), checluster))
+	checluster.Spec.DevEnvironments.DisableContainerBuildCapabilities = ptr.To(false)
+	assert.NoError(t, cl.Update(context.TODO(), checluster))
+	assertPodSecurityEnforceLevel("baseline")
+
+	assert.NoError(t, cl.Get(context.TODO(), client.ObjectKeyFromObject(checluster), checluster))
+	checluster.Spec.DevEnvironments.DisableContainerRunCapabilities = ptr.To(false)
+	assert.NoError(t, cl.Update(context.TODO(), checluster))
+	assertPodSecurityEnforceLevel("privileged")
+
+	// the levels are not managed anymore
+	assert.NoError(t, cl.Get(context.TODO(), client.ObjectKeyFromObject(checluster), checluster))
+	checluster.Spec.DevEnvironments.PodSecurityAdmission = nil
+	assert.NoError(t, cl.Update(context.TODO(), checluster))
+	assertPodSecurityEnforceLevel("")
+}
+
+func TestPodSecurityEnforceLevelPerProfile(t *testing.T) {
+	ns1, _ := getUserNamespace("ns1", "user_1", map[string]string{"team": "a"})
+	ns2, _ := getUserNamespace("ns2", "user_2", nil)
+	checluster := &chev2.CheCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "eclipse-che",
+			Namespace: "eclipse-che",
+		},
+		Spec: chev2.CheClusterSpec{
+			DevEnvironments: chev2.CheClusterDevEnvironments{
+				DisableContainerBuildCapabilities: ptr.To(false),
+				DisableContainerRunCapabilities:   ptr.To(false),
+				PodSecurityAdmission:              &chev2.PodSecurityAdmission{},
+				Profiles: []chev2.DevEnvironmentProfile{
+					{
+						Name:                            "no-container-run",
+						NamespaceSelector:               &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+						DisableContainerRunCapabilities: ptr.To(true),
+					},
+				},
+			},
+		},
+	}
+
+	_, cl, r := setup(infrastructure.Kubernetes, ns1, ns2, checluster)
+	defer infrastructure.InitializeForTesting(infrastructure.OpenShiftV4)
+
+	for ns, expected := range map[string]string{"ns1": "baseline", "ns2": "privileged"} {
+		_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: ns}})
+		assert.NoError(t, err)
+
+		updatedNs := &corev1.Namespace{}
+		assert.NoError(t, cl.Get(context.TODO(), client.ObjectKey{Name: ns}, updatedNs))
+		assert.Equal(t, expected, updatedNs.Labels[constants.PodSecurityEnforceLabelKey])
+	}
+}
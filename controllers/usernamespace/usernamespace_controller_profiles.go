@@ -0,0 +1,193 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package usernamespace
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	dw "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/v2/pkg/attributes"
+	dwconstants "github.com/devfile/devworkspace-operator/pkg/constants"
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/eclipse-che/che-operator/pkg/common/infrastructure"
+	"github.com/eclipse-che/che-operator/pkg/deploy/devworkspace"
+	userv1 "github.com/openshift/api/user/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// getDevEnvironmentProfile returns the first profile from `spec.devEnvironments.profiles`
+// matching the given user namespace, or nil if there is no such profile.
+func (r *CheUserNamespaceReconciler) getDevEnvironmentProfile(
+	ctx context.Context,
+	ns *corev1.Namespace,
+	username string,
+	checluster *chev2.CheCluster,
+) (*chev2.DevEnvironmentProfile, error) {
+	for i := range checluster.Spec.DevEnvironments.Profiles {
+		profile := &checluster.Spec.DevEnvironments.Profiles[i]
+
+		matched, err := r.isProfileMatched(ctx, profile, ns, username)
+		if err != nil {
+			return nil, err
+		}
+
+		if matched {
+			return profile, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *CheUserNamespaceReconciler) isProfileMatched(
+	ctx context.Context,
+	profile *chev2.DevEnvironmentProfile,
+	ns *corev1.Namespace,
+	username string,
+) (bool, error) {
+	if profile.NamespaceSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(profile.NamespaceSelector)
+		if err != nil {
+			return false, fmt.Errorf("invalid namespace selector in profile %s: %w", profile.Name, err)
+		}
+
+		if !selector.Empty() && selector.Matches(labels.Set(ns.GetLabels())) {
+			return true, nil
+		}
+	}
+
+	// User groups are only available with OpenShift built-in OAuth, the groups are watched in this case
+	if username == "" || !infrastructure.IsOpenShiftOAuthEnabled() {
+		return false, nil
+	}
+
+	for _, groupName := range profile.Groups {
+		group := &userv1.Group{}
+		exists, err := r.clientWrapper.GetIgnoreNotFound(ctx, client.ObjectKey{Name: groupName}, group)
+		if err != nil {
+			return false, err
+		}
+
+		if exists && slices.Contains(group.Users, username) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func getNodeSelector(checluster *chev2.CheCluster, profile *chev2.DevEnvironmentProfile) map[string]string {
+	if profile != nil && len(profile.NodeSelector) != 0 {
+		return profile.NodeSelector
+	}
+
+	return checluster.Spec.DevEnvironments.NodeSelector
+}
+
+func getTolerations(checluster *chev2.CheCluster, profile *chev2.DevEnvironmentProfile) []corev1.Toleration {
+	if profile != nil && len(profile.Tolerations) != 0 {
+		return profile.Tolerations
+	}
+
+	return checluster.Spec.DevEnvironments.Tolerations
+}
+
+func isContainerBuildCapabilitiesEnabled(checluster *chev2.CheCluster, profile *chev2.DevEnvironmentProfile) bool {
+	if profile != nil && ptr.Deref(profile.DisableContainerBuildCapabilities, false) {
+		return false
+	}
+
+	return checluster.IsContainerBuildCapabilitiesEnabled()
+}
+
+func isContainerRunCapabilitiesEnabled(checluster *chev2.CheCluster, profile *chev2.DevEnvironmentProfile) bool {
+	if profile != nil && ptr.Deref(profile.DisableContainerRunCapabilities, false) {
+		return false
+	}
+
+	return checluster.IsContainerRunCapabilitiesEnabled()
+}
+
+// reconcileDevWorkspacesConfig refers the DevWorkspaces of the user namespace to the DevWorkspaceOperatorConfig
+// of the profile, or to the default one. The DevWorkspaces referring to a configuration not managed by the Operator
+// are left intact. The started workspaces, including the ones still starting, are updated once stopped,
+// so that they are not restarted or changed mid-start.
+func (r *CheUserNamespaceReconciler) reconcileDevWorkspacesConfig(
+	ctx context.Context,
+	targetNs string,
+	checluster *chev2.CheCluster,
+	profile *chev2.DevEnvironmentProfile,
+) error {
+	workspaces := &dw.DevWorkspaceList{}
+	if err := r.client.List(ctx, workspaces, client.InNamespace(targetNs)); err != nil {
+		return err
+	}
+
+	desiredConfig := types.NamespacedName{
+		Name:      devworkspace.GetDevWorkspaceConfigName(profile),
+		Namespace: checluster.Namespace,
+	}
+
+	for i := range workspaces.Items {
+		workspace := &workspaces.Items[i]
+		if workspace.Spec.Started {
+			continue
+		}
+
+		workspaceAttributes := workspace.Spec.Template.Attributes
+		if workspaceAttributes.Exists(dwconstants.ExternalDevWorkspaceConfiguration) {
+			config := types.NamespacedName{}
+			if err := workspaceAttributes.GetInto(dwconstants.ExternalDevWorkspaceConfiguration, &config); err != nil ||
+				config == desiredConfig ||
+				!isManagedDevWorkspaceConfig(config, checluster) {
+				continue
+			}
+		} else if profile == nil {
+			continue
+		}
+
+		patch := client.MergeFrom(workspace.DeepCopy())
+		if workspace.Spec.Template.Attributes == nil {
+			workspace.Spec.Template.Attributes = attributes.Attributes{}
+		}
+
+		var err error
+		workspace.Spec.Template.Attributes.Put(
+			dwconstants.ExternalDevWorkspaceConfiguration,
+			map[string]string{"name": desiredConfig.Name, "namespace": desiredConfig.Namespace},
+			&err,
+		)
+		if err != nil {
+			return err
+		}
+
+		if err = r.client.Patch(ctx, workspace, patch); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func isManagedDevWorkspaceConfig(config types.NamespacedName, checluster *chev2.CheCluster) bool {
+	return config.Namespace == checluster.Namespace &&
+		strings.HasPrefix(config.Name, devworkspace.GetDevWorkspaceConfigName(nil))
+}
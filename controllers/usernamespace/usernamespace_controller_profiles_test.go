@@ -0,0 +1,217 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package usernamespace
+
+import (
+	"context"
+	"testing"
+
+	dw "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/v2/pkg/attributes"
+	dwconstants "github.com/devfile/devworkspace-operator/pkg/constants"
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/eclipse-che/che-operator/pkg/common/constants"
+	"github.com/eclipse-che/che-operator/pkg/common/infrastructure"
+	containercapabilities "github.com/eclipse-che/che-operator/pkg/deploy/container-capabilities"
+	projectv1 "github.com/openshift/api/project/v1"
+	userv1 "github.com/openshift/api/user/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func getCheClusterWithProfiles() *chev2.CheCluster {
+	return &chev2.CheCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "eclipse-che",
+			Namespace: "eclipse-che",
+		},
+		Spec: chev2.CheClusterSpec{
+			DevEnvironments: chev2.CheClusterDevEnvironments{
+				NodeSelector:                      map[string]string{"global": "true"},
+				DisableContainerBuildCapabilities: ptr.To(false),
+				ContainerBuildConfiguration: &chev2.ContainerBuildConfiguration{
+					OpenShiftSecurityContextConstraint: "container-build",
+				},
+				DisableContainerRunCapabilities: ptr.To(false),
+				ContainerRunConfiguration: &chev2.ContainerRunConfiguration{
+					OpenShiftSecurityContextConstraint: "container-run",
+				},
+				Profiles: []chev2.DevEnvironmentProfile{
+					{
+						Name:              "data-science",
+						NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "data-science"}},
+						NodeSelector:      map[string]string{"gpu": "true"},
+						Tolerations: []corev1.Toleration{
+							{
+								Key:      "nvidia.com/gpu",
+								Operator: corev1.TolerationOpExists,
+								Effect:   corev1.TaintEffectNoSchedule,
+							},
+						},
+					},
+					{
+						Name:                            "contractors",
+						Groups:                          []string{"contractors"},
+						DisableContainerRunCapabilities: ptr.To(true),
+					},
+				},
+			},
+		},
+	}
+}
+
+func getUserNamespace(name string, username string, labels map[string]string) (*corev1.Namespace, *projectv1.Project) {
+	nsLabels := map[string]string{constants.WorkspaceNamespaceOwnerUidLabelKey: "uid_" + username}
+	for k, v := range labels {
+		nsLabels[k] = v
+	}
+
+	objectMeta := metav1.ObjectMeta{
+		Name:        name,
+		Labels:      nsLabels,
+		Annotations: map[string]string{constants.CheEclipseOrgUsername: username},
+	}
+
+	return &corev1.Namespace{ObjectMeta: objectMeta}, &projectv1.Project{ObjectMeta: *objectMeta.DeepCopy()}
+}
+
+func TestDevEnvironmentProfileSelectedByNamespaceLabel(t *testing.T) {
+	ns, project := getUserNamespace("ns1", "user_1", map[string]string{"team": "data-science"})
+
+	_, cl, r := setup(infrastructure.OpenShiftV4, ns, project, getCheClusterWithProfiles())
+
+	_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "ns1"}})
+	assert.NoError(t, err)
+
+	updatedNs := &corev1.Namespace{}
+	assert.NoError(t, cl.Get(context.TODO(), client.ObjectKey{Name: "ns1"}, updatedNs))
+	assert.Equal(t, `{"gpu":"true"}`, updatedNs.GetAnnotations()[nodeSelectorAnnotation])
+	assert.Equal(t, `[{"key":"nvidia.com/gpu","operator":"Exists","effect":"NoSchedule"}]`, updatedNs.GetAnnotations()[podTolerationsAnnotation])
+
+	rb := &rbacv1.RoleBinding{}
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: containercapabilities.NewContainerRun().GetUserClusterRoleBindingName(), Namespace: "ns1"}, rb))
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: containercapabilities.NewContainerBuild().GetUserClusterRoleBindingName(), Namespace: "ns1"}, rb))
+}
+
+func TestDevEnvironmentProfileSelectedByGroup(t *testing.T) {
+	ns, project := getUserNamespace("ns1", "user_1", nil)
+	group := &userv1.Group{
+		ObjectMeta: metav1.ObjectMeta{Name: "contractors"},
+		Users:      userv1.OptionalNames{"user_1"},
+	}
+
+	_, cl, r := setup(infrastructure.OpenShiftV4, ns, project, group, getCheClusterWithProfiles())
+
+	_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "ns1"}})
+	assert.NoError(t, err)
+
+	updatedNs := &corev1.Namespace{}
+	assert.NoError(t, cl.Get(context.TODO(), client.ObjectKey{Name: "ns1"}, updatedNs))
+	assert.Equal(t, `{"global":"true"}`, updatedNs.GetAnnotations()[nodeSelectorAnnotation])
+
+	rb := &rbacv1.RoleBinding{}
+	err = cl.Get(context.TODO(), types.NamespacedName{Name: containercapabilities.NewContainerRun().GetUserClusterRoleBindingName(), Namespace: "ns1"}, rb)
+	assert.True(t, errors.IsNotFound(err), "container run capability should be revoked by the profile")
+
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: containercapabilities.NewContainerBuild().GetUserClusterRoleBindingName(), Namespace: "ns1"}, rb))
+}
+
+func TestNoDevEnvironmentProfileMatched(t *testing.T) {
+	ns, project := getUserNamespace("ns1", "user_1", map[string]string{"team": "backend"})
+	group := &userv1.Group{
+		ObjectMeta: metav1.ObjectMeta{Name: "contractors"},
+		Users:      userv1.OptionalNames{"user_2"},
+	}
+
+	_, cl, r := setup(infrastructure.OpenShiftV4, ns, project, group, getCheClusterWithProfiles())
+
+	_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "ns1"}})
+	assert.NoError(t, err)
+
+	updatedNs := &corev1.Namespace{}
+	assert.NoError(t, cl.Get(context.TODO(), client.ObjectKey{Name: "ns1"}, updatedNs))
+	assert.Equal(t, `{"global":"true"}`, updatedNs.GetAnnotations()[nodeSelectorAnnotation])
+	assert.Empty(t, updatedNs.GetAnnotations()[podTolerationsAnnotation])
+
+	rb := &rbacv1.RoleBinding{}
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: containercapabilities.NewContainerRun().GetUserClusterRoleBindingName(), Namespace: "ns1"}, rb))
+}
+
+func TestDevEnvironmentProfileWorkspaceConfig(t *testing.T) {
+	ns, project := getUserNamespace("ns1", "user_1", map[string]string{"team": "data-science"})
+	checluster := getCheClusterWithProfiles()
+
+	getWorkspace := func(name string, started bool, phase dw.DevWorkspacePhase, configName string) *dw.DevWorkspace {
+		workspace := &dw.DevWorkspace{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns1"},
+			Spec:       dw.DevWorkspaceSpec{Started: started},
+			Status:     dw.DevWorkspaceStatus{Phase: phase},
+		}
+		if configName != "" {
+			workspace.Spec.Template.Attributes = attributes.Attributes{}.Put(
+				dwconstants.ExternalDevWorkspaceConfiguration,
+				map[string]string{"name": configName, "namespace": "eclipse-che"},
+				nil,
+			)
+		}
+		return workspace
+	}
+
+	_, cl, r := setup(
+		infrastructure.OpenShiftV4,
+		ns,
+		project,
+		checluster,
+		getWorkspace("stopped", false, dw.DevWorkspaceStatusStopped, "devworkspace-config"),
+		getWorkspace("running", true, dw.DevWorkspaceStatusRunning, "devworkspace-config"),
+		getWorkspace("starting", true, dw.DevWorkspaceStatusStarting, "devworkspace-config"),
+		getWorkspace("custom", false, dw.DevWorkspaceStatusStopped, "custom-config"),
+	)
+
+	assertWorkspaceConfig := func(name string, expected string) {
+		workspace := &dw.DevWorkspace{}
+		assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: "ns1"}, workspace))
+
+		config := types.NamespacedName{}
+		assert.NoError(t, workspace.Spec.Template.Attributes.GetInto(dwconstants.ExternalDevWorkspaceConfiguration, &config))
+		assert.Equal(t, types.NamespacedName{Name: expected, Namespace: "eclipse-che"}, config)
+	}
+
+	_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "ns1"}})
+	assert.NoError(t, err)
+
+	assertWorkspaceConfig("stopped", "devworkspace-config-data-science")
+	assertWorkspaceConfig("running", "devworkspace-config")
+	assertWorkspaceConfig("starting", "devworkspace-config")
+	assertWorkspaceConfig("custom", "custom-config")
+
+	updatedNs := &corev1.Namespace{}
+	assert.NoError(t, cl.Get(context.TODO(), client.ObjectKey{Name: "ns1"}, updatedNs))
+
+	// the profile does not match anymore
+	updatedNs.Labels["team"] = "backend"
+	assert.NoError(t, cl.Update(context.TODO(), updatedNs))
+
+	_, err = r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "ns1"}})
+	assert.NoError(t, err)
+
+	assertWorkspaceConfig("stopped", "devworkspace-config")
+	assertWorkspaceConfig("custom", "custom-config")
+}
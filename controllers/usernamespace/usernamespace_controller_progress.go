@@ -0,0 +1,289 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package usernamespace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/eclipse-che/che-operator/controllers/che"
+	"github.com/eclipse-che/che-operator/pkg/common/chetypes"
+	"github.com/eclipse-che/che-operator/pkg/common/constants"
+	"github.com/eclipse-che/che-operator/pkg/common/utils"
+	"github.com/eclipse-che/che-operator/pkg/deploy"
+	"github.com/eclipse-che/che-operator/pkg/deploy/tls"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	reconciliationStatusSyncPeriod = 15 * time.Second
+)
+
+// namespacesTracker records the hash of the inputs each user namespace was last reconciled with
+// and the progress of the reconciliation of the namespaces enqueued by a fan-out.
+type namespacesTracker struct {
+	mu           sync.Mutex
+	inputsHashes map[string]string
+	pending      map[string]bool
+	status       chev2.UserNamespacesReconciliationStatus
+	changed      bool
+
+	// inputsHash is the last computed hash of the inputs, for the CheCluster and the DWO namespace identified by inputsKey
+	inputsHash string
+	inputsKey  string
+}
+
+// namespaceInputs are the inputs of the reconciliation shared by all the user namespaces.
+// Changes in the user namespace itself are handled by the watches and are not part of the inputs.
+type namespaceInputs struct {
+	CheClusterName      string                          `json:"cheClusterName"`
+	CheClusterNamespace string                          `json:"cheClusterNamespace"`
+	DevEnvironments     chev2.CheClusterDevEnvironments `json:"devEnvironments"`
+	NetworkPolicy       *chev2.NetworkPolicy            `json:"networkPolicy,omitempty"`
+	DWONamespace        string                          `json:"dwoNamespace"`
+	// Proxy is the proxy configuration resolved from the CheCluster and the cluster-wide proxy
+	Proxy *chetypes.Proxy `json:"proxy,omitempty"`
+	// Resource versions of the objects synced from the CheCluster namespace
+	ResourceVersions map[string]string `json:"resourceVersions"`
+}
+
+func newNamespacesTracker() *namespacesTracker {
+	return &namespacesTracker{
+		inputsHashes: map[string]string{},
+		pending:      map[string]bool{},
+	}
+}
+
+// getOutdatedNamespaces returns the namespaces reconciled with other inputs than the given ones
+// and tracks the progress of their reconciliation.
+func (t *namespacesTracker) getOutdatedNamespaces(namespaces []string, inputsHash string) []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var outdated []string
+	for _, ns := range namespaces {
+		if t.inputsHashes[ns] != inputsHash {
+			outdated = append(outdated, ns)
+		}
+	}
+
+	if len(t.pending) == 0 {
+		// start a new round
+		now := metav1.Now()
+		t.status = chev2.UserNamespacesReconciliationStatus{StartTime: &now}
+		if len(outdated) == 0 {
+			t.status.CompletionTime = &now
+		}
+	}
+
+	for _, ns := range outdated {
+		if !t.pending[ns] {
+			t.pending[ns] = true
+			t.status.Total++
+		}
+	}
+	t.status.Skipped = int32(len(namespaces) - len(outdated))
+	t.changed = true
+
+	return outdated
+}
+
+// getInputsHash returns the last computed hash of the inputs if it was computed for the given key.
+func (t *namespacesTracker) getInputsHash(key string) (string, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.inputsHash, t.inputsHash != "" && t.inputsKey == key
+}
+
+func (t *namespacesTracker) setInputsHash(key string, inputsHash string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.inputsKey = key
+	t.inputsHash = inputsHash
+}
+
+// setReconciled records the inputs the namespace is reconciled with.
+func (t *namespacesTracker) setReconciled(ns string, inputsHash string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.inputsHashes[ns] = inputsHash
+	t.setDoneUnsafe(ns)
+}
+
+// forget removes the namespace, which is not a user namespace anymore.
+func (t *namespacesTracker) forget(ns string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.inputsHashes, ns)
+	t.setDoneUnsafe(ns)
+}
+
+func (t *namespacesTracker) setDoneUnsafe(ns string) {
+	if !t.pending[ns] {
+		return
+	}
+
+	delete(t.pending, ns)
+	t.status.Reconciled++
+	if len(t.pending) == 0 {
+		now := metav1.Now()
+		t.status.CompletionTime = &now
+	}
+	t.changed = true
+}
+
+// setChanged forces the progress to be synced on the next call of popStatus.
+func (t *namespacesTracker) setChanged() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.changed = true
+}
+
+// popStatus returns the progress if it has changed since the previous call.
+func (t *namespacesTracker) popStatus() (*chev2.UserNamespacesReconciliationStatus, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.changed {
+		return nil, false
+	}
+
+	t.changed = false
+	return t.status.DeepCopy(), true
+}
+
+// getNamespacesToReconcile returns the known user namespaces whose inputs have changed.
+func (r *CheUserNamespaceReconciler) getNamespacesToReconcile(ctx context.Context) []string {
+	checluster, err := deploy.FindCheClusterCRInNamespace(r.client, "")
+	if err != nil || checluster == nil {
+		return r.namespaceCache.GetAllKnownNamespaces()
+	}
+
+	inputsHash, err := r.computeInputsHash(ctx, checluster)
+	if err != nil {
+		logger.Error(err, "Failed to compute the inputs hash of the user namespaces")
+		return r.namespaceCache.GetAllKnownNamespaces()
+	}
+
+	return r.tracker.getOutdatedNamespaces(r.namespaceCache.GetAllKnownNamespaces(), inputsHash)
+}
+
+// getInputsHash returns the hash of the inputs of the user namespaces.
+// The hash is recomputed when the CheCluster spec or the DWO namespace change only,
+// the changes of the other inputs are watched and recompute it in getNamespacesToReconcile,
+// so that the proxy configuration and the synced objects are not read on every reconcile.
+func (r *CheUserNamespaceReconciler) getInputsHash(ctx context.Context, checluster *chev2.CheCluster) (string, error) {
+	if inputsHash, ok := r.tracker.getInputsHash(r.getInputsKey(checluster)); ok {
+		return inputsHash, nil
+	}
+
+	return r.computeInputsHash(ctx, checluster)
+}
+
+func (r *CheUserNamespaceReconciler) getInputsKey(checluster *chev2.CheCluster) string {
+	return fmt.Sprintf("%s/%d/%s", checluster.UID, checluster.Generation, r.getDWONamespace())
+}
+
+// computeInputsHash computes the hash of the inputs of the user namespaces and records it.
+func (r *CheUserNamespaceReconciler) computeInputsHash(ctx context.Context, checluster *chev2.CheCluster) (string, error) {
+	key := r.getInputsKey(checluster)
+	inputs := namespaceInputs{
+		CheClusterName:      checluster.Name,
+		CheClusterNamespace: checluster.Namespace,
+		DevEnvironments:     checluster.Spec.DevEnvironments,
+		NetworkPolicy:       checluster.Spec.Networking.NetworkPolicy,
+		DWONamespace:        r.getDWONamespace(),
+		ResourceVersions:    map[string]string{},
+	}
+
+	sources := map[string]client.Object{
+		constants.DefaultSelfSignedCertificateSecretName: &corev1.Secret{},
+		tls.CheMergedCABundleCertsCMName:                 &corev1.ConfigMap{},
+	}
+	if checluster.Spec.DevEnvironments.TrustedCerts != nil && checluster.Spec.DevEnvironments.TrustedCerts.GitTrustedCertsConfigMapName != "" {
+		sources[checluster.Spec.DevEnvironments.TrustedCerts.GitTrustedCertsConfigMapName] = &corev1.ConfigMap{}
+	}
+
+	proxy, err := che.GetProxyConfiguration(&chetypes.DeployContext{
+		CheCluster: checluster,
+		ClusterAPI: chetypes.ClusterAPI{
+			Client:           r.client,
+			NonCachingClient: r.nonCachedClient,
+			ClientWrapper:    r.clientWrapper,
+			Scheme:           r.scheme,
+		},
+		Context: ctx,
+	})
+	if err != nil {
+		return "", err
+	}
+	inputs.Proxy = proxy
+
+	for name, obj := range sources {
+		exists, err := r.clientWrapper.GetIgnoreNotFound(ctx, client.ObjectKey{Name: name, Namespace: checluster.Namespace}, obj)
+		if err != nil {
+			return "", err
+		}
+
+		if exists {
+			inputs.ResourceVersions[name] = obj.GetResourceVersion()
+		}
+	}
+
+	data, err := json.Marshal(inputs)
+	if err != nil {
+		return "", err
+	}
+
+	inputsHash := utils.ComputeHash256(data)
+	r.tracker.setInputsHash(key, inputsHash)
+
+	return inputsHash, nil
+}
+
+// syncReconciliationStatus reports the progress of the reconciliation of the user namespaces in the CheCluster status.
+// The status is synced periodically rather than on every reconcile to limit the number of updates.
+func (r *CheUserNamespaceReconciler) syncReconciliationStatus(ctx context.Context) {
+	status, changed := r.tracker.popStatus()
+	if !changed {
+		return
+	}
+
+	checluster, err := deploy.FindCheClusterCRInNamespace(r.client, "")
+	if err != nil || checluster == nil {
+		return
+	}
+
+	if reflect.DeepEqual(checluster.Status.UserNamespacesReconciliation, status) {
+		return
+	}
+
+	patch := client.MergeFrom(checluster.DeepCopy())
+	checluster.Status.UserNamespacesReconciliation = status
+	if err := r.client.Status().Patch(ctx, checluster, patch); err != nil {
+		logger.Error(err, "Failed to update the user namespaces reconciliation status")
+		r.tracker.setChanged()
+	}
+}
@@ -0,0 +1,165 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package usernamespace
+
+import (
+	"context"
+	"testing"
+
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/eclipse-che/che-operator/pkg/common/infrastructure"
+	"github.com/eclipse-che/che-operator/pkg/deploy/tls"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestSkipNamespacesReconciledWithSameInputs(t *testing.T) {
+	ns1, _ := getUserNamespace("ns1", "user_1", nil)
+	ns2, _ := getUserNamespace("ns2", "user_2", nil)
+	checluster := &chev2.CheCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "eclipse-che",
+			Namespace: "eclipse-che",
+		},
+	}
+
+	_, cl, r := setup(infrastructure.Kubernetes, ns1, ns2, checluster)
+
+	for _, ns := range []string{"ns1", "ns2"} {
+		_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: ns}})
+		assert.NoError(t, err)
+	}
+
+	// inputs are unchanged
+	assert.Empty(t, r.getNamespacesToReconcile(context.TODO()))
+
+	status, changed := r.tracker.popStatus()
+	assert.True(t, changed)
+	assert.Equal(t, int32(0), status.Total)
+	assert.Equal(t, int32(2), status.Skipped)
+	assert.NotNil(t, status.CompletionTime)
+
+	// inputs are changed
+	assert.NoError(t, cl.Get(context.TODO(), client.ObjectKeyFromObject(checluster), checluster))
+	checluster.Spec.DevEnvironments.SecondsOfInactivityBeforeIdling = ptr.To(int32(600))
+	assert.NoError(t, cl.Update(context.TODO(), checluster))
+
+	assert.ElementsMatch(t, []string{"ns1", "ns2"}, r.getNamespacesToReconcile(context.TODO()))
+
+	_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "ns1"}})
+	assert.NoError(t, err)
+
+	// only the namespace not reconciled yet is outdated, and it is counted once
+	assert.Equal(t, []string{"ns2"}, r.getNamespacesToReconcile(context.TODO()))
+
+	r.syncReconciliationStatus(context.TODO())
+
+	assert.NoError(t, cl.Get(context.TODO(), client.ObjectKeyFromObject(checluster), checluster))
+	assert.NotNil(t, checluster.Status.UserNamespacesReconciliation)
+	assert.Equal(t, int32(2), checluster.Status.UserNamespacesReconciliation.Total)
+	assert.Equal(t, int32(1), checluster.Status.UserNamespacesReconciliation.Reconciled)
+	assert.Nil(t, checluster.Status.UserNamespacesReconciliation.CompletionTime)
+
+	_, err = r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "ns2"}})
+	assert.NoError(t, err)
+
+	r.syncReconciliationStatus(context.TODO())
+
+	assert.NoError(t, cl.Get(context.TODO(), client.ObjectKeyFromObject(checluster), checluster))
+	assert.Equal(t, int32(2), checluster.Status.UserNamespacesReconciliation.Reconciled)
+	assert.NotNil(t, checluster.Status.UserNamespacesReconciliation.CompletionTime)
+}
+
+func TestProxyChangesOutdateNamespaces(t *testing.T) {
+	ns1, _ := getUserNamespace("ns1", "user_1", nil)
+	checluster := &chev2.CheCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "eclipse-che",
+			Namespace: "eclipse-che",
+		},
+		Spec: chev2.CheClusterSpec{
+			Components: chev2.CheClusterComponents{
+				CheServer: chev2.CheServer{
+					Proxy: &chev2.Proxy{
+						Url:                   "http://proxy.example.com",
+						Port:                  "3128",
+						CredentialsSecretName: "proxy-credentials",
+					},
+				},
+			},
+		},
+	}
+	credentials := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "proxy-credentials",
+			Namespace: "eclipse-che",
+		},
+		Data: map[string][]byte{
+			"user":     []byte("user"),
+			"password": []byte("password"),
+		},
+	}
+
+	_, cl, r := setup(infrastructure.Kubernetes, ns1, checluster, credentials)
+
+	_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "ns1"}})
+	assert.NoError(t, err)
+	assert.Empty(t, r.getNamespacesToReconcile(context.TODO()))
+
+	// the proxy credentials are changed
+	credentials.Data["password"] = []byte("new-password")
+	assert.NoError(t, cl.Update(context.TODO(), credentials))
+
+	assert.Equal(t, []string{"ns1"}, r.getNamespacesToReconcile(context.TODO()))
+}
+
+func TestInputsHashComputedOncePerCheClusterChange(t *testing.T) {
+	checluster := &chev2.CheCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "eclipse-che",
+			Namespace:  "eclipse-che",
+			Generation: 1,
+		},
+	}
+	caBundle := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      tls.CheMergedCABundleCertsCMName,
+			Namespace: "eclipse-che",
+		},
+	}
+
+	_, cl, r := setup(infrastructure.Kubernetes, checluster, caBundle)
+
+	assert.NoError(t, cl.Get(context.TODO(), client.ObjectKeyFromObject(checluster), checluster))
+	inputsHash, err := r.getInputsHash(context.TODO(), checluster)
+	assert.NoError(t, err)
+
+	// the synced objects are not read again until a watch recomputes the hash
+	caBundle.Data = map[string]string{"ca.crt": "certificate"}
+	assert.NoError(t, cl.Update(context.TODO(), caBundle))
+
+	cachedInputsHash, err := r.getInputsHash(context.TODO(), checluster)
+	assert.NoError(t, err)
+	assert.Equal(t, inputsHash, cachedInputsHash)
+
+	// the CheCluster spec is changed
+	checluster.Generation++
+	changedInputsHash, err := r.getInputsHash(context.TODO(), checluster)
+	assert.NoError(t, err)
+	assert.NotEqual(t, inputsHash, changedInputsHash)
+}
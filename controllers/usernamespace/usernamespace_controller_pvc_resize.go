@@ -0,0 +1,201 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package usernamespace
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	dwconstants "github.com/devfile/devworkspace-operator/pkg/constants"
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	defaultStorageClassAnnotation = "storageclass.kubernetes.io/is-default-class"
+	// pvcResizeReportedAnnotation keeps the last resize event reported for the PVC,
+	// so that the same event is not emitted on every reconciliation.
+	pvcResizeReportedAnnotation = "che.eclipse.org/pvc-resize-reported"
+	pvcResizeCheckPeriod        = time.Minute
+)
+
+// reconcileWorkspacePVCSize expands the workspace PVCs that are smaller than the configured claim size,
+// if their storage class allows volume expansion. PVCs are never shrunk.
+// Returns true if any PVC is still being resized by the storage provisioner.
+func (r *CheUserNamespaceReconciler) reconcileWorkspacePVCSize(ctx context.Context, ns *corev1.Namespace, checluster *chev2.CheCluster) (bool, error) {
+	perUserClaimSize := getClaimSize(checluster.Spec.DevEnvironments.Storage.PerUserStrategyPvcConfig)
+	perWorkspaceClaimSize := getClaimSize(checluster.Spec.DevEnvironments.Storage.PerWorkspaceStrategyPvcConfig)
+	if perUserClaimSize == nil && perWorkspaceClaimSize == nil {
+		return false, nil
+	}
+
+	pvcs := &corev1.PersistentVolumeClaimList{}
+	if err := r.nonCachedClient.List(ctx, pvcs, client.InNamespace(ns.Name)); err != nil {
+		return false, err
+	}
+
+	resizeInProgress := false
+
+	for i := range pvcs.Items {
+		pvc := &pvcs.Items[i]
+
+		claimSize := perWorkspaceClaimSize
+		if pvc.Name == dwconstants.DefaultWorkspacePVCName {
+			claimSize = perUserClaimSize
+		} else if pvc.GetLabels()[dwconstants.DevWorkspaceIDLabel] == "" {
+			continue
+		}
+
+		if claimSize == nil || pvc.DeletionTimestamp != nil {
+			continue
+		}
+
+		requested := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+		if requested.Cmp(*claimSize) >= 0 {
+			resizing, err := r.reportPVCResizeProgress(ctx, ns, pvc)
+			if err != nil {
+				return false, err
+			}
+
+			resizeInProgress = resizeInProgress || resizing
+			continue
+		}
+
+		allowed, storageClassName, err := r.isVolumeExpansionAllowed(ctx, pvc)
+		if err != nil {
+			return false, err
+		}
+
+		if !allowed {
+			logrus.Debugf("PVC %s/%s is not resized, storage class '%s' does not allow volume expansion", ns.Name, pvc.Name, storageClassName)
+			continue
+		}
+
+		message := fmt.Sprintf("PVC %s is being resized from %s to %s", pvc.Name, requested.String(), claimSize.String())
+
+		patch := client.MergeFrom(pvc.DeepCopy())
+		if pvc.Spec.Resources.Requests == nil {
+			pvc.Spec.Resources.Requests = corev1.ResourceList{}
+		}
+		pvc.Spec.Resources.Requests[corev1.ResourceStorage] = *claimSize
+		setPVCResizeReported(pvc, "PVCResizing", message)
+		if err := r.nonCachedClient.Patch(ctx, pvc, patch); err != nil {
+			return false, err
+		}
+
+		r.recorder.Event(ns, corev1.EventTypeNormal, "PVCResizing", message)
+		resizeInProgress = true
+	}
+
+	return resizeInProgress, nil
+}
+
+// reportPVCResizeProgress reports the resize steps that require the user action or failed, once per step.
+// Returns true if the volume is still being resized by the storage provisioner.
+func (r *CheUserNamespaceReconciler) reportPVCResizeProgress(ctx context.Context, ns *corev1.Namespace, pvc *corev1.PersistentVolumeClaim) (bool, error) {
+	resizing := false
+	eventType, reason, message := "", "", ""
+
+	for _, condition := range pvc.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+
+		switch condition.Type {
+		case corev1.PersistentVolumeClaimResizing:
+			resizing = true
+		case corev1.PersistentVolumeClaimFileSystemResizePending:
+			eventType, reason = corev1.EventTypeNormal, "PVCResizePending"
+			message = fmt.Sprintf("PVC %s is resized, restart the workspaces to complete the file system resize", pvc.Name)
+		case corev1.PersistentVolumeClaimControllerResizeError, corev1.PersistentVolumeClaimNodeResizeError:
+			eventType, reason = corev1.EventTypeWarning, "PVCResizeFailed"
+			message = fmt.Sprintf("PVC %s resize failed: %s", pvc.Name, condition.Message)
+		}
+	}
+
+	if reason == "" || isPVCResizeReported(pvc, reason, message) {
+		return resizing, nil
+	}
+
+	patch := client.MergeFrom(pvc.DeepCopy())
+	setPVCResizeReported(pvc, reason, message)
+	if err := r.nonCachedClient.Patch(ctx, pvc, patch); err != nil {
+		if errors.IsNotFound(err) {
+			return resizing, nil
+		}
+		return false, err
+	}
+
+	r.recorder.Event(ns, eventType, reason, message)
+	return resizing, nil
+}
+
+func isPVCResizeReported(pvc *corev1.PersistentVolumeClaim, reason string, message string) bool {
+	return pvc.GetAnnotations()[pvcResizeReportedAnnotation] == reason+": "+message
+}
+
+func setPVCResizeReported(pvc *corev1.PersistentVolumeClaim, reason string, message string) {
+	if pvc.Annotations == nil {
+		pvc.Annotations = map[string]string{}
+	}
+	pvc.Annotations[pvcResizeReportedAnnotation] = reason + ": " + message
+}
+
+// isVolumeExpansionAllowed checks if the storage class of the PVC allows volume expansion.
+// Returns the name of the storage class as well.
+func (r *CheUserNamespaceReconciler) isVolumeExpansionAllowed(ctx context.Context, pvc *corev1.PersistentVolumeClaim) (bool, string, error) {
+	storageClassName := ptr.Deref(pvc.Spec.StorageClassName, "")
+
+	if storageClassName == "" {
+		storageClasses := &storagev1.StorageClassList{}
+		if err := r.nonCachedClient.List(ctx, storageClasses); err != nil {
+			return false, "", err
+		}
+
+		for _, storageClass := range storageClasses.Items {
+			if storageClass.GetAnnotations()[defaultStorageClassAnnotation] == "true" {
+				return ptr.Deref(storageClass.AllowVolumeExpansion, false), storageClass.Name, nil
+			}
+		}
+
+		return false, storageClassName, nil
+	}
+
+	storageClass := &storagev1.StorageClass{}
+	exists, err := r.nonCachedClientWrapper.GetIgnoreNotFound(ctx, client.ObjectKey{Name: storageClassName}, storageClass)
+	if err != nil || !exists {
+		return false, storageClassName, err
+	}
+
+	return ptr.Deref(storageClass.AllowVolumeExpansion, false), storageClassName, nil
+}
+
+func getClaimSize(pvcConfig *chev2.PVC) *resource.Quantity {
+	if pvcConfig == nil || pvcConfig.ClaimSize == "" {
+		return nil
+	}
+
+	claimSize, err := resource.ParseQuantity(pvcConfig.ClaimSize)
+	if err != nil {
+		return nil
+	}
+
+	return &claimSize
+}
@@ -0,0 +1,176 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package usernamespace
+
+import (
+	"context"
+	"testing"
+
+	dwconstants "github.com/devfile/devworkspace-operator/pkg/constants"
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/eclipse-che/che-operator/pkg/common/infrastructure"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func getWorkspacePVC(name string, size string, storageClass string, labels map[string]string) *corev1.PersistentVolumeClaim {
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns1", Labels: labels},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)},
+			},
+		},
+	}
+	if storageClass != "" {
+		pvc.Spec.StorageClassName = ptr.To(storageClass)
+	}
+	return pvc
+}
+
+func getStorageClass(name string, allowVolumeExpansion bool, isDefault bool) *storagev1.StorageClass {
+	storageClass := &storagev1.StorageClass{
+		ObjectMeta:           metav1.ObjectMeta{Name: name},
+		AllowVolumeExpansion: ptr.To(allowVolumeExpansion),
+	}
+	if isDefault {
+		storageClass.Annotations = map[string]string{defaultStorageClassAnnotation: "true"}
+	}
+	return storageClass
+}
+
+func getRecordedEvents(r *CheUserNamespaceReconciler) []string {
+	var events []string
+	recorder := r.recorder.(*record.FakeRecorder)
+	for {
+		select {
+		case event := <-recorder.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+func TestResizePerUserPVC(t *testing.T) {
+	ns, project := getUserNamespace("ns1", "user_1", nil)
+	pvc := getWorkspacePVC(dwconstants.DefaultWorkspacePVCName, "2Gi", "gp3", nil)
+
+	_, cl, r := setup(infrastructure.OpenShiftV4, ns, project, pvc, getStorageClass("gp3", true, false), getCheClusterWithPerUserPVC())
+
+	result, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "ns1"}})
+	assert.NoError(t, err)
+	assert.Equal(t, pvcResizeCheckPeriod, result.RequeueAfter)
+
+	resizedPVC := &corev1.PersistentVolumeClaim{}
+	assert.NoError(t, cl.Get(context.TODO(), client.ObjectKey{Name: dwconstants.DefaultWorkspacePVCName, Namespace: "ns1"}, resizedPVC))
+	assert.Equal(t, "5Gi", ptr.To(resizedPVC.Spec.Resources.Requests[corev1.ResourceStorage]).String())
+
+	events := getRecordedEvents(r)
+	assert.Len(t, events, 1)
+	assert.Contains(t, events[0], "PVCResizing")
+
+	// Resize is completed by the storage provisioner, file system resize is pending
+	resizedPVC.Status.Conditions = []corev1.PersistentVolumeClaimCondition{
+		{Type: corev1.PersistentVolumeClaimFileSystemResizePending, Status: corev1.ConditionTrue},
+	}
+	assert.NoError(t, cl.Status().Update(context.TODO(), resizedPVC))
+
+	result, err = r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "ns1"}})
+	assert.NoError(t, err)
+	assert.Zero(t, result.RequeueAfter)
+
+	events = getRecordedEvents(r)
+	assert.Len(t, events, 1)
+	assert.Contains(t, events[0], "PVCResizePending")
+
+	// The same step is reported once
+	_, err = r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "ns1"}})
+	assert.NoError(t, err)
+	assert.Empty(t, getRecordedEvents(r))
+
+	assert.NoError(t, cl.Get(context.TODO(), client.ObjectKey{Name: dwconstants.DefaultWorkspacePVCName, Namespace: "ns1"}, resizedPVC))
+	resizedPVC.Status.Conditions = []corev1.PersistentVolumeClaimCondition{
+		{Type: corev1.PersistentVolumeClaimNodeResizeError, Status: corev1.ConditionTrue, Message: "resize2fs failed"},
+	}
+	assert.NoError(t, cl.Status().Update(context.TODO(), resizedPVC))
+
+	for range 2 {
+		_, err = r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "ns1"}})
+		assert.NoError(t, err)
+	}
+
+	events = getRecordedEvents(r)
+	assert.Len(t, events, 1)
+	assert.Contains(t, events[0], "PVCResizeFailed")
+}
+
+func TestResizePerWorkspacePVCWithDefaultStorageClass(t *testing.T) {
+	ns, project := getUserNamespace("ns1", "user_1", nil)
+	workspacePVC := getWorkspacePVC("storage-ws1", "2Gi", "", map[string]string{dwconstants.DevWorkspaceIDLabel: "ws1"})
+	otherPVC := getWorkspacePVC("other", "1Gi", "", nil)
+
+	checluster := getCheClusterWithPerUserPVC()
+	checluster.Spec.DevEnvironments.Storage.PerWorkspaceStrategyPvcConfig = &chev2.PVC{ClaimSize: "4Gi"}
+
+	_, cl, r := setup(infrastructure.OpenShiftV4, ns, project, workspacePVC, otherPVC, getStorageClass("standard", true, true), checluster)
+
+	_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "ns1"}})
+	assert.NoError(t, err)
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	assert.NoError(t, cl.Get(context.TODO(), client.ObjectKey{Name: "storage-ws1", Namespace: "ns1"}, pvc))
+	assert.Equal(t, "4Gi", ptr.To(pvc.Spec.Resources.Requests[corev1.ResourceStorage]).String())
+
+	// Not a workspace PVC
+	assert.NoError(t, cl.Get(context.TODO(), client.ObjectKey{Name: "other", Namespace: "ns1"}, pvc))
+	assert.Equal(t, "1Gi", ptr.To(pvc.Spec.Resources.Requests[corev1.ResourceStorage]).String())
+}
+
+func TestNotResizePVCIfVolumeExpansionNotAllowed(t *testing.T) {
+	ns, project := getUserNamespace("ns1", "user_1", nil)
+	pvc := getWorkspacePVC(dwconstants.DefaultWorkspacePVCName, "2Gi", "gp3", nil)
+
+	_, cl, r := setup(infrastructure.OpenShiftV4, ns, project, pvc, getStorageClass("gp3", false, false), getCheClusterWithPerUserPVC())
+
+	result, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "ns1"}})
+	assert.NoError(t, err)
+	assert.Zero(t, result.RequeueAfter)
+
+	assert.NoError(t, cl.Get(context.TODO(), client.ObjectKey{Name: dwconstants.DefaultWorkspacePVCName, Namespace: "ns1"}, pvc))
+	assert.Equal(t, "2Gi", ptr.To(pvc.Spec.Resources.Requests[corev1.ResourceStorage]).String())
+
+	assert.Empty(t, getRecordedEvents(r))
+}
+
+func TestNotShrinkPVC(t *testing.T) {
+	ns, project := getUserNamespace("ns1", "user_1", nil)
+	pvc := getWorkspacePVC(dwconstants.DefaultWorkspacePVCName, "10Gi", "gp3", nil)
+
+	_, cl, r := setup(infrastructure.OpenShiftV4, ns, project, pvc, getStorageClass("gp3", true, false), getCheClusterWithPerUserPVC())
+
+	_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "ns1"}})
+	assert.NoError(t, err)
+
+	assert.NoError(t, cl.Get(context.TODO(), client.ObjectKey{Name: dwconstants.DefaultWorkspacePVCName, Namespace: "ns1"}, pvc))
+	assert.Equal(t, "10Gi", ptr.To(pvc.Spec.Resources.Requests[corev1.ResourceStorage]).String())
+	assert.Empty(t, getRecordedEvents(r))
+}
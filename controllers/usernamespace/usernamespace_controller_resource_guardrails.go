@@ -0,0 +1,98 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package usernamespace
+
+import (
+	"context"
+
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/eclipse-che/che-operator/pkg/common/diffs"
+	k8sclient "github.com/eclipse-che/che-operator/pkg/common/k8s-client"
+	defaults "github.com/eclipse-che/che-operator/pkg/common/operator-defaults"
+	"github.com/eclipse-che/che-operator/pkg/deploy"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	resourceQuotaName = prefixedName("resource-quota")
+	limitRangeName    = prefixedName("limit-range")
+)
+
+// reconcileResourceGuardrails syncs the ResourceQuota and the LimitRange of the user namespace
+// and deletes them when they are not configured anymore.
+func (r *CheUserNamespaceReconciler) reconcileResourceGuardrails(
+	ctx context.Context,
+	targetNs string,
+	checluster *chev2.CheCluster,
+	profile *chev2.DevEnvironmentProfile,
+) error {
+	if resourceQuotaSpec := getResourceQuotaSpec(checluster, profile); resourceQuotaSpec != nil {
+		resourceQuota := &corev1.ResourceQuota{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "ResourceQuota",
+				APIVersion: corev1.SchemeGroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      resourceQuotaName,
+				Namespace: targetNs,
+				Labels:    deploy.GetLabels(defaults.GetCheFlavor()),
+			},
+			Spec: *resourceQuotaSpec.DeepCopy(),
+		}
+
+		if err := r.clientWrapper.Sync(ctx, resourceQuota, &k8sclient.SyncOptions{DiffOpts: diffs.ResourceQuota}); err != nil {
+			return err
+		}
+	} else {
+		if err := r.clientWrapper.DeleteByKeyIgnoreNotFound(ctx, client.ObjectKey{Name: resourceQuotaName, Namespace: targetNs}, &corev1.ResourceQuota{}); err != nil {
+			return err
+		}
+	}
+
+	if limitRangeSpec := getLimitRangeSpec(checluster, profile); limitRangeSpec != nil {
+		limitRange := &corev1.LimitRange{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "LimitRange",
+				APIVersion: corev1.SchemeGroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      limitRangeName,
+				Namespace: targetNs,
+				Labels:    deploy.GetLabels(defaults.GetCheFlavor()),
+			},
+			Spec: *limitRangeSpec.DeepCopy(),
+		}
+
+		return r.clientWrapper.Sync(ctx, limitRange, &k8sclient.SyncOptions{DiffOpts: diffs.LimitRange})
+	}
+
+	return r.clientWrapper.DeleteByKeyIgnoreNotFound(ctx, client.ObjectKey{Name: limitRangeName, Namespace: targetNs}, &corev1.LimitRange{})
+}
+
+func getResourceQuotaSpec(checluster *chev2.CheCluster, profile *chev2.DevEnvironmentProfile) *corev1.ResourceQuotaSpec {
+	if profile != nil && profile.ResourceQuota != nil {
+		return profile.ResourceQuota
+	}
+
+	return checluster.Spec.DevEnvironments.ResourceQuota
+}
+
+func getLimitRangeSpec(checluster *chev2.CheCluster, profile *chev2.DevEnvironmentProfile) *corev1.LimitRangeSpec {
+	if profile != nil && profile.LimitRange != nil {
+		return profile.LimitRange
+	}
+
+	return checluster.Spec.DevEnvironments.LimitRange
+}
@@ -0,0 +1,137 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package usernamespace
+
+import (
+	"context"
+	"testing"
+
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/eclipse-che/che-operator/pkg/common/infrastructure"
+	userv1 "github.com/openshift/api/user/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func getCheClusterWithResourceGuardrails() *chev2.CheCluster {
+	return &chev2.CheCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "eclipse-che",
+			Namespace: "eclipse-che",
+		},
+		Spec: chev2.CheClusterSpec{
+			DevEnvironments: chev2.CheClusterDevEnvironments{
+				ResourceQuota: &corev1.ResourceQuotaSpec{
+					Hard: corev1.ResourceList{
+						corev1.ResourceLimitsCPU:              resource.MustParse("4"),
+						corev1.ResourceLimitsMemory:           resource.MustParse("8Gi"),
+						corev1.ResourcePersistentVolumeClaims: resource.MustParse("5"),
+						corev1.ResourceRequestsStorage:        resource.MustParse("20Gi"),
+					},
+				},
+				LimitRange: &corev1.LimitRangeSpec{
+					Limits: []corev1.LimitRangeItem{
+						{
+							Type:    corev1.LimitTypeContainer,
+							Default: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+							Max:     corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestResourceGuardrails(t *testing.T) {
+	ns, _ := getUserNamespace("ns1", "user_1", nil)
+	checluster := getCheClusterWithResourceGuardrails()
+
+	_, cl, r := setup(infrastructure.Kubernetes, ns, checluster)
+
+	_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "ns1"}})
+	assert.NoError(t, err)
+
+	resourceQuota := &corev1.ResourceQuota{}
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: "che-resource-quota", Namespace: "ns1"}, resourceQuota))
+	assert.Equal(t, resource.MustParse("8Gi"), resourceQuota.Spec.Hard[corev1.ResourceLimitsMemory])
+
+	limitRange := &corev1.LimitRange{}
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: "che-limit-range", Namespace: "ns1"}, limitRange))
+	assert.Equal(t, checluster.Spec.DevEnvironments.LimitRange.Limits, limitRange.Spec.Limits)
+
+	// manual changes are reverted
+	resourceQuota.Spec.Hard[corev1.ResourceLimitsMemory] = resource.MustParse("64Gi")
+	assert.NoError(t, cl.Update(context.TODO(), resourceQuota))
+
+	_, err = r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "ns1"}})
+	assert.NoError(t, err)
+
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: "che-resource-quota", Namespace: "ns1"}, resourceQuota))
+	assert.Equal(t, resource.MustParse("8Gi"), resourceQuota.Spec.Hard[corev1.ResourceLimitsMemory])
+
+	// guardrails are removed from the CheCluster
+	assert.NoError(t, cl.Get(context.TODO(), client.ObjectKeyFromObject(checluster), checluster))
+	checluster.Spec.DevEnvironments.ResourceQuota = nil
+	checluster.Spec.DevEnvironments.LimitRange = nil
+	assert.NoError(t, cl.Update(context.TODO(), checluster))
+
+	_, err = r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "ns1"}})
+	assert.NoError(t, err)
+
+	err = cl.Get(context.TODO(), types.NamespacedName{Name: "che-resource-quota", Namespace: "ns1"}, resourceQuota)
+	assert.True(t, errors.IsNotFound(err))
+	err = cl.Get(context.TODO(), types.NamespacedName{Name: "che-limit-range", Namespace: "ns1"}, limitRange)
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestResourceGuardrailsOverriddenByProfile(t *testing.T) {
+	ns, project := getUserNamespace("ns1", "user_1", nil)
+	group := &userv1.Group{
+		ObjectMeta: metav1.ObjectMeta{Name: "data-scientists"},
+		Users:      []string{"user_1"},
+	}
+
+	checluster := getCheClusterWithResourceGuardrails()
+	checluster.Spec.DevEnvironments.Profiles = []chev2.DevEnvironmentProfile{
+		{
+			Name:   "data-science",
+			Groups: []string{"data-scientists"},
+			ResourceQuota: &corev1.ResourceQuotaSpec{
+				Hard: corev1.ResourceList{
+					corev1.ResourceLimitsMemory: resource.MustParse("32Gi"),
+				},
+			},
+		},
+	}
+
+	_, cl, r := setup(infrastructure.OpenShiftV4, ns, project, group, checluster)
+
+	_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "ns1"}})
+	assert.NoError(t, err)
+
+	resourceQuota := &corev1.ResourceQuota{}
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: "che-resource-quota", Namespace: "ns1"}, resourceQuota))
+	assert.Equal(t, corev1.ResourceList{corev1.ResourceLimitsMemory: resource.MustParse("32Gi")}, resourceQuota.Spec.Hard)
+
+	// LimitRange is not overridden by the profile
+	limitRange := &corev1.LimitRange{}
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: "che-limit-range", Namespace: "ns1"}, limitRange))
+	assert.Equal(t, checluster.Spec.DevEnvironments.LimitRange.Limits, limitRange.Spec.Limits)
+}
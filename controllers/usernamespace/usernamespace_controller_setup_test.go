@@ -14,7 +14,6 @@ package usernamespace
 
 import (
 	"context"
-	"sync"
 	"testing"
 
 	"github.com/eclipse-che/che-operator/controllers/namespacecache"
@@ -31,6 +30,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -165,11 +165,10 @@ func setup(infraType infrastructure.Type, objs ...client.Object) (*runtime.Schem
 		clientWrapper:          k8sclient.NewK8sClient(cl, scheme),
 		nonCachedClientWrapper: k8sclient.NewK8sClient(cl, scheme),
 		scheme:                 scheme,
-		namespaceCache: &namespacecache.NamespaceCache{
-			Client:          cl,
-			KnownNamespaces: map[string]namespacecache.NamespaceInfo{},
-			Lock:            sync.Mutex{},
-		},
+		namespaceCache:         namespacecache.NewNamespaceCache(cl),
+		recorder:               record.NewFakeRecorder(100),
+		fanOut:                 namespacecache.NewFanOut(cl),
+		tracker:                newNamespacesTracker(),
 	}
 
 	r.setDWONamespace("devworkspace-controller")
@@ -0,0 +1,211 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package usernamespace
+
+import (
+	"context"
+	"time"
+
+	dw "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	dwconstants "github.com/devfile/devworkspace-operator/pkg/constants"
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/eclipse-che/che-operator/pkg/deploy/volumesnapshot"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// restoreFromSnapshotAnnotation requests to re-provision the workspace PVC the VolumeSnapshot with the given name
+	// was taken from: the `claim-devworkspace` PVC of the `per-user` strategy or a PVC of the `per-workspace` strategy.
+	restoreFromSnapshotAnnotation = "che.eclipse.org/restore-from-snapshot"
+	// restorePendingSinceAnnotation records the time the restore started waiting for the workspaces to be stopped
+	restorePendingSinceAnnotation = "che.eclipse.org/restore-pending-since"
+	volumeRestoreCheckPeriod      = 10 * time.Second
+	// The running workspaces are checked less and less often, up to this period
+	maxVolumeRestorePendingCheckPeriod = 5 * time.Minute
+)
+
+// reconcileVolumeRestore re-provisions the workspace PVC from the VolumeSnapshot requested
+// with the namespace annotation. Returns the period after which the namespace must be reconciled again
+// if the restore is in progress, or zero otherwise.
+// The restore state is recorded in the annotations of the given namespace, which is patched by the caller.
+func (r *CheUserNamespaceReconciler) reconcileVolumeRestore(ctx context.Context, ns *corev1.Namespace, checluster *chev2.CheCluster) (time.Duration, error) {
+	targetNs := ns.Name
+	snapshotName := ns.GetAnnotations()[restoreFromSnapshotAnnotation]
+	if snapshotName == "" {
+		return 0, nil
+	}
+
+	snapshot := volumesnapshot.NewEmpty()
+	if err := r.nonCachedClient.Get(ctx, client.ObjectKey{Name: snapshotName, Namespace: targetNs}, snapshot); err != nil {
+		if !errors.IsNotFound(err) {
+			return 0, err
+		}
+
+		r.recorder.Eventf(ns, corev1.EventTypeWarning, "RestoreFailed", "VolumeSnapshot %s not found", snapshotName)
+		removeRestoreRequest(ns)
+		return 0, nil
+	}
+
+	if !volumesnapshot.IsReadyToUse(snapshot) {
+		return volumeRestoreCheckPeriod, nil
+	}
+
+	pvcName := volumesnapshot.GetSourcePVCName(snapshot)
+	if pvcName == "" {
+		pvcName = dwconstants.DefaultWorkspacePVCName
+	}
+	// The PVC of the `per-workspace` strategy is used by a single workspace
+	workspaceID := snapshot.GetLabels()[dwconstants.DevWorkspaceIDLabel]
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	err := r.nonCachedClient.Get(ctx, client.ObjectKey{Name: pvcName, Namespace: targetNs}, pvc)
+	if err != nil && !errors.IsNotFound(err) {
+		return 0, err
+	}
+	pvcExists := err == nil
+
+	// The workspaces are checked right before the PVC is deleted or re-provisioned
+	workspace, running, err := r.getWorkspaceUsingPVC(ctx, targetNs, workspaceID)
+	if err != nil {
+		return 0, err
+	} else if running {
+		return r.waitForWorkspacesStopped(ns, snapshotName), nil
+	}
+
+	if pvcExists {
+		if pvc.DeletionTimestamp == nil {
+			if err := r.nonCachedClient.Delete(ctx, pvc); err != nil && !errors.IsNotFound(err) {
+				return 0, err
+			}
+		}
+
+		// Wait until the PVC is deleted
+		return volumeRestoreCheckPeriod, nil
+	}
+
+	restoredPVC := getRestoredPVC(pvcName, targetNs, snapshot, checluster)
+	if workspace != nil {
+		// Keep the PVC of the `per-workspace` strategy owned by its workspace, as the DevWorkspace Operator does
+		restoredPVC.Labels = map[string]string{dwconstants.DevWorkspaceIDLabel: workspaceID}
+		if err := controllerutil.SetControllerReference(workspace, restoredPVC, r.scheme); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := r.nonCachedClient.Create(ctx, restoredPVC); err != nil {
+		if errors.IsAlreadyExists(err) {
+			// The PVC has been re-created by a workspace started meanwhile, it is deleted again once the workspace is stopped
+			return volumeRestoreCheckPeriod, nil
+		}
+		return 0, err
+	}
+
+	r.recorder.Eventf(ns, corev1.EventTypeNormal, "Restored", "Volume %s restored from VolumeSnapshot %s", pvcName, snapshotName)
+	removeRestoreRequest(ns)
+	return 0, nil
+}
+
+// getWorkspaceUsingPVC returns the workspace with the given id, and true if the workspace is running.
+// If the id is empty, the PVC is shared by all workspaces of the namespace, and true is returned if any of them is running.
+func (r *CheUserNamespaceReconciler) getWorkspaceUsingPVC(ctx context.Context, targetNs string, workspaceID string) (*dw.DevWorkspace, bool, error) {
+	workspaces := &dw.DevWorkspaceList{}
+	if err := r.nonCachedClient.List(ctx, workspaces, client.InNamespace(targetNs)); err != nil {
+		return nil, false, err
+	}
+
+	for i := range workspaces.Items {
+		workspace := &workspaces.Items[i]
+		if workspaceID == "" {
+			if workspace.Spec.Started {
+				return nil, true, nil
+			}
+		} else if workspace.Status.DevWorkspaceId == workspaceID {
+			return workspace, workspace.Spec.Started, nil
+		}
+	}
+
+	return nil, false, nil
+}
+
+// waitForWorkspacesStopped records that the restore is pending and warns the user once.
+// The running workspaces are checked again after the time the restore has been pending for,
+// bounded by volumeRestoreCheckPeriod and maxVolumeRestorePendingCheckPeriod.
+func (r *CheUserNamespaceReconciler) waitForWorkspacesStopped(ns *corev1.Namespace, snapshotName string) time.Duration {
+	pendingSince, err := time.Parse(time.RFC3339, ns.GetAnnotations()[restorePendingSinceAnnotation])
+	if err != nil {
+		ns.Annotations[restorePendingSinceAnnotation] = time.Now().UTC().Format(time.RFC3339)
+		r.recorder.Eventf(ns, corev1.EventTypeWarning, "RestorePending", "Stop all workspaces to restore VolumeSnapshot %s", snapshotName)
+		return volumeRestoreCheckPeriod
+	}
+
+	return min(max(time.Since(pendingSince), volumeRestoreCheckPeriod), maxVolumeRestorePendingCheckPeriod)
+}
+
+func removeRestoreRequest(ns *corev1.Namespace) {
+	delete(ns.Annotations, restoreFromSnapshotAnnotation)
+	delete(ns.Annotations, restorePendingSinceAnnotation)
+}
+
+func getRestoredPVC(name string, targetNs string, snapshot *unstructured.Unstructured, checluster *chev2.CheCluster) *corev1.PersistentVolumeClaim {
+	pvcConfig := checluster.Spec.DevEnvironments.Storage.PerUserStrategyPvcConfig
+	if name != dwconstants.DefaultWorkspacePVCName {
+		pvcConfig = checluster.Spec.DevEnvironments.Storage.PerWorkspaceStrategyPvcConfig
+	}
+	if pvcConfig == nil {
+		pvcConfig = &chev2.PVC{}
+	}
+
+	size := resource.MustParse(dwconstants.PVCStorageSize)
+	if claimSize, err := resource.ParseQuantity(pvcConfig.ClaimSize); err == nil {
+		size = claimSize
+	}
+	if restoreSize, found := volumesnapshot.GetRestoreSize(snapshot); found && restoreSize.Cmp(size) > 0 {
+		size = restoreSize
+	}
+
+	accessModes := pvcConfig.StorageAccessMode
+	if len(accessModes) == 0 {
+		accessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: targetNs,
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: accessModes,
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: size},
+			},
+			DataSource: &corev1.TypedLocalObjectReference{
+				APIGroup: ptr.To(volumesnapshot.GroupVersionKind.Group),
+				Kind:     volumesnapshot.GroupVersionKind.Kind,
+				Name:     snapshot.GetName(),
+			},
+		},
+	}
+
+	if pvcConfig.StorageClass != "" {
+		pvc.Spec.StorageClassName = ptr.To(pvcConfig.StorageClass)
+	}
+
+	return pvc
+}
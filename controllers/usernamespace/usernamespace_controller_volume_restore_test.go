@@ -0,0 +1,191 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package usernamespace
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dw "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	dwconstants "github.com/devfile/devworkspace-operator/pkg/constants"
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/eclipse-che/che-operator/pkg/common/infrastructure"
+	"github.com/eclipse-che/che-operator/pkg/deploy/volumesnapshot"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func getReadySnapshot(name string, namespace string, restoreSize string) *unstructured.Unstructured {
+	snapshot := volumesnapshot.New(name, namespace, dwconstants.DefaultWorkspacePVCName, "csi-snapclass", nil)
+	snapshot.Object["status"] = map[string]interface{}{
+		"readyToUse":  true,
+		"restoreSize": restoreSize,
+	}
+	return snapshot
+}
+
+func getCheClusterWithPerUserPVC() *chev2.CheCluster {
+	return &chev2.CheCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "eclipse-che",
+			Namespace: "eclipse-che",
+		},
+		Spec: chev2.CheClusterSpec{
+			DevEnvironments: chev2.CheClusterDevEnvironments{
+				Storage: chev2.WorkspaceStorage{
+					PerUserStrategyPvcConfig: &chev2.PVC{
+						ClaimSize:    "5Gi",
+						StorageClass: "gp3",
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestRestoreVolumeFromSnapshot(t *testing.T) {
+	ns, project := getUserNamespace("ns1", "user_1", nil)
+	ns.Annotations[restoreFromSnapshotAnnotation] = "backup-1"
+	project.Annotations[restoreFromSnapshotAnnotation] = "backup-1"
+
+	workspace := &dw.DevWorkspace{
+		ObjectMeta: metav1.ObjectMeta{Name: "ws", Namespace: "ns1"},
+		Spec:       dw.DevWorkspaceSpec{Started: true},
+	}
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: dwconstants.DefaultWorkspacePVCName, Namespace: "ns1"},
+	}
+
+	_, cl, r := setup(infrastructure.OpenShiftV4, ns, project, workspace, pvc, getReadySnapshot("backup-1", "ns1", "8Gi"), getCheClusterWithPerUserPVC())
+
+	// Restore waits for the workspaces to be stopped
+	result, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "ns1"}})
+	assert.NoError(t, err)
+	assert.Equal(t, volumeRestoreCheckPeriod, result.RequeueAfter)
+	assert.NoError(t, cl.Get(context.TODO(), client.ObjectKeyFromObject(pvc), &corev1.PersistentVolumeClaim{}))
+
+	updatedNs := &corev1.Namespace{}
+	assert.NoError(t, cl.Get(context.TODO(), client.ObjectKey{Name: "ns1"}, updatedNs))
+	assert.NotEmpty(t, updatedNs.Annotations[restorePendingSinceAnnotation])
+
+	// The user is warned once and the workspaces are checked less often
+	updatedNs.Annotations[restorePendingSinceAnnotation] = time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	assert.NoError(t, cl.Update(context.TODO(), updatedNs))
+
+	result, err = r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "ns1"}})
+	assert.NoError(t, err)
+	assert.Equal(t, maxVolumeRestorePendingCheckPeriod, result.RequeueAfter)
+
+	recorder := r.recorder.(*record.FakeRecorder)
+	assert.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, "RestorePending")
+
+	workspace.Spec.Started = false
+	assert.NoError(t, cl.Update(context.TODO(), workspace))
+
+	// The existing PVC is deleted
+	result, err = r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "ns1"}})
+	assert.NoError(t, err)
+	assert.Equal(t, volumeRestoreCheckPeriod, result.RequeueAfter)
+
+	// The PVC is re-provisioned from the snapshot
+	result, err = r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "ns1"}})
+	assert.NoError(t, err)
+	assert.Zero(t, result.RequeueAfter)
+
+	restoredPVC := &corev1.PersistentVolumeClaim{}
+	assert.NoError(t, cl.Get(context.TODO(), client.ObjectKeyFromObject(pvc), restoredPVC))
+	assert.Equal(t, "backup-1", restoredPVC.Spec.DataSource.Name)
+	assert.Equal(t, "VolumeSnapshot", restoredPVC.Spec.DataSource.Kind)
+	assert.Equal(t, "gp3", *restoredPVC.Spec.StorageClassName)
+	assert.True(t, restoredPVC.Spec.Resources.Requests[corev1.ResourceStorage].Equal(resource.MustParse("8Gi")))
+
+	assert.NoError(t, cl.Get(context.TODO(), client.ObjectKey{Name: "ns1"}, updatedNs))
+	assert.Empty(t, updatedNs.Annotations[restoreFromSnapshotAnnotation])
+	assert.Empty(t, updatedNs.Annotations[restorePendingSinceAnnotation])
+}
+
+func TestRestoreVolumeFromMissingSnapshot(t *testing.T) {
+	ns, project := getUserNamespace("ns1", "user_1", nil)
+	ns.Annotations[restoreFromSnapshotAnnotation] = "unknown"
+
+	_, cl, r := setup(infrastructure.OpenShiftV4, ns, project, getCheClusterWithPerUserPVC())
+
+	result, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "ns1"}})
+	assert.NoError(t, err)
+	assert.Zero(t, result.RequeueAfter)
+
+	updatedNs := &corev1.Namespace{}
+	assert.NoError(t, cl.Get(context.TODO(), client.ObjectKey{Name: "ns1"}, updatedNs))
+	assert.Empty(t, updatedNs.Annotations[restoreFromSnapshotAnnotation])
+}
+
+func TestRestorePerWorkspaceVolumeFromSnapshot(t *testing.T) {
+	ns, project := getUserNamespace("ns1", "user_1", nil)
+	ns.Annotations[restoreFromSnapshotAnnotation] = "backup-1"
+	project.Annotations[restoreFromSnapshotAnnotation] = "backup-1"
+
+	stoppedWorkspace := &dw.DevWorkspace{
+		ObjectMeta: metav1.ObjectMeta{Name: "ws1", Namespace: "ns1", UID: "ws1-uid"},
+		Status:     dw.DevWorkspaceStatus{DevWorkspaceId: "workspace1"},
+	}
+	// Other workspaces do not use the PVC of the `per-workspace` strategy
+	runningWorkspace := &dw.DevWorkspace{
+		ObjectMeta: metav1.ObjectMeta{Name: "ws2", Namespace: "ns1"},
+		Spec:       dw.DevWorkspaceSpec{Started: true},
+		Status:     dw.DevWorkspaceStatus{DevWorkspaceId: "workspace2"},
+	}
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "storage-workspace1",
+			Namespace: "ns1",
+			Labels:    map[string]string{dwconstants.DevWorkspaceIDLabel: "workspace1"},
+		},
+	}
+
+	snapshot := getReadySnapshot("backup-1", "ns1", "8Gi")
+	snapshot.SetLabels(map[string]string{dwconstants.DevWorkspaceIDLabel: "workspace1"})
+	assert.NoError(t, unstructured.SetNestedField(snapshot.Object, "storage-workspace1", "spec", "source", "persistentVolumeClaimName"))
+
+	_, cl, r := setup(infrastructure.OpenShiftV4, ns, project, stoppedWorkspace, runningWorkspace, pvc, snapshot, getCheClusterWithPerUserPVC())
+
+	// The existing PVC is deleted
+	result, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "ns1"}})
+	assert.NoError(t, err)
+	assert.Equal(t, volumeRestoreCheckPeriod, result.RequeueAfter)
+
+	// The PVC is re-provisioned from the snapshot and owned by its workspace
+	result, err = r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "ns1"}})
+	assert.NoError(t, err)
+	assert.Zero(t, result.RequeueAfter)
+
+	restoredPVC := &corev1.PersistentVolumeClaim{}
+	assert.NoError(t, cl.Get(context.TODO(), client.ObjectKeyFromObject(pvc), restoredPVC))
+	assert.Equal(t, "backup-1", restoredPVC.Spec.DataSource.Name)
+	assert.Equal(t, "workspace1", restoredPVC.Labels[dwconstants.DevWorkspaceIDLabel])
+	assert.Len(t, restoredPVC.OwnerReferences, 1)
+	assert.Equal(t, "ws1", restoredPVC.OwnerReferences[0].Name)
+	assert.Nil(t, restoredPVC.Spec.StorageClassName)
+
+	err = cl.Get(context.TODO(), client.ObjectKey{Name: dwconstants.DefaultWorkspacePVCName, Namespace: "ns1"}, &corev1.PersistentVolumeClaim{})
+	assert.True(t, errors.IsNotFound(err))
+}
@@ -0,0 +1,109 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package usernamespace
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/eclipse-che/che-operator/controllers/namespacecache"
+	"github.com/eclipse-che/che-operator/pkg/common/constants"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	// UserGroupsWebhookPath is the path of the DevWorkspace admission webhook recording the user groups
+	UserGroupsWebhookPath = "/validate-workspace-devfile-io-v1alpha2-devworkspace"
+
+	systemGroupPrefix = "system:"
+)
+
+var _ admission.Handler = &UserGroupsRecorder{}
+
+// UserGroupsRecorder records the groups of a user in the `che.eclipse.org/groups` annotation
+// of the user namespace when the user creates or updates a DevWorkspace, see getUserGroups.
+// The groups are the ones the cluster API server authenticated the user with,
+// that is the groups claim of the OIDC token or the OpenShift groups.
+// The requests are always allowed.
+type UserGroupsRecorder struct {
+	client         client.Client
+	namespaceCache *namespacecache.NamespaceCache
+}
+
+func NewUserGroupsRecorder(client client.Client, namespaceCache *namespacecache.NamespaceCache) *UserGroupsRecorder {
+	return &UserGroupsRecorder{
+		client:         client,
+		namespaceCache: namespaceCache,
+	}
+}
+
+func (r *UserGroupsRecorder) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if ptr.Deref(req.DryRun, false) {
+		return admission.Allowed("")
+	}
+
+	if err := r.recordUserGroups(ctx, req.Namespace, req.UserInfo.Username, req.UserInfo.Groups); err != nil {
+		logger.Error(err, "Failed to record the user groups", "namespace", req.Namespace, "username", req.UserInfo.Username)
+	}
+
+	return admission.Allowed("")
+}
+
+// recordUserGroups updates the groups annotation of the namespace, if the namespace belongs to the user.
+// Requests of other users or service accounts, for instance the DevWorkspace controller, are ignored.
+func (r *UserGroupsRecorder) recordUserGroups(ctx context.Context, namespace string, username string, groups []string) error {
+	info, err := r.namespaceCache.GetNamespaceInfo(ctx, namespace)
+	if err != nil {
+		return err
+	}
+
+	if info == nil || !info.IsWorkspaceNamespace || info.Username == "" || info.Username != username {
+		return nil
+	}
+
+	ns := &corev1.Namespace{}
+	if err := r.client.Get(ctx, client.ObjectKey{Name: namespace}, ns); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	userGroups := getUserGroupsAnnotation(groups)
+	if ns.GetAnnotations()[constants.CheEclipseOrgGroups] == userGroups {
+		return nil
+	}
+
+	patch := client.MergeFrom(ns.DeepCopy())
+	if ns.Annotations == nil {
+		ns.Annotations = map[string]string{}
+	}
+	ns.Annotations[constants.CheEclipseOrgGroups] = userGroups
+
+	return r.client.Patch(ctx, ns, patch)
+}
+
+// getUserGroupsAnnotation returns the sorted groups of the user, without the system groups
+// every authenticated user is a member of.
+func getUserGroupsAnnotation(groups []string) string {
+	userGroups := make([]string, 0, len(groups))
+	for _, group := range groups {
+		if group != "" && !strings.HasPrefix(group, systemGroupPrefix) {
+			userGroups = append(userGroups, group)
+		}
+	}
+
+	slices.Sort(userGroups)
+	return strings.Join(slices.Compact(userGroups), ",")
+}
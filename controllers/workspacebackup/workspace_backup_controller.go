@@ -0,0 +1,256 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package workspacebackup
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sort"
+	"time"
+
+	dwconstants "github.com/devfile/devworkspace-operator/pkg/constants"
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/eclipse-che/che-operator/controllers/namespacecache"
+	"github.com/eclipse-che/che-operator/pkg/common/constants"
+	"github.com/eclipse-che/che-operator/pkg/common/cron"
+	"github.com/eclipse-che/che-operator/pkg/deploy/volumesnapshot"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	defaultSchedule     = "0 1 * * *"
+	defaultMaxSnapshots = 7
+	snapshotTimeFormat  = "200601021504"
+)
+
+var (
+	logger = ctrl.Log.WithName("workspace-backup")
+
+	backupLabels = map[string]string{
+		constants.KubernetesPartOfLabelKey:    constants.CheEclipseOrg,
+		constants.KubernetesComponentLabelKey: constants.WorkspaceBackupComponentName,
+	}
+)
+
+type WorkspaceBackupReconciler struct {
+	client          client.Client
+	nonCachedClient client.Client
+	namespaceCache  *namespacecache.NamespaceCache
+	recorder        record.EventRecorder
+	now             func() time.Time
+}
+
+var _ reconcile.Reconciler = (*WorkspaceBackupReconciler)(nil)
+
+func NewWorkspaceBackupReconciler(
+	client client.Client,
+	noncachedClient client.Client,
+	namespaceCache *namespacecache.NamespaceCache,
+	recorder record.EventRecorder) *WorkspaceBackupReconciler {
+
+	return &WorkspaceBackupReconciler{
+		client:          client,
+		nonCachedClient: noncachedClient,
+		namespaceCache:  namespaceCache,
+		recorder:        recorder,
+		now:             time.Now,
+	}
+}
+
+func (r *WorkspaceBackupReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Use controller.TypedOptions to allow to configure 2 controllers for same object being reconciled
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("workspace-backup").
+		For(&chev2.CheCluster{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		WithOptions(
+			controller.TypedOptions[reconcile.Request]{
+				SkipNameValidation: ptr.To(true),
+			}).
+		Complete(r)
+}
+
+func (r *WorkspaceBackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	checluster := &chev2.CheCluster{}
+	if err := r.client.Get(ctx, req.NamespacedName, checluster); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	backup := checluster.Spec.DevEnvironments.Storage.Backup
+	if backup == nil {
+		return ctrl.Result{}, nil
+	}
+
+	scheduleSpec := backup.Schedule
+	if scheduleSpec == "" {
+		scheduleSpec = defaultSchedule
+	}
+
+	schedule, err := cron.Parse(scheduleSpec)
+	if err != nil {
+		// Invalid configuration is rejected by the webhook, nothing to retry here
+		logger.Error(err, "Invalid workspace backup schedule")
+		return ctrl.Result{}, nil
+	}
+
+	now := r.now().UTC()
+
+	pvcs, err := r.getWorkspacePVCs(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	snapshots, err := r.getSnapshots(ctx)
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			logger.Info("VolumeSnapshot API is not available, workspace backup skipped")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	maxSnapshots := int(ptr.Deref(backup.MaxSnapshots, defaultMaxSnapshots))
+	for i := range pvcs {
+		pvc := &pvcs[i]
+		pvcSnapshots := snapshots[types.NamespacedName{Namespace: pvc.Namespace, Name: pvc.Name}]
+
+		if err := r.backupPVC(ctx, pvc, pvcSnapshots, backup.VolumeSnapshotClassName, schedule, maxSnapshots, now); err != nil {
+			r.recorder.Eventf(pvc, corev1.EventTypeWarning, "BackupFailed", "Failed to back up the volume: %s", err.Error())
+			logger.Error(err, "Failed to back up the volume", "namespace", pvc.Namespace, "name", pvc.Name)
+		}
+	}
+
+	next := schedule.Next(now)
+	if next.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	return ctrl.Result{RequeueAfter: next.Sub(now)}, nil
+}
+
+// backupPVC snapshots the PVC if a scheduled backup is missed since the last snapshot
+// and deletes the oldest snapshots exceeding the limit.
+func (r *WorkspaceBackupReconciler) backupPVC(
+	ctx context.Context,
+	pvc *corev1.PersistentVolumeClaim,
+	snapshots []unstructured.Unstructured,
+	volumeSnapshotClassName string,
+	schedule *cron.Schedule,
+	maxSnapshots int,
+	now time.Time,
+) error {
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].GetCreationTimestamp().Time.Before(snapshots[j].GetCreationTimestamp().Time)
+	})
+
+	var lastBackup time.Time
+	if len(snapshots) > 0 {
+		lastBackup = snapshots[len(snapshots)-1].GetCreationTimestamp().Time
+	}
+
+	if !lastBackup.IsZero() && schedule.Next(lastBackup).After(now) {
+		return nil
+	}
+
+	labels := maps.Clone(backupLabels)
+	if workspaceID := pvc.GetLabels()[dwconstants.DevWorkspaceIDLabel]; workspaceID != "" {
+		// Restores the PVC of the `per-workspace` strategy for its workspace
+		labels[dwconstants.DevWorkspaceIDLabel] = workspaceID
+	}
+
+	snapshot := volumesnapshot.New(
+		fmt.Sprintf("%s-%s", pvc.Name, now.Format(snapshotTimeFormat)),
+		pvc.Namespace,
+		pvc.Name,
+		volumeSnapshotClassName,
+		labels,
+	)
+	if err := r.nonCachedClient.Create(ctx, snapshot); err != nil {
+		if errors.IsAlreadyExists(err) {
+			return nil
+		}
+		return err
+	}
+
+	r.recorder.Eventf(pvc, corev1.EventTypeNormal, "BackupCreated", "Volume snapshotted to %s", snapshot.GetName())
+
+	// The new snapshot is kept, so only maxSnapshots-1 of the existing ones remain
+	for i := 0; i < len(snapshots)-(maxSnapshots-1); i++ {
+		if err := r.nonCachedClient.Delete(ctx, &snapshots[i]); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getWorkspacePVCs returns the PVCs of the `per-user` and `per-workspace` strategies in the user namespaces.
+func (r *WorkspaceBackupReconciler) getWorkspacePVCs(ctx context.Context) ([]corev1.PersistentVolumeClaim, error) {
+	pvcs := &corev1.PersistentVolumeClaimList{}
+	if err := r.nonCachedClient.List(ctx, pvcs); err != nil {
+		return nil, err
+	}
+
+	var workspacePVCs []corev1.PersistentVolumeClaim
+	for _, pvc := range pvcs.Items {
+		if pvc.Name != dwconstants.DefaultWorkspacePVCName && pvc.GetLabels()[dwconstants.DevWorkspaceIDLabel] == "" {
+			continue
+		}
+
+		if pvc.DeletionTimestamp != nil || pvc.Status.Phase != corev1.ClaimBound {
+			continue
+		}
+
+		info, err := r.namespaceCache.GetNamespaceInfo(ctx, pvc.Namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		if info != nil && info.IsWorkspaceNamespace {
+			workspacePVCs = append(workspacePVCs, pvc)
+		}
+	}
+
+	return workspacePVCs, nil
+}
+
+// getSnapshots returns the backup snapshots grouped by the source PVC.
+func (r *WorkspaceBackupReconciler) getSnapshots(ctx context.Context) (map[types.NamespacedName][]unstructured.Unstructured, error) {
+	snapshots := volumesnapshot.NewList()
+	if err := r.nonCachedClient.List(ctx, snapshots, client.MatchingLabels(backupLabels)); err != nil {
+		return nil, err
+	}
+
+	snapshotsByPVC := map[types.NamespacedName][]unstructured.Unstructured{}
+	for _, snapshot := range snapshots.Items {
+		key := types.NamespacedName{Namespace: snapshot.GetNamespace(), Name: volumesnapshot.GetSourcePVCName(&snapshot)}
+		snapshotsByPVC[key] = append(snapshotsByPVC[key], snapshot)
+	}
+
+	return snapshotsByPVC, nil
+}
@@ -0,0 +1,138 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package workspacebackup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dwconstants "github.com/devfile/devworkspace-operator/pkg/constants"
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/eclipse-che/che-operator/controllers/namespacecache"
+	"github.com/eclipse-che/che-operator/pkg/common/constants"
+	"github.com/eclipse-che/che-operator/pkg/common/test"
+	"github.com/eclipse-che/che-operator/pkg/deploy/volumesnapshot"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var (
+	cheClusterKey = types.NamespacedName{Name: "eclipse-che", Namespace: "eclipse-che"}
+	now           = time.Date(2026, 10, 16, 1, 0, 30, 0, time.UTC)
+)
+
+func setup(objs ...client.Object) (client.Client, *WorkspaceBackupReconciler) {
+	ctx := test.NewCtxBuilder().WithObjects(objs...).WithCheCluster(nil).Build()
+	cl := ctx.ClusterAPI.Client
+
+	r := NewWorkspaceBackupReconciler(cl, cl, namespacecache.NewNamespaceCache(cl), record.NewFakeRecorder(10))
+	r.now = func() time.Time { return now }
+
+	return cl, r
+}
+
+func getCheCluster() *chev2.CheCluster {
+	return &chev2.CheCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cheClusterKey.Name,
+			Namespace: cheClusterKey.Namespace,
+		},
+		Spec: chev2.CheClusterSpec{
+			DevEnvironments: chev2.CheClusterDevEnvironments{
+				Storage: chev2.WorkspaceStorage{
+					Backup: &chev2.WorkspaceStorageBackup{
+						VolumeSnapshotClassName: "csi-snapclass",
+						Schedule:                "0 1 * * *",
+						MaxSnapshots:            ptr.To(int32(2)),
+					},
+				},
+			},
+		},
+	}
+}
+
+func getPVC(name string, namespace string, labels map[string]string) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Status: corev1.PersistentVolumeClaimStatus{
+			Phase: corev1.ClaimBound,
+		},
+	}
+}
+
+func getSnapshot(name string, namespace string, pvcName string, createdAt time.Time) client.Object {
+	snapshot := volumesnapshot.New(name, namespace, pvcName, "csi-snapclass", backupLabels)
+	snapshot.SetCreationTimestamp(metav1.NewTime(createdAt))
+	return snapshot
+}
+
+func TestBackupWorkspacePVCs(t *testing.T) {
+	userNamespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "user1-che",
+			Labels: map[string]string{
+				constants.KubernetesPartOfLabelKey:    constants.CheEclipseOrg,
+				constants.KubernetesComponentLabelKey: constants.WorkspacesNamespaceComponentName,
+			},
+		},
+	}
+	otherNamespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other"}}
+
+	cl, r := setup(
+		getCheCluster(),
+		userNamespace,
+		otherNamespace,
+		getPVC(dwconstants.DefaultWorkspacePVCName, "user1-che", nil),
+		getPVC("storage-workspace1", "user1-che", map[string]string{dwconstants.DevWorkspaceIDLabel: "workspace1"}),
+		getPVC("storage-workspace2", "user1-che", map[string]string{dwconstants.DevWorkspaceIDLabel: "workspace2"}),
+		getPVC("data", "user1-che", nil),
+		getPVC(dwconstants.DefaultWorkspacePVCName, "other", nil),
+		getSnapshot("claim-devworkspace-old", "user1-che", dwconstants.DefaultWorkspacePVCName, now.AddDate(0, 0, -2)),
+		getSnapshot("claim-devworkspace-last", "user1-che", dwconstants.DefaultWorkspacePVCName, now.AddDate(0, 0, -1)),
+		getSnapshot("storage-workspace1-today", "user1-che", "storage-workspace1", now.Add(-10*time.Second)),
+	)
+
+	result, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: cheClusterKey})
+	assert.NoError(t, err)
+	assert.Equal(t, 24*time.Hour-30*time.Second, result.RequeueAfter)
+
+	snapshots := volumesnapshot.NewList()
+	assert.NoError(t, cl.List(context.TODO(), snapshots))
+
+	var names []string
+	for _, snapshot := range snapshots.Items {
+		names = append(names, snapshot.GetNamespace()+"/"+snapshot.GetName())
+	}
+
+	assert.ElementsMatch(t, []string{
+		"user1-che/claim-devworkspace-202610160100",
+		"user1-che/claim-devworkspace-last",
+		"user1-che/storage-workspace1-today",
+		"user1-che/storage-workspace2-202610160100",
+	}, names)
+
+	snapshot := volumesnapshot.NewEmpty()
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: "storage-workspace2-202610160100", Namespace: "user1-che"}, snapshot))
+	assert.Equal(t, "workspace2", snapshot.GetLabels()[dwconstants.DevWorkspaceIDLabel])
+}
@@ -14,10 +14,8 @@ package workspace_config
 
 import (
 	"context"
-	"sync"
 	"testing"
 
-	"github.com/eclipse-che/che-operator/controllers/namespacecache"
 	"k8s.io/utils/ptr"
 
 	dwconstants "github.com/devfile/devworkspace-operator/pkg/constants"
@@ -71,16 +69,7 @@ func TestSyncConfigMap(t *testing.T) {
 		deployContext.ClusterAPI.Client,
 		deployContext.ClusterAPI.Client,
 		deployContext.ClusterAPI.Scheme,
-		&namespacecache.NamespaceCache{
-			Client: deployContext.ClusterAPI.Client,
-			KnownNamespaces: map[string]namespacecache.NamespaceInfo{
-				userNamespace: {
-					IsWorkspaceNamespace: true,
-					Username:             "user",
-				},
-			},
-			Lock: sync.Mutex{},
-		})
+		getNamespaceCache(t, deployContext.ClusterAPI.Client))
 
 	// Sync ConfigMap
 	err := workspaceConfigReconciler.syncNamespace(context.TODO(), eclipseCheNamespace, userNamespace)
@@ -236,16 +225,7 @@ func TestSyncConfigMapShouldMergeLabelsAndAnnotationsOnUpdate(t *testing.T) {
 		deployContext.ClusterAPI.Client,
 		deployContext.ClusterAPI.Client,
 		deployContext.ClusterAPI.Scheme,
-		&namespacecache.NamespaceCache{
-			Client: deployContext.ClusterAPI.Client,
-			KnownNamespaces: map[string]namespacecache.NamespaceInfo{
-				userNamespace: {
-					IsWorkspaceNamespace: true,
-					Username:             "user",
-				},
-			},
-			Lock: sync.Mutex{},
-		})
+		getNamespaceCache(t, deployContext.ClusterAPI.Client))
 
 	// Sync ConfigMap
 	err := workspaceConfigReconciler.syncNamespace(context.TODO(), eclipseCheNamespace, userNamespace)
@@ -352,16 +332,7 @@ func TestSyncConfigMapShouldRespectDWOLabels(t *testing.T) {
 		deployContext.ClusterAPI.Client,
 		deployContext.ClusterAPI.Client,
 		deployContext.ClusterAPI.Scheme,
-		&namespacecache.NamespaceCache{
-			Client: deployContext.ClusterAPI.Client,
-			KnownNamespaces: map[string]namespacecache.NamespaceInfo{
-				userNamespace: {
-					IsWorkspaceNamespace: true,
-					Username:             "user",
-				},
-			},
-			Lock: sync.Mutex{},
-		})
+		getNamespaceCache(t, deployContext.ClusterAPI.Client))
 
 	// Sync ConfigMap
 	err := workspaceConfigReconciler.syncNamespace(context.TODO(), eclipseCheNamespace, userNamespace)
@@ -449,16 +420,7 @@ func TestSyncConfigMapShouldRemoveSomeLabels(t *testing.T) {
 		deployContext.ClusterAPI.Client,
 		deployContext.ClusterAPI.Client,
 		deployContext.ClusterAPI.Scheme,
-		&namespacecache.NamespaceCache{
-			Client: deployContext.ClusterAPI.Client,
-			KnownNamespaces: map[string]namespacecache.NamespaceInfo{
-				userNamespace: {
-					IsWorkspaceNamespace: true,
-					Username:             "user",
-				},
-			},
-			Lock: sync.Mutex{},
-		})
+		getNamespaceCache(t, deployContext.ClusterAPI.Client))
 
 	// Sync ConfigMap
 	err := workspaceConfigReconciler.syncNamespace(context.TODO(), eclipseCheNamespace, userNamespace)
@@ -499,16 +461,7 @@ func TestSyncConfigMapShouldRetainIfAnnotationSetTrue(t *testing.T) {
 		deployContext.ClusterAPI.Client,
 		deployContext.ClusterAPI.Client,
 		deployContext.ClusterAPI.Scheme,
-		&namespacecache.NamespaceCache{
-			Client: deployContext.ClusterAPI.Client,
-			KnownNamespaces: map[string]namespacecache.NamespaceInfo{
-				userNamespace: {
-					IsWorkspaceNamespace: true,
-					Username:             "user",
-				},
-			},
-			Lock: sync.Mutex{},
-		})
+		getNamespaceCache(t, deployContext.ClusterAPI.Client))
 
 	// Sync ConfigMap
 	err := workspaceConfigReconciler.syncNamespace(context.TODO(), eclipseCheNamespace, userNamespace)
@@ -560,16 +513,7 @@ func TestSyncConfigMapShouldNotRetainIfAnnotationSetFalse(t *testing.T) {
 		deployContext.ClusterAPI.Client,
 		deployContext.ClusterAPI.Client,
 		deployContext.ClusterAPI.Scheme,
-		&namespacecache.NamespaceCache{
-			Client: deployContext.ClusterAPI.Client,
-			KnownNamespaces: map[string]namespacecache.NamespaceInfo{
-				userNamespace: {
-					IsWorkspaceNamespace: true,
-					Username:             "user",
-				},
-			},
-			Lock: sync.Mutex{},
-		})
+		getNamespaceCache(t, deployContext.ClusterAPI.Client))
 
 	// Sync ConfigMap
 	err := workspaceConfigReconciler.syncNamespace(context.TODO(), eclipseCheNamespace, userNamespace)
@@ -13,9 +13,18 @@
 package workspace_config
 
 import (
+	"context"
+	"testing"
+
+	"github.com/eclipse-che/che-operator/controllers/namespacecache"
+	"github.com/eclipse-che/che-operator/pkg/common/constants"
 	"github.com/eclipse-che/che-operator/pkg/common/infrastructure"
 	defaults "github.com/eclipse-che/che-operator/pkg/common/operator-defaults"
 	"github.com/eclipse-che/che-operator/pkg/common/test"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 func init() {
@@ -24,3 +33,26 @@ func init() {
 	infrastructure.InitializeForTesting(infrastructure.OpenShiftV4)
 	defaults.InitializeForTesting("../../config/manager/manager.yaml")
 }
+
+// getNamespaceCache returns the cache which knows the workspace namespace of the user.
+func getNamespaceCache(t *testing.T, cli client.Client) *namespacecache.NamespaceCache {
+	err := cli.Create(context.TODO(), &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: userNamespace,
+			Labels: map[string]string{
+				constants.KubernetesPartOfLabelKey:    constants.CheEclipseOrg,
+				constants.KubernetesComponentLabelKey: constants.WorkspacesNamespaceComponentName,
+			},
+			Annotations: map[string]string{
+				constants.CheEclipseOrgUsername: "user",
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	namespaceCache := namespacecache.NewNamespaceCache(cli)
+	_, err = namespaceCache.ExamineNamespace(context.TODO(), userNamespace)
+	assert.NoError(t, err)
+
+	return namespaceCache
+}
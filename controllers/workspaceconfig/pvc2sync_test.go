@@ -14,10 +14,8 @@ package workspace_config
 
 import (
 	"context"
-	"sync"
 	"testing"
 
-	"github.com/eclipse-che/che-operator/controllers/namespacecache"
 	"k8s.io/apimachinery/pkg/api/errors"
 
 	"github.com/eclipse-che/che-operator/pkg/common/constants"
@@ -57,16 +55,7 @@ func TestSyncPVC(t *testing.T) {
 		deployContext.ClusterAPI.Client,
 		deployContext.ClusterAPI.Client,
 		deployContext.ClusterAPI.Scheme,
-		&namespacecache.NamespaceCache{
-			Client: deployContext.ClusterAPI.Client,
-			KnownNamespaces: map[string]namespacecache.NamespaceInfo{
-				userNamespace: {
-					IsWorkspaceNamespace: true,
-					Username:             "user",
-				},
-			},
-			Lock: sync.Mutex{},
-		})
+		getNamespaceCache(t, deployContext.ClusterAPI.Client))
 
 	assertSyncConfig(t, workspaceConfigReconciler, 0, v1PvcGKV)
 
@@ -160,16 +149,7 @@ func TestSyncPVCShouldRetainIfAnnotationSetTrue(t *testing.T) {
 		deployContext.ClusterAPI.Client,
 		deployContext.ClusterAPI.Client,
 		deployContext.ClusterAPI.Scheme,
-		&namespacecache.NamespaceCache{
-			Client: deployContext.ClusterAPI.Client,
-			KnownNamespaces: map[string]namespacecache.NamespaceInfo{
-				userNamespace: {
-					IsWorkspaceNamespace: true,
-					Username:             "user",
-				},
-			},
-			Lock: sync.Mutex{},
-		})
+		getNamespaceCache(t, deployContext.ClusterAPI.Client))
 
 	assertSyncConfig(t, workspaceConfigReconciler, 0, v1PvcGKV)
 
@@ -222,16 +202,7 @@ func TestSyncPVCShouldNotRetainIfAnnotationSetFalse(t *testing.T) {
 		deployContext.ClusterAPI.Client,
 		deployContext.ClusterAPI.Client,
 		deployContext.ClusterAPI.Scheme,
-		&namespacecache.NamespaceCache{
-			Client: deployContext.ClusterAPI.Client,
-			KnownNamespaces: map[string]namespacecache.NamespaceInfo{
-				userNamespace: {
-					IsWorkspaceNamespace: true,
-					Username:             "user",
-				},
-			},
-			Lock: sync.Mutex{},
-		})
+		getNamespaceCache(t, deployContext.ClusterAPI.Client))
 
 	assertSyncConfig(t, workspaceConfigReconciler, 0, v1PvcGKV)
 
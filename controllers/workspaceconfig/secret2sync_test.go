@@ -14,10 +14,8 @@ package workspace_config
 
 import (
 	"context"
-	"sync"
 	"testing"
 
-	"github.com/eclipse-che/che-operator/controllers/namespacecache"
 	"k8s.io/utils/ptr"
 
 	dwconstants "github.com/devfile/devworkspace-operator/pkg/constants"
@@ -62,16 +60,7 @@ func TestSyncSecrets(t *testing.T) {
 		deployContext.ClusterAPI.Client,
 		deployContext.ClusterAPI.Client,
 		deployContext.ClusterAPI.Scheme,
-		&namespacecache.NamespaceCache{
-			Client: deployContext.ClusterAPI.Client,
-			KnownNamespaces: map[string]namespacecache.NamespaceInfo{
-				userNamespace: {
-					IsWorkspaceNamespace: true,
-					Username:             "user",
-				},
-			},
-			Lock: sync.Mutex{},
-		})
+		getNamespaceCache(t, deployContext.ClusterAPI.Client))
 
 	// Sync Secret
 	err := workspaceConfigReconciler.syncNamespace(context.TODO(), eclipseCheNamespace, userNamespace)
@@ -237,16 +226,7 @@ func TestSyncSecretShouldMergeLabelsAndAnnotationsOnUpdate(t *testing.T) {
 		deployContext.ClusterAPI.Client,
 		deployContext.ClusterAPI.Client,
 		deployContext.ClusterAPI.Scheme,
-		&namespacecache.NamespaceCache{
-			Client: deployContext.ClusterAPI.Client,
-			KnownNamespaces: map[string]namespacecache.NamespaceInfo{
-				userNamespace: {
-					IsWorkspaceNamespace: true,
-					Username:             "user",
-				},
-			},
-			Lock: sync.Mutex{},
-		})
+		getNamespaceCache(t, deployContext.ClusterAPI.Client))
 
 	// Sync Secret
 	err := workspaceConfigReconciler.syncNamespace(context.TODO(), eclipseCheNamespace, userNamespace)
@@ -343,16 +323,7 @@ func TestSyncSecretShouldRespectDWOLabels(t *testing.T) {
 		deployContext.ClusterAPI.Client,
 		deployContext.ClusterAPI.Client,
 		deployContext.ClusterAPI.Scheme,
-		&namespacecache.NamespaceCache{
-			Client: deployContext.ClusterAPI.Client,
-			KnownNamespaces: map[string]namespacecache.NamespaceInfo{
-				userNamespace: {
-					IsWorkspaceNamespace: true,
-					Username:             "user",
-				},
-			},
-			Lock: sync.Mutex{},
-		})
+		getNamespaceCache(t, deployContext.ClusterAPI.Client))
 
 	// Sync Secret
 	err := workspaceConfigReconciler.syncNamespace(context.TODO(), eclipseCheNamespace, userNamespace)
@@ -441,16 +412,7 @@ func TestSyncSecretShouldRemoveSomeLabels(t *testing.T) {
 		deployContext.ClusterAPI.Client,
 		deployContext.ClusterAPI.Client,
 		deployContext.ClusterAPI.Scheme,
-		&namespacecache.NamespaceCache{
-			Client: deployContext.ClusterAPI.Client,
-			KnownNamespaces: map[string]namespacecache.NamespaceInfo{
-				userNamespace: {
-					IsWorkspaceNamespace: true,
-					Username:             "user",
-				},
-			},
-			Lock: sync.Mutex{},
-		})
+		getNamespaceCache(t, deployContext.ClusterAPI.Client))
 
 	// Sync Secret
 	err := workspaceConfigReconciler.syncNamespace(context.TODO(), eclipseCheNamespace, userNamespace)
@@ -14,11 +14,8 @@ package workspace_config
 
 import (
 	"context"
-	"sync"
 	"testing"
 
-	"github.com/eclipse-che/che-operator/controllers/namespacecache"
-
 	"github.com/eclipse-che/che-operator/pkg/deploy"
 	templatev1 "github.com/openshift/api/template/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
@@ -82,16 +79,7 @@ func TestSyncTemplateWithLimitRange(t *testing.T) {
 		deployContext.ClusterAPI.Client,
 		deployContext.ClusterAPI.Client,
 		deployContext.ClusterAPI.Scheme,
-		&namespacecache.NamespaceCache{
-			Client: deployContext.ClusterAPI.Client,
-			KnownNamespaces: map[string]namespacecache.NamespaceInfo{
-				userNamespace: {
-					IsWorkspaceNamespace: true,
-					Username:             "user",
-				},
-			},
-			Lock: sync.Mutex{},
-		})
+		getNamespaceCache(t, deployContext.ClusterAPI.Client))
 
 	// Sync Template
 	err := workspaceConfigReconciler.syncNamespace(context.TODO(), eclipseCheNamespace, userNamespace)
@@ -274,16 +262,7 @@ func TestSyncUnstructuredShouldRetainIfAnnotationSetTrue(t *testing.T) {
 		deployContext.ClusterAPI.Client,
 		deployContext.ClusterAPI.Client,
 		deployContext.ClusterAPI.Scheme,
-		&namespacecache.NamespaceCache{
-			Client: deployContext.ClusterAPI.Client,
-			KnownNamespaces: map[string]namespacecache.NamespaceInfo{
-				userNamespace: {
-					IsWorkspaceNamespace: true,
-					Username:             "user",
-				},
-			},
-			Lock: sync.Mutex{},
-		})
+		getNamespaceCache(t, deployContext.ClusterAPI.Client))
 
 	// Sync Template
 	err := workspaceConfigReconciler.syncNamespace(context.TODO(), eclipseCheNamespace, userNamespace)
@@ -358,16 +337,7 @@ func TestSyncUnstructuredShouldNotRetainIfAnnotationSetFalse(t *testing.T) {
 		deployContext.ClusterAPI.Client,
 		deployContext.ClusterAPI.Client,
 		deployContext.ClusterAPI.Scheme,
-		&namespacecache.NamespaceCache{
-			Client: deployContext.ClusterAPI.Client,
-			KnownNamespaces: map[string]namespacecache.NamespaceInfo{
-				userNamespace: {
-					IsWorkspaceNamespace: true,
-					Username:             "user",
-				},
-			},
-			Lock: sync.Mutex{},
-		})
+		getNamespaceCache(t, deployContext.ClusterAPI.Client))
 
 	// Sync Template
 	err := workspaceConfigReconciler.syncNamespace(context.TODO(), eclipseCheNamespace, userNamespace)
@@ -440,16 +410,7 @@ func TestSyncUnstructuredShouldNotRetainIfAnnotationIsNotSet(t *testing.T) {
 		deployContext.ClusterAPI.Client,
 		deployContext.ClusterAPI.Client,
 		deployContext.ClusterAPI.Scheme,
-		&namespacecache.NamespaceCache{
-			Client: deployContext.ClusterAPI.Client,
-			KnownNamespaces: map[string]namespacecache.NamespaceInfo{
-				userNamespace: {
-					IsWorkspaceNamespace: true,
-					Username:             "user",
-				},
-			},
-			Lock: sync.Mutex{},
-		})
+		getNamespaceCache(t, deployContext.ClusterAPI.Client))
 
 	// Sync Template
 	err := workspaceConfigReconciler.syncNamespace(context.TODO(), eclipseCheNamespace, userNamespace)
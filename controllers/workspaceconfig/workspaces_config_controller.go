@@ -58,6 +58,7 @@ type WorkspacesConfigReconciler struct {
 	clientWrapper                 *k8sclient.K8sClientWrapper
 	nonCachedClientWrapper        *k8sclient.K8sClientWrapper
 	namespaceCache                *namespacecache.NamespaceCache
+	fanOut                        *namespacecache.FanOut
 	labelsToRemoveBeforeSync      []*regexp.Regexp
 	annotationsToRemoveBeforeSync []*regexp.Regexp
 }
@@ -124,6 +125,7 @@ func NewWorkspacesConfigReconciler(
 		clientWrapper:                 k8sclient.NewK8sClient(cli, scheme),
 		nonCachedClientWrapper:        k8sclient.NewK8sClient(nonCachedCli, scheme),
 		namespaceCache:                namespaceCache,
+		fanOut:                        namespacecache.NewFanOut(cli),
 		labelsToRemoveBeforeSync:      labelsToRemoveBeforeSync,
 		annotationsToRemoveBeforeSync: annotationsToRemoveBeforeSync,
 	}
@@ -150,8 +152,9 @@ func (r *WorkspacesConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	// Use controller.TypedOptions to allow to configure 2 controllers for same object being reconciled
 	return bld.WithOptions(
 		controller.TypedOptions[reconcile.Request]{
-			SkipNameValidation: ptr.To(true),
-			UsePriorityQueue:   ptr.To(false),
+			SkipNameValidation:      ptr.To(true),
+			UsePriorityQueue:        ptr.To(true),
+			MaxConcurrentReconciles: namespacecache.MaxConcurrentReconciles,
 		}).Complete(r)
 }
 
@@ -198,7 +201,7 @@ func (r *WorkspacesConfigReconciler) watchRules(
 	cheNamespaceRule bool,
 	userNamespaceRule bool,
 ) handler.EventHandler {
-	return handler.EnqueueRequestsFromMapFunc(
+	return r.fanOut.EnqueueRequestsFromMapFunc(
 		func(context context.Context, obj client.Object) []reconcile.Request {
 			var eventRules []namespacecache.EventRule
 
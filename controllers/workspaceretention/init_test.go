@@ -0,0 +1,26 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package workspaceretention
+
+import (
+	"github.com/eclipse-che/che-operator/pkg/common/infrastructure"
+	defaults "github.com/eclipse-che/che-operator/pkg/common/operator-defaults"
+	"github.com/eclipse-che/che-operator/pkg/common/test"
+)
+
+func init() {
+	test.EnableTestMode()
+
+	infrastructure.InitializeForTesting(infrastructure.Kubernetes)
+	defaults.InitializeForTesting("../../config/manager/manager.yaml")
+}
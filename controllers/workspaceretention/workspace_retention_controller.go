@@ -0,0 +1,551 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package workspaceretention
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	dw "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	dwconstants "github.com/devfile/devworkspace-operator/pkg/constants"
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/eclipse-che/che-operator/controllers/namespacecache"
+	"github.com/eclipse-che/che-operator/pkg/common/constants"
+	"github.com/eclipse-che/che-operator/pkg/deploy/volumesnapshot"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// warnedAnnotation keeps the time the workspace owner was warned about the inactivity.
+	// The warning is valid for the inactivity period it was emitted in, so that it is emitted once per period
+	// and the workspace is deleted only after its owner has been warned.
+	warnedAnnotation = "che.eclipse.org/retention-warned-at"
+	// lastActivityAnnotation keeps the last activity time of the workspaces in a user namespace,
+	// so that the namespace is not deleted right after its workspaces were deleted by the user.
+	lastActivityAnnotation = "che.eclipse.org/retention-last-activity"
+
+	stopReason         = "retention-policy"
+	retentionCheckTime = time.Hour
+	// Workspaces waiting for their snapshots to be ready are checked more often
+	snapshotCheckPeriod = time.Minute
+	day                 = 24 * time.Hour
+	snapshotTimeFormat  = "20060102"
+	// Used when the retention policy is not defaulted by the API server
+	defaultDaysOfSnapshotRetention = 30
+
+	actionWarn            = "warn"
+	actionStop            = "stop"
+	actionSnapshot        = "snapshot"
+	actionDelete          = "delete"
+	actionDeleteNamespace = "delete-namespace"
+	actionDeleteSnapshot  = "delete-snapshot"
+)
+
+var (
+	logger = ctrl.Log.WithName("workspace-retention")
+
+	retentionActions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "che_operator_workspace_retention_actions_total",
+			Help: "Number of actions taken by the workspace retention policy.",
+		},
+		[]string{"action"},
+	)
+
+	retentionLabels = map[string]string{
+		constants.KubernetesPartOfLabelKey:    constants.CheEclipseOrg,
+		constants.KubernetesComponentLabelKey: constants.WorkspaceRetentionComponentName,
+	}
+)
+
+func init() {
+	metrics.Registry.MustRegister(retentionActions)
+}
+
+type WorkspaceRetentionReconciler struct {
+	client          client.Client
+	nonCachedClient client.Client
+	namespaceCache  *namespacecache.NamespaceCache
+	recorder        record.EventRecorder
+	now             func() time.Time
+}
+
+var _ reconcile.Reconciler = (*WorkspaceRetentionReconciler)(nil)
+
+func NewWorkspaceRetentionReconciler(
+	client client.Client,
+	noncachedClient client.Client,
+	namespaceCache *namespacecache.NamespaceCache,
+	recorder record.EventRecorder) *WorkspaceRetentionReconciler {
+
+	return &WorkspaceRetentionReconciler{
+		client:          client,
+		nonCachedClient: noncachedClient,
+		namespaceCache:  namespaceCache,
+		recorder:        recorder,
+		now:             time.Now,
+	}
+}
+
+func (r *WorkspaceRetentionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Use controller.TypedOptions to allow to configure 2 controllers for same object being reconciled
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("workspace-retention").
+		For(&chev2.CheCluster{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		WithOptions(
+			controller.TypedOptions[reconcile.Request]{
+				SkipNameValidation: ptr.To(true),
+			}).
+		Complete(r)
+}
+
+func (r *WorkspaceRetentionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	checluster := &chev2.CheCluster{}
+	if err := r.client.Get(ctx, req.NamespacedName, checluster); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	policy := checluster.Spec.DevEnvironments.RetentionPolicy
+	if policy == nil {
+		return ctrl.Result{}, nil
+	}
+
+	workspaces := &dw.DevWorkspaceList{}
+	if err := r.nonCachedClient.List(ctx, workspaces); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	now := r.now()
+	if err := r.deleteExpiredSnapshots(ctx, policy, now); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	lastActivity := map[string]time.Time{}
+	requeueAfter := retentionCheckTime
+
+	for i := range workspaces.Items {
+		workspace := &workspaces.Items[i]
+
+		info, err := r.namespaceCache.GetNamespaceInfo(ctx, workspace.Namespace)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		if info == nil || !info.IsWorkspaceNamespace || workspace.DeletionTimestamp != nil {
+			continue
+		}
+
+		deleted, snapshotPending, err := r.applyPolicy(ctx, policy, workspace, now)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		if snapshotPending {
+			requeueAfter = snapshotCheckPeriod
+		}
+
+		if !deleted {
+			last := getLastActivity(workspace)
+			if workspace.Spec.Started {
+				// The user namespace is in use while a workspace is running
+				last = now
+			}
+
+			if last.After(lastActivity[workspace.Namespace]) {
+				lastActivity[workspace.Namespace] = last
+			}
+		} else if _, found := lastActivity[workspace.Namespace]; !found {
+			lastActivity[workspace.Namespace] = time.Time{}
+		}
+	}
+
+	if err := r.reconcileNamespaces(ctx, checluster, lastActivity, now); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// applyPolicy stops the workspace running for too long, or warns about and deletes the stopped workspace depending on its inactivity.
+// Returns whether the workspace is deleted, and whether its deletion waits for the volume snapshots to be ready.
+func (r *WorkspaceRetentionReconciler) applyPolicy(
+	ctx context.Context,
+	policy *chev2.WorkspaceRetentionPolicy,
+	workspace *dw.DevWorkspace,
+	now time.Time,
+) (bool, bool, error) {
+	lastActivity := getLastActivity(workspace)
+
+	if workspace.Spec.Started {
+		// The DevWorkspace does not expose the user activity, a running workspace is only limited in uptime
+		uptime := now.Sub(lastActivity)
+		if !isExceeded(uptime, policy.DaysOfUptimeBeforeStop) {
+			return false, false, nil
+		}
+
+		patch := client.MergeFrom(workspace.DeepCopy())
+		workspace.Spec.Started = false
+		setAnnotation(workspace, dwconstants.DevWorkspaceStopReasonAnnotation, stopReason)
+		if err := r.nonCachedClient.Patch(ctx, workspace, patch); err != nil && !errors.IsNotFound(err) {
+			return false, false, err
+		}
+
+		r.recorder.Eventf(workspace, corev1.EventTypeNormal, "RetentionStop",
+			"Workspace stopped after running for %d days, the maximum uptime is %d days", int32(uptime/day), *policy.DaysOfUptimeBeforeStop)
+		retentionActions.WithLabelValues(actionStop).Inc()
+		logger.Info("Workspace stopped by the retention policy", "namespace", workspace.Namespace, "name", workspace.Name)
+		return false, false, nil
+	}
+
+	inactivity := now.Sub(lastActivity)
+	inactiveDays := int32(inactivity / day)
+	warnedAt, warned := getWarningTime(workspace, lastActivity)
+
+	if warned && isDeletionDue(policy, inactivity, now.Sub(warnedAt)) {
+		if policy.VolumeSnapshotClassName != "" {
+			ready, err := r.snapshotVolumes(ctx, workspace, policy.VolumeSnapshotClassName, warnedAt, now)
+			if err != nil {
+				// Never delete a workspace whose volumes could not be preserved
+				r.recorder.Eventf(workspace, corev1.EventTypeWarning, "RetentionSnapshotFailed",
+					"Failed to snapshot workspace volumes before deletion: %s", err.Error())
+				logger.Error(err, "Failed to snapshot workspace volumes", "namespace", workspace.Namespace, "name", workspace.Name)
+				return false, false, nil
+			}
+
+			if !ready {
+				return false, true, nil
+			}
+		}
+
+		r.recorder.Eventf(workspace, corev1.EventTypeNormal, "RetentionDeletion",
+			"Workspace deleted after %d days of inactivity", inactiveDays)
+		if err := r.nonCachedClient.Delete(ctx, workspace); err != nil && !errors.IsNotFound(err) {
+			return false, false, err
+		}
+
+		retentionActions.WithLabelValues(actionDelete).Inc()
+		logger.Info("Workspace deleted by the retention policy", "namespace", workspace.Namespace, "name", workspace.Name)
+		return true, false, nil
+	}
+
+	if isExceeded(inactivity, policy.DaysOfInactivityBeforeWarning) && !warned {
+		patch := client.MergeFrom(workspace.DeepCopy())
+		setAnnotation(workspace, warnedAnnotation, now.UTC().Format(time.RFC3339))
+		if err := r.nonCachedClient.Patch(ctx, workspace, patch); err != nil && !errors.IsNotFound(err) {
+			return false, false, err
+		}
+
+		r.recorder.Eventf(workspace, corev1.EventTypeWarning, "RetentionWarning",
+			"Workspace has been inactive for %d days.%s", inactiveDays, getUpcomingActions(policy, lastActivity, now))
+		retentionActions.WithLabelValues(actionWarn).Inc()
+	}
+
+	return false, false, nil
+}
+
+// snapshotVolumes creates a VolumeSnapshot of every PVC the workspace data is stored in,
+// unless one has been taken since the workspace owner was warned.
+// Returns true once all the snapshots are ready to use.
+func (r *WorkspaceRetentionReconciler) snapshotVolumes(
+	ctx context.Context,
+	workspace *dw.DevWorkspace,
+	volumeSnapshotClassName string,
+	warnedAt time.Time,
+	now time.Time,
+) (bool, error) {
+	pvcs := &corev1.PersistentVolumeClaimList{}
+	if err := r.nonCachedClient.List(ctx, pvcs, client.InNamespace(workspace.Namespace)); err != nil {
+		return false, err
+	}
+
+	snapshots := volumesnapshot.NewList()
+	if err := r.nonCachedClient.List(ctx, snapshots, client.InNamespace(workspace.Namespace), client.MatchingLabels(retentionLabels)); err != nil {
+		return false, err
+	}
+
+	ready := true
+	for _, pvc := range pvcs.Items {
+		if pvc.Name != dwconstants.DefaultWorkspacePVCName && !isOwnedBy(&pvc, workspace) {
+			continue
+		}
+
+		if snapshot := findSnapshot(snapshots.Items, pvc.Name, warnedAt); snapshot != nil {
+			// The volume shared by several workspaces is snapshotted once for all of them
+			ready = ready && volumesnapshot.IsReadyToUse(snapshot)
+			continue
+		}
+
+		snapshot := volumesnapshot.New(
+			fmt.Sprintf("%s-%s", pvc.Name, now.UTC().Format(snapshotTimeFormat)),
+			pvc.Namespace,
+			pvc.Name,
+			volumeSnapshotClassName,
+			retentionLabels,
+		)
+
+		if err := r.nonCachedClient.Create(ctx, snapshot); err != nil {
+			if !errors.IsAlreadyExists(err) {
+				return false, err
+			}
+
+			// The same-day snapshot of the volume, taken before the workspace owner was warned, is still valid
+			existing := volumesnapshot.NewEmpty()
+			if err := r.nonCachedClient.Get(ctx, client.ObjectKeyFromObject(snapshot), existing); err != nil {
+				return false, err
+			}
+			if volumesnapshot.GetSourcePVCName(existing) != pvc.Name {
+				return false, fmt.Errorf("VolumeSnapshot %s already exists and is not a snapshot of %s", existing.GetName(), pvc.Name)
+			}
+
+			ready = ready && volumesnapshot.IsReadyToUse(existing)
+			continue
+		}
+
+		ready = false
+
+		r.recorder.Eventf(workspace, corev1.EventTypeNormal, "RetentionSnapshot",
+			"Volume %s snapshotted to %s before workspace deletion", pvc.Name, snapshot.GetName())
+		retentionActions.WithLabelValues(actionSnapshot).Inc()
+	}
+
+	return ready, nil
+}
+
+// reconcileNamespaces records the last activity of the user namespaces and deletes
+// the ones created from the namespace template that have no workspaces left after the deletion period.
+func (r *WorkspaceRetentionReconciler) reconcileNamespaces(
+	ctx context.Context,
+	checluster *chev2.CheCluster,
+	lastActivity map[string]time.Time,
+	now time.Time,
+) error {
+	policy := checluster.Spec.DevEnvironments.RetentionPolicy
+
+	namespaces := &corev1.NamespaceList{}
+	if err := r.nonCachedClient.List(ctx, namespaces, client.MatchingLabels{
+		constants.KubernetesPartOfLabelKey:    constants.CheEclipseOrg,
+		constants.KubernetesComponentLabelKey: constants.WorkspacesNamespaceComponentName,
+	}); err != nil {
+		return err
+	}
+
+	for i := range namespaces.Items {
+		ns := &namespaces.Items[i]
+		if ns.DeletionTimestamp != nil {
+			continue
+		}
+
+		nsLastActivity, err := time.Parse(time.RFC3339, ns.GetAnnotations()[lastActivityAnnotation])
+		hadWorkspaces := err == nil
+
+		workspacesLastActivity, hasWorkspaces := lastActivity[ns.Name]
+		if workspacesLastActivity.After(nsLastActivity) {
+			patch := client.MergeFrom(ns.DeepCopy())
+			setAnnotation(ns, lastActivityAnnotation, workspacesLastActivity.UTC().Format(time.RFC3339))
+			if err := r.nonCachedClient.Patch(ctx, ns, patch); err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+			continue
+		}
+
+		// The namespaces provisioned in advance are kept until the user creates a workspace
+		if !hadWorkspaces ||
+			!ptr.Deref(policy.DeleteNamespaces, false) ||
+			!isExceeded(now.Sub(nsLastActivity), policy.DaysOfInactivityBeforeDeletion) ||
+			(hasWorkspaces && !workspacesLastActivity.IsZero()) ||
+			checluster.GetUserNamespaceName(ns.GetAnnotations()[constants.CheEclipseOrgUsername]) != ns.Name {
+			continue
+		}
+
+		if hasWorkspaces || r.hasWorkspaces(ctx, ns.Name) {
+			// Wait for the deleted workspaces to be finalized
+			continue
+		}
+
+		if r.hasRetentionSnapshots(ctx, ns.Name) {
+			// The namespace is kept until the snapshots taken before workspace deletion are deleted
+			continue
+		}
+
+		r.recorder.Eventf(checluster, corev1.EventTypeNormal, "RetentionNamespaceDeletion",
+			"User namespace %s deleted after %d days of inactivity", ns.Name, int32(now.Sub(nsLastActivity)/day))
+		if err := r.nonCachedClient.Delete(ctx, ns); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+
+		retentionActions.WithLabelValues(actionDeleteNamespace).Inc()
+		logger.Info("User namespace deleted by the retention policy", "namespace", ns.Name)
+	}
+
+	return nil
+}
+
+// deleteExpiredSnapshots deletes the volume snapshots taken before workspace deletion once the retention period has passed,
+// which lets the user namespaces holding them be deleted.
+func (r *WorkspaceRetentionReconciler) deleteExpiredSnapshots(ctx context.Context, policy *chev2.WorkspaceRetentionPolicy, now time.Time) error {
+	retention := ptr.Deref(policy.DaysOfSnapshotRetention, defaultDaysOfSnapshotRetention)
+	if retention == 0 {
+		return nil
+	}
+
+	snapshots := volumesnapshot.NewList()
+	if err := r.nonCachedClient.List(ctx, snapshots, client.MatchingLabels(retentionLabels)); err != nil {
+		// No snapshot can be taken on a cluster without the VolumeSnapshot API
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		return err
+	}
+
+	for i := range snapshots.Items {
+		snapshot := &snapshots.Items[i]
+		if snapshot.GetDeletionTimestamp() != nil || !isExceeded(now.Sub(snapshot.GetCreationTimestamp().Time), &retention) {
+			continue
+		}
+
+		if err := r.nonCachedClient.Delete(ctx, snapshot); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+
+		retentionActions.WithLabelValues(actionDeleteSnapshot).Inc()
+		logger.Info("Volume snapshot deleted by the retention policy", "namespace", snapshot.GetNamespace(), "name", snapshot.GetName())
+	}
+
+	return nil
+}
+
+func (r *WorkspaceRetentionReconciler) hasWorkspaces(ctx context.Context, namespace string) bool {
+	workspaces := &dw.DevWorkspaceList{}
+	if err := r.nonCachedClient.List(ctx, workspaces, client.InNamespace(namespace)); err != nil {
+		return true
+	}
+
+	return len(workspaces.Items) > 0
+}
+
+func (r *WorkspaceRetentionReconciler) hasRetentionSnapshots(ctx context.Context, namespace string) bool {
+	snapshots := volumesnapshot.NewList()
+	if err := r.nonCachedClient.List(ctx, snapshots, client.InNamespace(namespace), client.MatchingLabels(retentionLabels)); err != nil {
+		return !meta.IsNoMatchError(err)
+	}
+
+	return len(snapshots.Items) > 0
+}
+
+// getLastActivity returns the time the workspace was last started or stopped, or its creation time.
+func getLastActivity(workspace *dw.DevWorkspace) time.Time {
+	lastActivity := workspace.CreationTimestamp.Time
+
+	if startedAt, err := strconv.ParseInt(workspace.GetAnnotations()[dwconstants.DevWorkspaceStartedAtAnnotation], 10, 64); err == nil {
+		if t := time.Unix(0, startedAt); t.After(lastActivity) {
+			lastActivity = t
+		}
+	}
+
+	if workspace.Spec.Started {
+		return lastActivity
+	}
+
+	// The workspace conditions are updated when the workspace is stopped
+	for _, condition := range workspace.Status.Conditions {
+		if condition.LastTransitionTime.After(lastActivity) {
+			lastActivity = condition.LastTransitionTime.Time
+		}
+	}
+
+	return lastActivity
+}
+
+// getWarningTime returns the time the workspace owner was warned, and true if it was in the current inactivity period.
+func getWarningTime(workspace *dw.DevWorkspace, lastActivity time.Time) (time.Time, bool) {
+	warnedAt, err := time.Parse(time.RFC3339, workspace.GetAnnotations()[warnedAnnotation])
+	if err != nil || warnedAt.Before(lastActivity) {
+		return time.Time{}, false
+	}
+	return warnedAt, true
+}
+
+// isDeletionDue checks if both the deletion period and the time between the warning and the deletion have passed.
+func isDeletionDue(policy *chev2.WorkspaceRetentionPolicy, inactivity time.Duration, sinceWarning time.Duration) bool {
+	if policy.DaysOfInactivityBeforeDeletion == nil {
+		return false
+	}
+
+	deletion := *policy.DaysOfInactivityBeforeDeletion
+	warning := ptr.Deref(policy.DaysOfInactivityBeforeWarning, 0)
+
+	return isExceeded(inactivity, &deletion) && sinceWarning >= time.Duration(deletion-warning)*day
+}
+
+func getUpcomingActions(policy *chev2.WorkspaceRetentionPolicy, lastActivity time.Time, now time.Time) string {
+	if policy.DaysOfInactivityBeforeDeletion != nil {
+		deletion := *policy.DaysOfInactivityBeforeDeletion
+		deleteAt := lastActivity.Add(time.Duration(deletion) * day)
+		if afterWarning := now.Add(time.Duration(deletion-ptr.Deref(policy.DaysOfInactivityBeforeWarning, 0)) * day); afterWarning.After(deleteAt) {
+			deleteAt = afterWarning
+		}
+		return fmt.Sprintf(" It will be deleted on %s.", deleteAt.UTC().Format(time.DateOnly))
+	}
+	return ""
+}
+
+// findSnapshot returns the retention snapshot of the given PVC taken since the given time.
+func findSnapshot(snapshots []unstructured.Unstructured, pvcName string, since time.Time) *unstructured.Unstructured {
+	for i := range snapshots {
+		if volumesnapshot.GetSourcePVCName(&snapshots[i]) == pvcName && !snapshots[i].GetCreationTimestamp().Time.Before(since) {
+			return &snapshots[i]
+		}
+	}
+	return nil
+}
+
+func isExceeded(inactivity time.Duration, days *int32) bool {
+	return days != nil && inactivity >= time.Duration(*days)*day
+}
+
+func isOwnedBy(obj metav1.Object, owner metav1.Object) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.UID == owner.GetUID() {
+			return true
+		}
+	}
+	return false
+}
+
+func setAnnotation(obj metav1.Object, key string, value string) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[key] = value
+	obj.SetAnnotations(annotations)
+}
@@ -0,0 +1,333 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package workspaceretention
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	dw "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	dwconstants "github.com/devfile/devworkspace-operator/pkg/constants"
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/eclipse-che/che-operator/controllers/namespacecache"
+	"github.com/eclipse-che/che-operator/pkg/common/constants"
+	"github.com/eclipse-che/che-operator/pkg/common/test"
+	"github.com/eclipse-che/che-operator/pkg/deploy/volumesnapshot"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var (
+	cheClusterKey = types.NamespacedName{Name: "eclipse-che", Namespace: "eclipse-che"}
+	now           = time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+)
+
+func setup(objs ...client.Object) (client.Client, *record.FakeRecorder, *WorkspaceRetentionReconciler) {
+	ctx := test.NewCtxBuilder().WithObjects(objs...).WithCheCluster(nil).Build()
+	cl := ctx.ClusterAPI.Client
+
+	recorder := record.NewFakeRecorder(20)
+	r := NewWorkspaceRetentionReconciler(cl, cl, namespacecache.NewNamespaceCache(cl), recorder)
+	r.now = func() time.Time { return now }
+
+	return cl, recorder, r
+}
+
+func getCheCluster(policy *chev2.WorkspaceRetentionPolicy) *chev2.CheCluster {
+	return &chev2.CheCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cheClusterKey.Name,
+			Namespace: cheClusterKey.Namespace,
+		},
+		Spec: chev2.CheClusterSpec{
+			DevEnvironments: chev2.CheClusterDevEnvironments{
+				RetentionPolicy: policy,
+			},
+		},
+	}
+}
+
+func getUserNamespace(name string, username string, daysAgo int) *corev1.Namespace {
+	return &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				constants.KubernetesPartOfLabelKey:    constants.CheEclipseOrg,
+				constants.KubernetesComponentLabelKey: constants.WorkspacesNamespaceComponentName,
+			},
+			Annotations:       map[string]string{constants.CheEclipseOrgUsername: username},
+			CreationTimestamp: metav1.NewTime(now.AddDate(0, 0, -daysAgo)),
+		},
+	}
+}
+
+func getDevWorkspace(name string, namespace string, started bool, daysOfInactivity int) *dw.DevWorkspace {
+	startedAt := now.AddDate(0, 0, -daysOfInactivity)
+	return &dw.DevWorkspace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         namespace,
+			UID:               types.UID(name),
+			CreationTimestamp: metav1.NewTime(now.AddDate(-1, 0, 0)),
+			Annotations: map[string]string{
+				dwconstants.DevWorkspaceStartedAtAnnotation: strconv.FormatInt(startedAt.UnixNano(), 10),
+			},
+		},
+		Spec: dw.DevWorkspaceSpec{
+			Started: started,
+		},
+	}
+}
+
+func getRetentionActions(action string) float64 {
+	metric := &dto.Metric{}
+	_ = retentionActions.WithLabelValues(action).Write(metric)
+	return metric.GetCounter().GetValue()
+}
+
+func getWarnedDevWorkspace(name string, namespace string, daysOfInactivity int, daysSinceWarning int) *dw.DevWorkspace {
+	workspace := getDevWorkspace(name, namespace, false, daysOfInactivity)
+	workspace.Annotations[warnedAnnotation] = now.AddDate(0, 0, -daysSinceWarning).Format(time.RFC3339)
+	return workspace
+}
+
+func TestWarnInactiveAndStopLongRunningWorkspaces(t *testing.T) {
+	// Started long ago and stopped recently
+	recentlyStopped := getDevWorkspace("recently-stopped", "user1-che", false, 100)
+	recentlyStopped.Status.Conditions = []dw.DevWorkspaceCondition{
+		{Type: dw.DevWorkspaceReady, LastTransitionTime: metav1.NewTime(now.AddDate(0, 0, -1))},
+	}
+
+	cl, recorder, r := setup(
+		getCheCluster(&chev2.WorkspaceRetentionPolicy{
+			DaysOfInactivityBeforeWarning:  ptr.To(int32(30)),
+			DaysOfUptimeBeforeStop:         ptr.To(int32(45)),
+			DaysOfInactivityBeforeDeletion: ptr.To(int32(90)),
+		}),
+		getUserNamespace("user1-che", "user1", 365),
+		getDevWorkspace("active", "user1-che", false, 1),
+		getDevWorkspace("running", "user1-che", true, 35),
+		getDevWorkspace("long-running", "user1-che", true, 50),
+		recentlyStopped,
+		getDevWorkspace("inactive", "user1-che", false, 35),
+	)
+
+	stops := getRetentionActions(actionStop)
+
+	result, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: cheClusterKey})
+	assert.NoError(t, err)
+	assert.Equal(t, retentionCheckTime, result.RequeueAfter)
+
+	workspace := &dw.DevWorkspace{}
+	for _, name := range []string{"active", "recently-stopped", "running", "long-running"} {
+		assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: "user1-che"}, workspace), name)
+		assert.Empty(t, workspace.Annotations[warnedAnnotation], name)
+	}
+
+	assert.False(t, workspace.Spec.Started)
+	assert.Equal(t, stopReason, workspace.Annotations[dwconstants.DevWorkspaceStopReasonAnnotation])
+	assert.Equal(t, stops+1, getRetentionActions(actionStop))
+
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: "inactive", Namespace: "user1-che"}, workspace))
+	assert.Equal(t, now.Format(time.RFC3339), workspace.Annotations[warnedAnnotation])
+
+	// A running workspace is never warned about inactivity
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: "running", Namespace: "user1-che"}, workspace))
+	assert.True(t, workspace.Spec.Started)
+
+	// stop and warning
+	assert.Len(t, recorder.Events, 2)
+	events := <-recorder.Events + "\n" + <-recorder.Events
+	assert.Contains(t, events, "Workspace stopped after running for 50 days, the maximum uptime is 45 days")
+	assert.Contains(t, events, "Workspace has been inactive for 35 days.")
+
+	// The DevWorkspace Operator updates the conditions of the stopped workspace
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: "long-running", Namespace: "user1-che"}, workspace))
+	workspace.Status.Conditions = []dw.DevWorkspaceCondition{
+		{Type: dw.DevWorkspaceReady, LastTransitionTime: metav1.NewTime(now)},
+	}
+	assert.NoError(t, cl.Update(context.TODO(), workspace))
+
+	// Workspaces are warned once per inactivity period
+	_, err = r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: cheClusterKey})
+	assert.NoError(t, err)
+	assert.Len(t, recorder.Events, 0)
+}
+
+func TestDeleteWarnedWorkspaces(t *testing.T) {
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      dwconstants.DefaultWorkspacePVCName,
+			Namespace: "user1-che",
+		},
+	}
+
+	cl, _, r := setup(
+		getCheCluster(&chev2.WorkspaceRetentionPolicy{
+			DaysOfInactivityBeforeWarning:  ptr.To(int32(30)),
+			DaysOfInactivityBeforeDeletion: ptr.To(int32(90)),
+			VolumeSnapshotClassName:        "csi-snapclass",
+		}),
+		getUserNamespace("user1-che", "user1", 365),
+		pvc,
+		getDevWorkspace("never-warned", "user1-che", false, 100),
+		getWarnedDevWorkspace("recently-warned", "user1-che", 100, 10),
+		getWarnedDevWorkspace("warned", "user1-che", 100, 70),
+	)
+
+	deletions := getRetentionActions(actionDelete)
+
+	result, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: cheClusterKey})
+	assert.NoError(t, err)
+	assert.Equal(t, snapshotCheckPeriod, result.RequeueAfter)
+
+	// The workspace is deleted once the snapshot is ready to use
+	workspace := &dw.DevWorkspace{}
+	for _, name := range []string{"never-warned", "recently-warned", "warned"} {
+		assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: "user1-che"}, workspace), name)
+	}
+
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: "never-warned", Namespace: "user1-che"}, workspace))
+	assert.Equal(t, now.Format(time.RFC3339), workspace.Annotations[warnedAnnotation])
+
+	snapshot := volumesnapshot.NewEmpty()
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: "claim-devworkspace-20261016", Namespace: "user1-che"}, snapshot))
+	className, _, _ := unstructured.NestedString(snapshot.Object, "spec", "volumeSnapshotClassName")
+	assert.Equal(t, "csi-snapclass", className)
+	assert.Equal(t, constants.WorkspaceRetentionComponentName, snapshot.GetLabels()[constants.KubernetesComponentLabelKey])
+
+	snapshot.SetCreationTimestamp(metav1.NewTime(now))
+	assert.NoError(t, unstructured.SetNestedField(snapshot.Object, true, "status", "readyToUse"))
+	assert.NoError(t, cl.Update(context.TODO(), snapshot))
+
+	result, err = r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: cheClusterKey})
+	assert.NoError(t, err)
+	assert.Equal(t, retentionCheckTime, result.RequeueAfter)
+
+	err = cl.Get(context.TODO(), types.NamespacedName{Name: "warned", Namespace: "user1-che"}, workspace)
+	assert.True(t, errors.IsNotFound(err))
+	assert.Equal(t, deletions+1, getRetentionActions(actionDelete))
+
+	for _, name := range []string{"never-warned", "recently-warned"} {
+		assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: "user1-che"}, workspace), name)
+	}
+}
+
+func TestDeleteWarnedWorkspaceWithSameDaySnapshot(t *testing.T) {
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      dwconstants.DefaultWorkspacePVCName,
+			Namespace: "user1-che",
+		},
+	}
+
+	// Taken earlier today, but not recognized as taken since the warning
+	snapshot := volumesnapshot.New("claim-devworkspace-20261016", "user1-che", dwconstants.DefaultWorkspacePVCName, "csi-snapclass", retentionLabels)
+	snapshot.SetCreationTimestamp(metav1.NewTime(now.Add(-time.Hour)))
+	assert.NoError(t, unstructured.SetNestedField(snapshot.Object, true, "status", "readyToUse"))
+
+	cl, _, r := setup(
+		getCheCluster(&chev2.WorkspaceRetentionPolicy{
+			DaysOfInactivityBeforeWarning:  ptr.To(int32(30)),
+			DaysOfInactivityBeforeDeletion: ptr.To(int32(90)),
+			VolumeSnapshotClassName:        "csi-snapclass",
+		}),
+		getUserNamespace("user1-che", "user1", 365),
+		pvc,
+		snapshot,
+		getWarnedDevWorkspace("warned", "user1-che", 100, 70),
+	)
+
+	result, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: cheClusterKey})
+	assert.NoError(t, err)
+	assert.Equal(t, retentionCheckTime, result.RequeueAfter)
+
+	err = cl.Get(context.TODO(), types.NamespacedName{Name: "warned", Namespace: "user1-che"}, &dw.DevWorkspace{})
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestDeleteInactiveNamespaces(t *testing.T) {
+	getInactiveNamespace := func(name string, username string, daysOfInactivity int) *corev1.Namespace {
+		ns := getUserNamespace(name, username, 365)
+		ns.Annotations[lastActivityAnnotation] = now.AddDate(0, 0, -daysOfInactivity).Format(time.RFC3339)
+		return ns
+	}
+
+	retentionSnapshot := volumesnapshot.New("claim-devworkspace-20261006", "user6-che", dwconstants.DefaultWorkspacePVCName, "csi-snapclass", retentionLabels)
+	retentionSnapshot.SetCreationTimestamp(metav1.NewTime(now.AddDate(0, 0, -10)))
+
+	expiredSnapshot := volumesnapshot.New("claim-devworkspace-20260901", "user7-che", dwconstants.DefaultWorkspacePVCName, "csi-snapclass", retentionLabels)
+	expiredSnapshot.SetCreationTimestamp(metav1.NewTime(now.AddDate(0, 0, -45)))
+
+	cl, _, r := setup(
+		getCheCluster(&chev2.WorkspaceRetentionPolicy{
+			DaysOfInactivityBeforeWarning:  ptr.To(int32(30)),
+			DaysOfInactivityBeforeDeletion: ptr.To(int32(90)),
+			DeleteNamespaces:               ptr.To(true),
+		}),
+		getInactiveNamespace("user1-che", "user1", 100),
+		getInactiveNamespace("user2-che", "user2", 100),
+		getDevWorkspace("ws", "user2-che", false, 10),
+		getInactiveNamespace("custom", "user3", 100),
+		getInactiveNamespace("user4-che", "user4", 10),
+		getUserNamespace("user5-che", "user5", 365),
+		getInactiveNamespace("user6-che", "user6", 100),
+		retentionSnapshot,
+		getInactiveNamespace("user7-che", "user7", 100),
+		expiredSnapshot,
+	)
+
+	snapshotDeletions := getRetentionActions(actionDeleteSnapshot)
+
+	_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: cheClusterKey})
+	assert.NoError(t, err)
+
+	ns := &corev1.Namespace{}
+	err = cl.Get(context.TODO(), types.NamespacedName{Name: "user1-che"}, ns)
+	assert.True(t, errors.IsNotFound(err))
+
+	// Namespace with an active workspace
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: "user2-che"}, ns))
+	assert.Equal(t, now.AddDate(0, 0, -10).Format(time.RFC3339), ns.Annotations[lastActivityAnnotation])
+
+	// Namespace not created from the template
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: "custom"}, ns))
+
+	// Namespace with recently deleted workspaces
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: "user4-che"}, ns))
+
+	// Namespace provisioned in advance, that never had a workspace
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: "user5-che"}, ns))
+
+	// Namespace holding the snapshots taken before workspace deletion
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: "user6-che"}, ns))
+	assert.NoError(t, cl.Get(context.TODO(), client.ObjectKeyFromObject(retentionSnapshot), volumesnapshot.NewEmpty()))
+
+	// Namespace deleted along with its expired snapshots
+	err = cl.Get(context.TODO(), client.ObjectKeyFromObject(expiredSnapshot), volumesnapshot.NewEmpty())
+	assert.True(t, errors.IsNotFound(err))
+	assert.Equal(t, snapshotDeletions+1, getRetentionActions(actionDeleteSnapshot))
+	err = cl.Get(context.TODO(), types.NamespacedName{Name: "user7-che"}, ns)
+	assert.True(t, errors.IsNotFound(err))
+}
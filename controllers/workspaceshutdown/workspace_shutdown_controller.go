@@ -0,0 +1,315 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package workspaceshutdown
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	dw "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	dwconstants "github.com/devfile/devworkspace-operator/pkg/constants"
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/eclipse-che/che-operator/controllers/namespacecache"
+	"github.com/eclipse-che/che-operator/pkg/common/cron"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// ExemptAnnotation exempts a DevWorkspace or all DevWorkspaces in a namespace from the scheduled shutdown.
+	ExemptAnnotation = "che.eclipse.org/scheduled-shutdown-exempt"
+	// warnedForAnnotation keeps the start of the window the workspaces were last warned about,
+	// so that the warning events are emitted once per window.
+	warnedForAnnotation = "che.eclipse.org/scheduled-shutdown-warned-for"
+
+	stopReason                            = "scheduled-shutdown"
+	eventReason                           = "ScheduledShutdown"
+	defaultMinutesOfWarningBeforeShutdown = 30
+	// Workspaces started inside an active window are stopped on the next check
+	activeWindowCheckPeriod = time.Minute
+	// Limits merging of adjacent windows, which never ends when the windows cover all time
+	activeWindowLookahead = 7 * 24 * time.Hour
+	timeFormat            = "Mon 15:04 MST"
+)
+
+var (
+	logger = ctrl.Log.WithName("workspace-shutdown")
+)
+
+type WorkspaceShutdownReconciler struct {
+	client         client.Client
+	namespaceCache *namespacecache.NamespaceCache
+	recorder       record.EventRecorder
+	now            func() time.Time
+}
+
+var _ reconcile.Reconciler = (*WorkspaceShutdownReconciler)(nil)
+
+func NewWorkspaceShutdownReconciler(
+	client client.Client,
+	namespaceCache *namespacecache.NamespaceCache,
+	recorder record.EventRecorder) *WorkspaceShutdownReconciler {
+
+	return &WorkspaceShutdownReconciler{
+		client:         client,
+		namespaceCache: namespaceCache,
+		recorder:       recorder,
+		now:            time.Now,
+	}
+}
+
+func (r *WorkspaceShutdownReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Use controller.TypedOptions to allow to configure 2 controllers for same object being reconciled
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("workspace-shutdown").
+		For(&chev2.CheCluster{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		WithOptions(
+			controller.TypedOptions[reconcile.Request]{
+				SkipNameValidation: ptr.To(true),
+			}).
+		Complete(r)
+}
+
+func (r *WorkspaceShutdownReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	checluster := &chev2.CheCluster{}
+	if err := r.client.Get(ctx, req.NamespacedName, checluster); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	scheduledShutdown := checluster.Spec.DevEnvironments.ScheduledShutdown
+	if scheduledShutdown == nil || len(scheduledShutdown.Windows) == 0 {
+		return ctrl.Result{}, r.syncStatus(ctx, checluster, nil)
+	}
+
+	windows, loc, err := parseScheduledShutdown(scheduledShutdown)
+	if err != nil {
+		// Invalid configuration is rejected by the webhook, nothing to retry here
+		logger.Error(err, "Invalid scheduled shutdown configuration")
+		return ctrl.Result{}, nil
+	}
+
+	now := r.now().In(loc)
+	warning := time.Duration(ptr.Deref(scheduledShutdown.MinutesOfWarningBeforeShutdown, defaultMinutesOfWarningBeforeShutdown)) * time.Minute
+
+	status := &chev2.ScheduledShutdownStatus{}
+	requeueAfter := time.Duration(0)
+
+	if activeUntil := cron.ActiveUntil(windows, now, now.Add(activeWindowLookahead)); !activeUntil.IsZero() {
+		if err := r.stopWorkspaces(ctx, activeUntil); err != nil {
+			return ctrl.Result{}, err
+		}
+
+		status.ActiveUntil = &metav1.Time{Time: activeUntil}
+		status.Message = fmt.Sprintf("Workspaces are stopped until %s due to the scheduled shutdown.", activeUntil.Format(timeFormat))
+		requeueAfter = min(activeWindowCheckPeriod, activeUntil.Sub(now))
+	} else if nextStart := getNextStart(windows, now); !nextStart.IsZero() {
+		warnAt := nextStart.Add(-warning)
+		if warning > 0 && !now.Before(warnAt) {
+			if err := r.warnWorkspaces(ctx, checluster, nextStart); err != nil {
+				return ctrl.Result{}, err
+			}
+
+			status.Message = fmt.Sprintf("Workspaces will be stopped at %s due to the scheduled shutdown.", nextStart.Format(timeFormat))
+			requeueAfter = nextStart.Sub(now)
+		} else {
+			requeueAfter = warnAt.Sub(now)
+		}
+	}
+
+	if status.Message == "" {
+		status = nil
+	}
+
+	if err := r.syncStatus(ctx, checluster, status); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// parseScheduledShutdown parses the windows schedule and the time zone.
+func parseScheduledShutdown(scheduledShutdown *chev2.WorkspaceScheduledShutdown) ([]cron.Window, *time.Location, error) {
+	loc := time.UTC
+	if scheduledShutdown.TimeZone != "" {
+		var err error
+		if loc, err = time.LoadLocation(scheduledShutdown.TimeZone); err != nil {
+			return nil, nil, fmt.Errorf("invalid time zone '%s': %w", scheduledShutdown.TimeZone, err)
+		}
+	}
+
+	windows := make([]cron.Window, 0, len(scheduledShutdown.Windows))
+	for _, w := range scheduledShutdown.Windows {
+		schedule, err := cron.Parse(w.Schedule)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid schedule '%s': %w", w.Schedule, err)
+		}
+
+		if w.Duration.Duration <= 0 {
+			return nil, nil, fmt.Errorf("window duration must be positive, found '%s'", w.Duration.Duration)
+		}
+
+		windows = append(windows, cron.Window{Schedule: schedule, Duration: w.Duration.Duration})
+	}
+
+	return windows, loc, nil
+}
+
+// getNextStart returns the start of the closest upcoming window.
+func getNextStart(windows []cron.Window, now time.Time) time.Time {
+	nextStart := time.Time{}
+
+	for _, w := range windows {
+		if start := w.Schedule.Next(now); !start.IsZero() && (nextStart.IsZero() || start.Before(nextStart)) {
+			nextStart = start
+		}
+	}
+
+	return nextStart
+}
+
+// stopWorkspaces stops all running workspaces in the user namespaces except the exempted ones.
+func (r *WorkspaceShutdownReconciler) stopWorkspaces(ctx context.Context, activeUntil time.Time) error {
+	workspaces, err := r.getRunningWorkspaces(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, workspace := range workspaces {
+		patch := client.MergeFrom(workspace.DeepCopy())
+		workspace.Spec.Started = false
+		if workspace.Annotations == nil {
+			workspace.Annotations = map[string]string{}
+		}
+		workspace.Annotations[dwconstants.DevWorkspaceStopReasonAnnotation] = stopReason
+
+		if err := r.client.Patch(ctx, workspace, patch); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+
+		r.recorder.Eventf(workspace, corev1.EventTypeWarning, eventReason,
+			"Workspace stopped by the scheduled shutdown until %s", activeUntil.Format(timeFormat))
+		logger.Info("Workspace stopped by the scheduled shutdown", "namespace", workspace.Namespace, "name", workspace.Name)
+	}
+
+	return nil
+}
+
+// warnWorkspaces emits a warning event on every running workspace that is stopped when the upcoming window starts.
+// The workspaces are warned once per window.
+func (r *WorkspaceShutdownReconciler) warnWorkspaces(ctx context.Context, checluster *chev2.CheCluster, nextStart time.Time) error {
+	warnedFor := nextStart.UTC().Format(time.RFC3339)
+	if checluster.GetAnnotations()[warnedForAnnotation] == warnedFor {
+		return nil
+	}
+
+	workspaces, err := r.getRunningWorkspaces(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, workspace := range workspaces {
+		r.recorder.Eventf(workspace, corev1.EventTypeWarning, eventReason,
+			"Workspace will be stopped at %s by the scheduled shutdown", nextStart.Format(timeFormat))
+	}
+
+	patch := client.MergeFrom(checluster.DeepCopy())
+	if checluster.Annotations == nil {
+		checluster.Annotations = map[string]string{}
+	}
+	checluster.Annotations[warnedForAnnotation] = warnedFor
+	return r.client.Patch(ctx, checluster, patch)
+}
+
+// getRunningWorkspaces returns the running workspaces in the user namespaces except the exempted ones.
+func (r *WorkspaceShutdownReconciler) getRunningWorkspaces(ctx context.Context) ([]*dw.DevWorkspace, error) {
+	workspaces := &dw.DevWorkspaceList{}
+	if err := r.client.List(ctx, workspaces); err != nil {
+		return nil, err
+	}
+
+	var running []*dw.DevWorkspace
+	exemptNamespaces := map[string]bool{}
+	for i := range workspaces.Items {
+		workspace := &workspaces.Items[i]
+		if !workspace.Spec.Started || workspace.GetAnnotations()[ExemptAnnotation] == "true" {
+			continue
+		}
+
+		exempt, found := exemptNamespaces[workspace.Namespace]
+		if !found {
+			var err error
+			if exempt, err = r.isNamespaceExempt(ctx, workspace.Namespace); err != nil {
+				return nil, err
+			}
+			exemptNamespaces[workspace.Namespace] = exempt
+		}
+
+		if !exempt {
+			running = append(running, workspace)
+		}
+	}
+
+	return running, nil
+}
+
+// isNamespaceExempt returns true if the namespace is not a user namespace
+// or is annotated to be exempted from the scheduled shutdown.
+func (r *WorkspaceShutdownReconciler) isNamespaceExempt(ctx context.Context, namespace string) (bool, error) {
+	info, err := r.namespaceCache.GetNamespaceInfo(ctx, namespace)
+	if err != nil {
+		return false, err
+	}
+
+	if info == nil || !info.IsWorkspaceNamespace {
+		return true, nil
+	}
+
+	ns := &corev1.Namespace{}
+	if err := r.client.Get(ctx, client.ObjectKey{Name: namespace}, ns); err != nil {
+		if errors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	}
+
+	return ns.GetAnnotations()[ExemptAnnotation] == "true", nil
+}
+
+// syncStatus publishes the current or upcoming shutdown window in the CheCluster status, which the dashboard shows to users.
+// The status is owned by the Operator, the dashboard header message set by the administrator is left intact.
+func (r *WorkspaceShutdownReconciler) syncStatus(ctx context.Context, checluster *chev2.CheCluster, status *chev2.ScheduledShutdownStatus) error {
+	if equality.Semantic.DeepEqual(checluster.Status.ScheduledShutdown, status) {
+		return nil
+	}
+
+	patch := client.MergeFrom(checluster.DeepCopy())
+	checluster.Status.ScheduledShutdown = status
+	return r.client.Status().Patch(ctx, checluster, patch)
+}
@@ -0,0 +1,244 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package workspaceshutdown
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dw "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	dwconstants "github.com/devfile/devworkspace-operator/pkg/constants"
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/eclipse-che/che-operator/controllers/namespacecache"
+	"github.com/eclipse-che/che-operator/pkg/common/constants"
+	"github.com/eclipse-che/che-operator/pkg/common/test"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var cheClusterKey = types.NamespacedName{Name: "eclipse-che", Namespace: "eclipse-che"}
+
+func setup(now string, objs ...client.Object) (client.Client, *record.FakeRecorder, *WorkspaceShutdownReconciler) {
+	ctx := test.NewCtxBuilder().WithObjects(objs...).WithCheCluster(nil).Build()
+	cl := ctx.ClusterAPI.Client
+
+	recorder := record.NewFakeRecorder(10)
+	r := NewWorkspaceShutdownReconciler(cl, namespacecache.NewNamespaceCache(cl), recorder)
+	r.now = func() time.Time {
+		t, _ := time.Parse(time.RFC3339, now)
+		return t
+	}
+
+	return cl, recorder, r
+}
+
+func getCheCluster(headerMessage *chev2.DashboardHeaderMessage, status *chev2.ScheduledShutdownStatus) *chev2.CheCluster {
+	return &chev2.CheCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cheClusterKey.Name,
+			Namespace: cheClusterKey.Namespace,
+		},
+		Spec: chev2.CheClusterSpec{
+			DevEnvironments: chev2.CheClusterDevEnvironments{
+				ScheduledShutdown: &chev2.WorkspaceScheduledShutdown{
+					Windows: []chev2.WorkspaceShutdownWindow{
+						{Schedule: "0 20 * * 1-5", Duration: metav1.Duration{Duration: 12 * time.Hour}},
+						{Schedule: "0 0 * * 6", Duration: metav1.Duration{Duration: 48 * time.Hour}},
+					},
+				},
+			},
+			Components: chev2.CheClusterComponents{
+				Dashboard: chev2.Dashboard{
+					HeaderMessage: headerMessage,
+				},
+			},
+		},
+		Status: chev2.CheClusterStatus{
+			ScheduledShutdown: status,
+		},
+	}
+}
+
+func getNamespace(name string, isUserNamespace bool, annotations map[string]string) *corev1.Namespace {
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Annotations: annotations,
+		},
+	}
+
+	if isUserNamespace {
+		ns.Labels = map[string]string{
+			constants.KubernetesPartOfLabelKey:    constants.CheEclipseOrg,
+			constants.KubernetesComponentLabelKey: constants.WorkspacesNamespaceComponentName,
+		}
+	}
+
+	return ns
+}
+
+func getDevWorkspace(name string, namespace string, annotations map[string]string) *dw.DevWorkspace {
+	return &dw.DevWorkspace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   namespace,
+			Annotations: annotations,
+		},
+		Spec: dw.DevWorkspaceSpec{
+			Started: true,
+		},
+	}
+}
+
+func TestStopWorkspacesInsideWindow(t *testing.T) {
+	exempt := map[string]string{ExemptAnnotation: "true"}
+
+	cl, recorder, r := setup(
+		"2026-10-16T20:30:00Z", // Friday
+		getCheCluster(nil, nil),
+		getNamespace("user1-che", true, nil),
+		getNamespace("user2-che", true, exempt),
+		getNamespace("other", false, nil),
+		getDevWorkspace("ws1", "user1-che", nil),
+		getDevWorkspace("ws2", "user1-che", exempt),
+		getDevWorkspace("ws3", "user2-che", nil),
+		getDevWorkspace("ws4", "other", nil),
+	)
+
+	result, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: cheClusterKey})
+	assert.NoError(t, err)
+	assert.Equal(t, time.Minute, result.RequeueAfter)
+
+	workspace := &dw.DevWorkspace{}
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: "ws1", Namespace: "user1-che"}, workspace))
+	assert.False(t, workspace.Spec.Started)
+	assert.Equal(t, stopReason, workspace.Annotations[dwconstants.DevWorkspaceStopReasonAnnotation])
+
+	for _, key := range []types.NamespacedName{
+		{Name: "ws2", Namespace: "user1-che"},
+		{Name: "ws3", Namespace: "user2-che"},
+		{Name: "ws4", Namespace: "other"},
+	} {
+		assert.NoError(t, cl.Get(context.TODO(), key, workspace))
+		assert.True(t, workspace.Spec.Started, key.String())
+	}
+
+	assert.Len(t, recorder.Events, 1)
+	assert.Contains(t, <-recorder.Events, eventReason)
+
+	// The weekend window starts before the weekday one ends
+	checluster := &chev2.CheCluster{}
+	assert.NoError(t, cl.Get(context.TODO(), cheClusterKey, checluster))
+	assert.Equal(t, "Workspaces are stopped until Mon 00:00 UTC due to the scheduled shutdown.", checluster.Status.ScheduledShutdown.Message)
+	assert.Equal(t, "2026-10-19T00:00:00Z", checluster.Status.ScheduledShutdown.ActiveUntil.UTC().Format(time.RFC3339))
+	assert.Nil(t, checluster.Spec.Components.Dashboard.HeaderMessage)
+}
+
+func TestWarnBeforeWindow(t *testing.T) {
+	cl, recorder, r := setup(
+		"2026-10-16T19:45:00Z",
+		getCheCluster(nil, nil),
+		getNamespace("user1-che", true, nil),
+		getNamespace("user2-che", true, map[string]string{ExemptAnnotation: "true"}),
+		getDevWorkspace("ws1", "user1-che", nil),
+		getDevWorkspace("ws2", "user2-che", nil),
+	)
+
+	result, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: cheClusterKey})
+	assert.NoError(t, err)
+	assert.Equal(t, 15*time.Minute, result.RequeueAfter)
+
+	workspace := &dw.DevWorkspace{}
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: "ws1", Namespace: "user1-che"}, workspace))
+	assert.True(t, workspace.Spec.Started)
+
+	checluster := &chev2.CheCluster{}
+	assert.NoError(t, cl.Get(context.TODO(), cheClusterKey, checluster))
+	assert.Equal(t, "Workspaces will be stopped at Fri 20:00 UTC due to the scheduled shutdown.", checluster.Status.ScheduledShutdown.Message)
+	assert.Nil(t, checluster.Status.ScheduledShutdown.ActiveUntil)
+
+	assert.Len(t, recorder.Events, 1)
+	event := <-recorder.Events
+	assert.Contains(t, event, corev1.EventTypeWarning)
+	assert.Contains(t, event, "Workspace will be stopped at Fri 20:00 UTC")
+
+	// The workspaces are warned once per window
+	_, err = r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: cheClusterKey})
+	assert.NoError(t, err)
+	assert.Len(t, recorder.Events, 0)
+}
+
+func TestRemoveStatusOutsideWindow(t *testing.T) {
+	cl, _, r := setup(
+		"2026-10-19T12:00:00Z",
+		getCheCluster(nil, &chev2.ScheduledShutdownStatus{Message: "Workspaces are stopped until Mon 00:00 UTC due to the scheduled shutdown."}),
+	)
+
+	result, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: cheClusterKey})
+	assert.NoError(t, err)
+	assert.Equal(t, 7*time.Hour+30*time.Minute, result.RequeueAfter)
+
+	checluster := &chev2.CheCluster{}
+	assert.NoError(t, cl.Get(context.TODO(), cheClusterKey, checluster))
+	assert.Nil(t, checluster.Status.ScheduledShutdown)
+}
+
+func TestKeepAdministratorHeaderMessage(t *testing.T) {
+	headerMessage := &chev2.DashboardHeaderMessage{Show: true, Text: "Maintenance on Sunday"}
+	cl, _, r := setup(
+		"2026-10-16T20:30:00Z",
+		getCheCluster(headerMessage, nil),
+	)
+
+	_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: cheClusterKey})
+	assert.NoError(t, err)
+
+	checluster := &chev2.CheCluster{}
+	assert.NoError(t, cl.Get(context.TODO(), cheClusterKey, checluster))
+	assert.Equal(t, headerMessage, checluster.Spec.Components.Dashboard.HeaderMessage)
+	assert.NotNil(t, checluster.Status.ScheduledShutdown)
+
+	// The administrator message is not removed outside the window either
+	r.now = func() time.Time {
+		t, _ := time.Parse(time.RFC3339, "2026-10-19T12:00:00Z")
+		return t
+	}
+	_, err = r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: cheClusterKey})
+	assert.NoError(t, err)
+
+	assert.NoError(t, cl.Get(context.TODO(), cheClusterKey, checluster))
+	assert.Equal(t, headerMessage, checluster.Spec.Components.Dashboard.HeaderMessage)
+	assert.Nil(t, checluster.Status.ScheduledShutdown)
+}
+
+func TestWindowsCoveringAllTime(t *testing.T) {
+	checluster := getCheCluster(nil, nil)
+	checluster.Spec.DevEnvironments.ScheduledShutdown.Windows = []chev2.WorkspaceShutdownWindow{
+		{Schedule: "0 0 * * *", Duration: metav1.Duration{Duration: 24 * time.Hour}},
+	}
+
+	cl, _, r := setup("2026-10-16T12:00:00Z", checluster)
+
+	result, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: cheClusterKey})
+	assert.NoError(t, err)
+	assert.Equal(t, time.Minute, result.RequeueAfter)
+
+	assert.NoError(t, cl.Get(context.TODO(), cheClusterKey, checluster))
+	assert.Equal(t, "Workspaces are stopped until Fri 12:00 UTC due to the scheduled shutdown.", checluster.Status.ScheduledShutdown.Message)
+}
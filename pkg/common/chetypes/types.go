@@ -43,10 +43,12 @@ type ClusterAPI struct {
 }
 
 type Authentication struct {
-	GroupsClaim    string
-	GroupsPrefix   string
-	UsernameClaim  string
-	UsernamePrefix string
+	GroupsClaim        string
+	GroupsExpression   string
+	GroupsPrefix       string
+	UsernameClaim      string
+	UsernameExpression string
+	UsernamePrefix     string
 
 	IssuerURL string
 	IssuerCA  string
@@ -52,6 +52,7 @@ const (
 	// OpenVSXRegistry Server extensions
 	OpenVSXServerExtensionPublishJobName = "openvsx-server-publisher"
 	OpenVSXServerExtensionsConfigMapName = "openvsx-server-extensions"
+	OpenVSXServerExtensionMirrorJobName  = "openvsx-server-mirror"
 
 	// OpenVSXRegistry Database
 	OpenVSXDatabaseComponentName    = "openvsx-database"
@@ -63,6 +64,11 @@ const (
 	OpenVSXDatabaseClaimSize        = "1Gi"
 	OpenVSXDatabaseServicePort      = int32(5432)
 
+	// OpenVSXRegistry Backup
+	OpenVSXBackupComponentName = "openvsx-backup"
+	OpenVSXRestoreJobName      = "openvsx-restore"
+	OpenVSXBackupClaimSize     = "5Gi"
+
 	// Server
 	DefaultServerMemoryLimit        = "1024Mi"
 	DefaultServerMemoryRequest      = "512Mi"
@@ -121,7 +127,11 @@ const (
 	KubernetesManagedByLabelKey        = "app.kubernetes.io/managed-by"
 	KubernetesInstanceLabelKey         = "app.kubernetes.io/instance"
 	KubernetesNameLabelKey             = "app.kubernetes.io/name"
+	KubernetesVersionLabelKey          = "app.kubernetes.io/version"
 	WorkspaceNamespaceOwnerUidLabelKey = "che.eclipse.org/workspace-namespace-owner-uid"
+	OLMOwnerLabelKey                   = "olm.owner"
+	OLMOwnerKindLabelKey               = "olm.owner.kind"
+	OLMOwnerNamespaceLabelKey          = "olm.owner.namespace"
 
 	// Annotations
 	CheEclipseOrgMountPath                          = "che.eclipse.org/mount-path"
@@ -135,6 +145,12 @@ const (
 	OpenShiftIOOwningComponent                      = "openshift.io/owning-component"
 	ConfigOpenShiftIOInjectTrustedCaBundle          = "config.openshift.io/inject-trusted-cabundle"
 	CheEclipseOrgUsername                           = "che.eclipse.org/username"
+	CheEclipseOrgGroups                             = "che.eclipse.org/groups"
+	CheEclipseOrgHiddenEditors                      = "che.eclipse.org/hidden-editors"
+	CheEclipseOrgReplicasBeforeRestore              = "che.eclipse.org/replicas-before-restore"
+	CheEclipseOrgRetryUpgrade                       = "che.eclipse.org/retry-upgrade"
+	CheEclipseOrgManagedLabels                      = "che.eclipse.org/managed-labels"
+	CheEclipseOrgManagedAnnotations                 = "che.eclipse.org/managed-annotations"
 
 	// DevEnvironments
 	PerUserPVCStorageStrategy           = "per-user"
@@ -152,17 +168,21 @@ const (
 	DefaultIngressClass                    = "nginx"
 
 	// components name
-	DevfileRegistryName                = "devfile-registry"
-	PluginRegistryName                 = "plugin-registry"
-	GatewayContainerName               = "gateway"
-	GatewayConfigSideCarContainerName  = "configbump"
-	GatewayAuthenticationContainerName = "oauth-proxy"
-	GatewayAuthorizationContainerName  = "kube-rbac-proxy"
-	KubernetesImagePullerComponentName = "kubernetes-image-puller"
-	EditorDefinitionComponentName      = "editor-definition"
-	CheCABundle                        = "ca-bundle"
-	MetricsComponentName               = "metrics"
-	WorkspacesNamespaceComponentName   = "workspaces-namespace"
+	DevfileRegistryName                 = "devfile-registry"
+	PluginRegistryName                  = "plugin-registry"
+	GatewayContainerName                = "gateway"
+	GatewayConfigSideCarContainerName   = "configbump"
+	GatewayAuthenticationContainerName  = "oauth-proxy"
+	GatewayAuthorizationContainerName   = "kube-rbac-proxy"
+	KubernetesImagePullerComponentName  = "kubernetes-image-puller"
+	BuiltInImagePullerComponentName     = "image-puller"
+	EditorDefinitionComponentName       = "editor-definition"
+	CustomEditorDefinitionComponentName = "custom-editor-definition"
+	CheCABundle                         = "ca-bundle"
+	MetricsComponentName                = "metrics"
+	WorkspacesNamespaceComponentName    = "workspaces-namespace"
+	WorkspaceBackupComponentName        = "workspace-backup"
+	WorkspaceRetentionComponentName     = "workspace-retention"
 
 	// common
 	CheFlavor             = "che"
@@ -180,6 +200,12 @@ const (
 	DefaultContainerRunSccName             = "container-run"
 	DefaultDisableContainerRunCapabilities = true
 
+	// Pod Security Admission
+	PodSecurityEnforceLabelKey            = "pod-security.kubernetes.io/enforce"
+	DefaultPodSecurityLevel               = "restricted"
+	DefaultContainerBuildPodSecurityLevel = "baseline"
+	DefaultContainerRunPodSecurityLevel   = "privileged"
+
 	// Networking
 	NetworkPolicyEnabled = false
 
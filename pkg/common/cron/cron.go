@@ -0,0 +1,189 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule is a parsed cron expression in the standard five fields format:
+// `minute hour day-of-month month day-of-week`.
+// Each field supports `*`, single values, ranges `a-b`, steps `*/n` or `a-b/n` and comma separated lists.
+type Schedule struct {
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+
+	domRestricted bool
+	dowRestricted bool
+}
+
+type bounds struct {
+	min, max int
+}
+
+var (
+	minuteBounds = bounds{0, 59}
+	hourBounds   = bounds{0, 23}
+	domBounds    = bounds{1, 31}
+	monthBounds  = bounds{1, 12}
+	// 7 is an alias for Sunday
+	dowBounds = bounds{0, 7}
+)
+
+// Parse parses a cron expression.
+func Parse(spec string) (*Schedule, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields in cron expression '%s', found %d", spec, len(fields))
+	}
+
+	var err error
+	s := &Schedule{
+		domRestricted: fields[2] != "*",
+		dowRestricted: fields[4] != "*",
+	}
+
+	if s.minute, err = parseField(fields[0], minuteBounds); err != nil {
+		return nil, err
+	}
+	if s.hour, err = parseField(fields[1], hourBounds); err != nil {
+		return nil, err
+	}
+	if s.dom, err = parseField(fields[2], domBounds); err != nil {
+		return nil, err
+	}
+	if s.month, err = parseField(fields[3], monthBounds); err != nil {
+		return nil, err
+	}
+	if s.dow, err = parseField(fields[4], dowBounds); err != nil {
+		return nil, err
+	}
+
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+
+	return s, nil
+}
+
+// Next returns the first activation time strictly after the given time,
+// or zero time if there is no activation within the next five years.
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
+
+// dayMatches follows the cron convention: when both day-of-month and day-of-week
+// are restricted, the day matches if any of them matches.
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatches := s.dom&(1<<uint(t.Day())) != 0
+	dowMatches := s.dow&(1<<uint(t.Weekday())) != 0
+
+	if s.domRestricted && s.dowRestricted {
+		return domMatches || dowMatches
+	}
+
+	return domMatches && dowMatches
+}
+
+func parseField(field string, b bounds) (uint64, error) {
+	var bits uint64
+
+	for _, part := range strings.Split(field, ",") {
+		rangeAndStep := strings.SplitN(part, "/", 2)
+
+		start, end := b.min, b.max
+		if rangeAndStep[0] != "*" {
+			startAndEnd := strings.SplitN(rangeAndStep[0], "-", 2)
+
+			var err error
+			if start, err = parseValue(startAndEnd[0], b); err != nil {
+				return 0, err
+			}
+
+			end = start
+			if len(startAndEnd) == 2 {
+				if end, err = parseValue(startAndEnd[1], b); err != nil {
+					return 0, err
+				}
+			} else if len(rangeAndStep) == 2 {
+				// `n/step` means from `n` to the maximum
+				end = b.max
+			}
+
+			if start > end {
+				return 0, fmt.Errorf("invalid range '%s'", rangeAndStep[0])
+			}
+		}
+
+		step := 1
+		if len(rangeAndStep) == 2 {
+			var err error
+			if step, err = strconv.Atoi(rangeAndStep[1]); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step '%s'", rangeAndStep[1])
+			}
+		}
+
+		for i := start; i <= end; i += step {
+			bits |= 1 << uint(i)
+		}
+	}
+
+	return bits, nil
+}
+
+func parseValue(value string, b bounds) (int, error) {
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value '%s'", value)
+	}
+
+	if i < b.min || i > b.max {
+		return 0, fmt.Errorf("value '%d' is out of range [%d, %d]", i, b.min, b.max)
+	}
+
+	return i, nil
+}
@@ -0,0 +1,171 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseInvalid(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+	} {
+		_, err := Parse(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestNext(t *testing.T) {
+	type testCase struct {
+		spec     string
+		from     string
+		expected string
+	}
+
+	testCases := []testCase{
+		{
+			spec:     "0 20 * * 1-5",
+			from:     "2026-10-16T19:59:00Z", // Friday
+			expected: "2026-10-16T20:00:00Z",
+		},
+		{
+			spec:     "0 20 * * 1-5",
+			from:     "2026-10-16T20:00:00Z",
+			expected: "2026-10-19T20:00:00Z",
+		},
+		{
+			spec:     "0 0 * * 6",
+			from:     "2026-10-12T10:00:00Z",
+			expected: "2026-10-17T00:00:00Z",
+		},
+		{
+			spec:     "*/15 9-10 * * *",
+			from:     "2026-10-12T10:50:00Z",
+			expected: "2026-10-13T09:00:00Z",
+		},
+		{
+			spec:     "0 0 1 1 *",
+			from:     "2026-10-12T10:00:00Z",
+			expected: "2027-01-01T00:00:00Z",
+		},
+		{
+			spec:     "0 0 13 * 5",
+			from:     "2026-10-10T10:00:00Z", // Saturday, next Friday is the 16th
+			expected: "2026-10-13T00:00:00Z",
+		},
+		{
+			spec:     "30 8 * * 7",
+			from:     "2026-10-12T10:00:00Z",
+			expected: "2026-10-18T08:30:00Z",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.spec, func(t *testing.T) {
+			s, err := Parse(testCase.spec)
+			assert.NoError(t, err)
+
+			from, _ := time.Parse(time.RFC3339, testCase.from)
+			expected, _ := time.Parse(time.RFC3339, testCase.expected)
+			assert.Equal(t, expected, s.Next(from))
+		})
+	}
+}
+
+func TestActiveUntil(t *testing.T) {
+	type testCase struct {
+		name     string
+		windows  map[string]time.Duration
+		at       string
+		expected string
+	}
+
+	testCases := []testCase{
+		{
+			name:     "Inside window",
+			windows:  map[string]time.Duration{"0 20 * * *": 12 * time.Hour},
+			at:       "2026-10-16T22:00:00Z",
+			expected: "2026-10-17T08:00:00Z",
+		},
+		{
+			name:     "Outside window",
+			windows:  map[string]time.Duration{"0 20 * * *": 12 * time.Hour},
+			at:       "2026-10-16T12:00:00Z",
+			expected: "",
+		},
+		{
+			name:     "Adjacent windows are merged",
+			windows:  map[string]time.Duration{"0 20 * * 1-5": 12 * time.Hour, "0 0 * * 6": 48 * time.Hour},
+			at:       "2026-10-16T20:30:00Z",
+			expected: "2026-10-19T00:00:00Z",
+		},
+		{
+			name:     "Windows covering all time are limited",
+			windows:  map[string]time.Duration{"0 0 * * *": 24 * time.Hour},
+			at:       "2026-10-16T12:00:00Z",
+			expected: "2026-10-23T12:00:00Z",
+		},
+		{
+			name:     "Every minute windows covering all time are limited",
+			windows:  map[string]time.Duration{"* * * * *": time.Minute},
+			at:       "2026-10-16T12:00:00Z",
+			expected: "2026-10-23T12:00:00Z",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			windows := []Window{}
+			for spec, duration := range testCase.windows {
+				s, err := Parse(spec)
+				assert.NoError(t, err)
+				windows = append(windows, Window{Schedule: s, Duration: duration})
+			}
+
+			at, _ := time.Parse(time.RFC3339, testCase.at)
+			activeUntil := ActiveUntil(windows, at, at.Add(7*24*time.Hour))
+
+			if testCase.expected == "" {
+				assert.True(t, activeUntil.IsZero())
+			} else {
+				assert.Equal(t, testCase.expected, activeUntil.Format(time.RFC3339))
+			}
+		})
+	}
+}
+
+func TestIsAlwaysActive(t *testing.T) {
+	at, _ := time.Parse(time.RFC3339, "2026-10-16T12:00:00Z")
+
+	daily, _ := Parse("0 0 * * *")
+	assert.True(t, IsAlwaysActive([]Window{{Schedule: daily, Duration: 24 * time.Hour}}, at))
+	assert.False(t, IsAlwaysActive([]Window{{Schedule: daily, Duration: 23 * time.Hour}}, at))
+
+	everyMinute, _ := Parse("* * * * *")
+	assert.True(t, IsAlwaysActive([]Window{{Schedule: everyMinute, Duration: time.Minute}}, at))
+
+	newYear, _ := Parse("0 0 1 1 *")
+	assert.False(t, IsAlwaysActive([]Window{{Schedule: newYear, Duration: 10 * 24 * time.Hour}}, at))
+}
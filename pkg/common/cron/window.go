@@ -0,0 +1,67 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package cron
+
+import (
+	"time"
+)
+
+// Window is a recurring time window which starts at the schedule activations and lasts for the duration.
+type Window struct {
+	Schedule *Schedule
+	Duration time.Duration
+}
+
+// ActiveUntil returns the end of the windows active at the given time, or zero time if no window is active.
+// Overlapping and adjacent windows are merged up to the limit, which bounds the result
+// when the windows cover all time.
+func ActiveUntil(windows []Window, t time.Time, limit time.Time) time.Time {
+	activeUntil := t
+
+	for extended := true; extended && activeUntil.Before(limit); {
+		extended = false
+		for _, w := range windows {
+			for start := w.Schedule.Next(t.Add(-w.Duration)); !start.IsZero() && !start.After(activeUntil) && activeUntil.Before(limit); start = w.Schedule.Next(start) {
+				if end := start.Add(w.Duration); end.After(activeUntil) {
+					activeUntil = end
+					extended = true
+				}
+			}
+		}
+	}
+
+	if activeUntil.After(limit) {
+		activeUntil = limit
+	}
+
+	if activeUntil.After(t) {
+		return activeUntil
+	}
+
+	return time.Time{}
+}
+
+// IsAlwaysActive returns true if the windows leave no gap within a year after the given time.
+func IsAlwaysActive(windows []Window, t time.Time) bool {
+	limit := t.AddDate(1, 0, 0)
+
+	for t.Before(limit) {
+		activeUntil := ActiveUntil(windows, t, limit)
+		if activeUntil.IsZero() {
+			return false
+		}
+		t = activeUntil
+	}
+
+	return true
+}
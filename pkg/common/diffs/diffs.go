@@ -21,9 +21,11 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	securityv1 "github.com/openshift/api/security/v1"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	networking "k8s.io/api/networking/v1"
 	rbac "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -66,6 +68,17 @@ var KubernetesImagePullerDiffOpts = cmp.Options{
 	cmpopts.IgnoreFields(chev1alpha1.KubernetesImagePullerSpec{}, "ImagePullerImage"),
 }
 
+var DaemonSet = cmp.Options{
+	cmpopts.IgnoreFields(appsv1.DaemonSet{}, "TypeMeta", "ObjectMeta", "Status"),
+	cmpopts.IgnoreFields(appsv1.DaemonSetSpec{}, "RevisionHistoryLimit"),
+	cmpopts.IgnoreFields(appsv1.RollingUpdateDaemonSet{}, "MaxSurge"),
+	cmpopts.IgnoreFields(corev1.PodSpec{}, "DNSPolicy", "SchedulerName", "DeprecatedServiceAccount", "RestartPolicy", "SecurityContext"),
+	cmpopts.IgnoreFields(corev1.Container{}, "TerminationMessagePath", "TerminationMessagePolicy", "ImagePullPolicy"),
+	cmp.Comparer(func(x, y resource.Quantity) bool {
+		return x.Cmp(y) == 0
+	}),
+}
+
 func Ingress(labels []string, annotations []string) cmp.Options {
 	return cmp.Options{
 		cmpopts.IgnoreFields(networking.Ingress{}, "TypeMeta", "Status"),
@@ -85,6 +98,14 @@ var NetworkPolicy = cmp.Options{
 	cmpopts.IgnoreFields(networking.NetworkPolicy{}, "TypeMeta", "ObjectMeta"),
 }
 
+var ResourceQuota = cmp.Options{
+	cmpopts.IgnoreFields(corev1.ResourceQuota{}, "TypeMeta", "ObjectMeta", "Status"),
+}
+
+var LimitRange = cmp.Options{
+	cmpopts.IgnoreFields(corev1.LimitRange{}, "TypeMeta", "ObjectMeta"),
+}
+
 var ServiceMonitor = cmp.Options{
 	cmpopts.IgnoreFields(monitoringv1.ServiceMonitor{}, "TypeMeta", "ObjectMeta"),
 }
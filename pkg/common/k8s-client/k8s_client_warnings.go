@@ -0,0 +1,67 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package k8s_client
+
+import (
+	"context"
+	"sync"
+
+	"k8s.io/client-go/rest"
+)
+
+type warningsCollectorKey struct{}
+
+type warningsCollector struct {
+	mu       sync.Mutex
+	warnings []string
+}
+
+// WarningHandler passes the warnings returned by the API server to the collector
+// of the request context, see CollectWarnings, and to the delegate otherwise.
+type WarningHandler struct {
+	delegate rest.WarningHandlerWithContext
+}
+
+var _ rest.WarningHandlerWithContext = (*WarningHandler)(nil)
+
+func NewWarningHandler(delegate rest.WarningHandlerWithContext) *WarningHandler {
+	return &WarningHandler{delegate: delegate}
+}
+
+func (h *WarningHandler) HandleWarningHeaderWithContext(ctx context.Context, code int, agent string, message string) {
+	// 299 is the only code of the warnings returned by the API server
+	if collector, ok := ctx.Value(warningsCollectorKey{}).(*warningsCollector); ok && code == 299 && message != "" {
+		collector.mu.Lock()
+		defer collector.mu.Unlock()
+
+		collector.warnings = append(collector.warnings, message)
+		return
+	}
+
+	if h.delegate != nil {
+		h.delegate.HandleWarningHeaderWithContext(ctx, code, agent, message)
+	}
+}
+
+// CollectWarnings returns the context which collects the warnings returned by the API server
+// for the requests made with it, and the function returning the collected warnings.
+// The client config must use the WarningHandler.
+func CollectWarnings(ctx context.Context) (context.Context, func() []string) {
+	collector := &warningsCollector{}
+	return context.WithValue(ctx, warningsCollectorKey{}, collector), func() []string {
+		collector.mu.Lock()
+		defer collector.mu.Unlock()
+
+		return append([]string{}, collector.warnings...)
+	}
+}
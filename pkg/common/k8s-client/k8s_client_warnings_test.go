@@ -0,0 +1,41 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package k8s_client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingWarningHandler struct {
+	messages []string
+}
+
+func (h *recordingWarningHandler) HandleWarningHeaderWithContext(_ context.Context, _ int, _ string, message string) {
+	h.messages = append(h.messages, message)
+}
+
+func TestCollectWarnings(t *testing.T) {
+	delegate := &recordingWarningHandler{}
+	handler := NewWarningHandler(delegate)
+
+	ctx, getWarnings := CollectWarnings(context.TODO())
+
+	handler.HandleWarningHeaderWithContext(ctx, 299, "", "collected")
+	handler.HandleWarningHeaderWithContext(context.TODO(), 299, "", "delegated")
+
+	assert.Equal(t, []string{"collected"}, getWarnings())
+	assert.Equal(t, []string{"delegated"}, delegate.messages)
+}
@@ -49,6 +49,7 @@ var (
 	defaultPluginRegistryOpenVSXURL                         string
 	defaultOpenVSXImage                                     string
 	defaultOpenVSXDatabaseImage                             string
+	defaultKubernetesImagePullerImage                       string
 	defaultDashboardHeaderMessageText                       string
 	defaultDevfileRegistryExternalDevfileRegistries         string
 
@@ -106,6 +107,7 @@ func Initialize() {
 
 	defaultOpenVSXImage = os.Getenv(util.GetArchitectureDependentEnvName("RELATED_IMAGE_openvsx"))
 	defaultOpenVSXDatabaseImage = os.Getenv(util.GetArchitectureDependentEnvName("RELATED_IMAGE_openvsx_postgres"))
+	defaultKubernetesImagePullerImage = os.Getenv(util.GetArchitectureDependentEnvName("RELATED_IMAGE_kubernetes_image_puller"))
 
 	// Don't get some k8s specific env
 	if !infrastructure.IsOpenShift() {
@@ -181,6 +183,14 @@ func GetOpenVSXDatabaseImage(checluster interface{}) string {
 	return PatchDefaultImageName(checluster, defaultOpenVSXDatabaseImage)
 }
 
+func GetKubernetesImagePullerImage(checluster interface{}) string {
+	if !initialized {
+		Initialize()
+	}
+
+	return PatchDefaultImageName(checluster, defaultKubernetesImagePullerImage)
+}
+
 func GetGatewayImage(checluster interface{}) string {
 	if !initialized {
 		Initialize()
@@ -319,6 +329,10 @@ func GetDevEnvironmentsDisableContainerBuildCapabilities() string {
 
 func PatchDefaultImageName(checluster interface{}, imageName string) string {
 	checlusterUnstructured, _ := runtime.DefaultUnstructuredConverter.ToUnstructured(checluster)
+	if mirroredImage := applyImageMirrors(checlusterUnstructured, imageName); mirroredImage != imageName {
+		return mirroredImage
+	}
+
 	hostname, _, _ := unstructured.NestedString(checlusterUnstructured, "spec", "containerRegistry", "hostname")
 	organization, _, _ := unstructured.NestedString(checlusterUnstructured, "spec", "containerRegistry", "organization")
 
@@ -0,0 +1,102 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package defaults
+
+import (
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type imageMirror struct {
+	source string
+	mirror string
+	// digestOnly restricts the mirror to the images referenced by digest
+	digestOnly bool
+}
+
+// ApplyImageMirrors rewrites the image with the mirror of the longest matching source
+// from `spec.containerRegistry.mirrors` and, if enabled, from the cluster `ImageDigestMirrorSet` objects
+// recorded in `status.imageDigestMirrors`.
+// Returns the image unchanged if there is no matching source.
+func ApplyImageMirrors(checluster interface{}, image string) string {
+	checlusterUnstructured, _ := runtime.DefaultUnstructuredConverter.ToUnstructured(checluster)
+	return applyImageMirrors(checlusterUnstructured, image)
+}
+
+func applyImageMirrors(checlusterUnstructured map[string]interface{}, image string) string {
+	var matched *imageMirror
+	for _, mirror := range getImageMirrors(checlusterUnstructured) {
+		if mirror.digestOnly && !strings.Contains(image, "@") {
+			continue
+		}
+
+		if isImageInRepository(image, mirror.source) && (matched == nil || len(mirror.source) > len(matched.source)) {
+			matched = &mirror
+		}
+	}
+
+	if matched == nil {
+		return image
+	}
+
+	return matched.mirror + strings.TrimPrefix(image, matched.source)
+}
+
+func getImageMirrors(checlusterUnstructured map[string]interface{}) []imageMirror {
+	specMirrors, _, _ := unstructured.NestedSlice(checlusterUnstructured, "spec", "containerRegistry", "mirrors")
+	mirrors := toImageMirrors(specMirrors, false)
+
+	useImageDigestMirrorSets, _, _ := unstructured.NestedBool(checlusterUnstructured, "spec", "containerRegistry", "useImageDigestMirrorSets")
+	if useImageDigestMirrorSets {
+		statusMirrors, _, _ := unstructured.NestedSlice(checlusterUnstructured, "status", "imageDigestMirrors")
+		mirrors = append(mirrors, toImageMirrors(statusMirrors, true)...)
+	}
+
+	return mirrors
+}
+
+func toImageMirrors(items []interface{}, digestOnly bool) []imageMirror {
+	var mirrors []imageMirror
+	for _, item := range items {
+		if item, ok := item.(map[string]interface{}); ok {
+			source, _, _ := unstructured.NestedString(item, "source")
+			mirror, _, _ := unstructured.NestedString(item, "mirror")
+			if source != "" && mirror != "" {
+				mirrors = append(mirrors, imageMirror{source: source, mirror: mirror, digestOnly: digestOnly})
+			}
+		}
+	}
+	return mirrors
+}
+
+// isImageInRepository checks if the image belongs to the given registry or repository, or to the nested ones.
+func isImageInRepository(image string, repository string) bool {
+	if !strings.HasPrefix(image, repository) {
+		return false
+	}
+
+	rest := image[len(repository):]
+	switch {
+	case rest == "":
+		return true
+	case rest[0] == '/' || rest[0] == '@':
+		return true
+	case rest[0] == ':':
+		// a tag, not a registry port
+		return !strings.Contains(rest, "/")
+	default:
+		return false
+	}
+}
@@ -0,0 +1,72 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package defaults
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestApplyImageMirrors(t *testing.T) {
+	checluster := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"containerRegistry": map[string]interface{}{
+				"hostname": "ignored.example.com",
+				"mirrors": []interface{}{
+					map[string]interface{}{"source": "quay.io", "mirror": "mirror.example.com/quay"},
+					map[string]interface{}{"source": "quay.io/eclipse", "mirror": "mirror.example.com/eclipse"},
+					map[string]interface{}{"source": "registry.io/che-server", "mirror": "mirror.example.com/server"},
+				},
+			},
+		},
+	}
+
+	type testCase struct {
+		image         string
+		expectedImage string
+	}
+
+	testCases := []testCase{
+		{image: "quay.io/eclipse/che-server:next", expectedImage: "mirror.example.com/eclipse/che-server:next"},
+		{image: "quay.io/devfile/udi@sha256:1234", expectedImage: "mirror.example.com/quay/devfile/udi@sha256:1234"},
+		{image: "quay.io/eclipse-che/image:next", expectedImage: "mirror.example.com/quay/eclipse-che/image:next"},
+		{image: "quay.io:5000/eclipse/image:next", expectedImage: "quay.io:5000/eclipse/image:next"},
+		{image: "registry.io/che-server:next", expectedImage: "mirror.example.com/server:next"},
+		{image: "registry.io/che-server-2:next", expectedImage: "registry.io/che-server-2:next"},
+		{image: "docker.io/library/postgres:16", expectedImage: "docker.io/library/postgres:16"},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.image, func(t *testing.T) {
+			assert.Equal(t, testCase.expectedImage, applyImageMirrors(checluster, testCase.image))
+		})
+	}
+
+	// Mirrors of the ImageDigestMirrorSet objects apply only to the images referenced by digest
+	checluster["spec"].(map[string]interface{})["containerRegistry"].(map[string]interface{})["useImageDigestMirrorSets"] = true
+	checluster["status"] = map[string]interface{}{
+		"imageDigestMirrors": []interface{}{
+			map[string]interface{}{"source": "docker.io/library", "mirror": "mirror.example.com/library"},
+		},
+	}
+	assert.Equal(t, "mirror.example.com/library/postgres@sha256:1234", applyImageMirrors(checluster, "docker.io/library/postgres@sha256:1234"))
+	assert.Equal(t, "docker.io/library/postgres:16", applyImageMirrors(checluster, "docker.io/library/postgres:16"))
+	delete(checluster, "status")
+
+	// Mirrors take precedence over the hostname
+	obj := &unstructured.Unstructured{Object: checluster}
+	assert.Equal(t, "mirror.example.com/eclipse/che-server:next", PatchDefaultImageName(obj, "quay.io/eclipse/che-server:next"))
+	assert.Equal(t, "ignored.example.com/library/postgres:16", PatchDefaultImageName(obj, "docker.io/library/postgres:16"))
+}
@@ -16,6 +16,8 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/eclipse-che/che-operator/pkg/common/chetypes"
 	"github.com/pkg/errors"
@@ -43,11 +45,21 @@ type Reconcilable interface {
 // ReconcilerManager manages a collection of Reconcilable objects and executes them in order.
 type ReconcilerManager struct {
 	reconcilers []Reconcilable
+
+	// mu serializes the reconciliation of the chain and of the requeued reconcilers
+	mu sync.Mutex
+	// requeueAt keeps the time the done reconcilers asked to be reconciled again
+	requeueAt map[Reconcilable]time.Time
+
+	// exposed for testing purpose only
+	now func() time.Time
 }
 
 func NewReconcilerManager() *ReconcilerManager {
 	return &ReconcilerManager{
 		reconcilers: make([]Reconcilable, 0),
+		requeueAt:   map[Reconcilable]time.Time{},
+		now:         time.Now,
 	}
 }
 
@@ -58,7 +70,11 @@ func (r *ReconcilerManager) AddReconciler(reconciler Reconcilable) {
 // ReconcileAll reconciles all registered reconcilers in the order they were added.
 // The reconciliation process stops at the first reconciler that returns done=false,
 // ensuring dependencies between reconcilers are respected.
+// The requeue requested by a done reconciler is kept for that reconciler only, see ReconcileRequeued.
 func (r *ReconcilerManager) ReconcileAll(ctx *chetypes.DeployContext) (reconcile.Result, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	for _, reconciler := range r.reconcilers {
 		result, done, err := reconciler.Reconcile(ctx)
 
@@ -71,11 +87,70 @@ func (r *ReconcilerManager) ReconcileAll(ctx *chetypes.DeployContext) (reconcile
 				return result, false, nil
 			}
 		}
+
+		r.setRequeueAt(reconciler, result.RequeueAfter)
 	}
 
 	return reconcile.Result{}, true, nil
 }
 
+// HasRequeued returns true if any done reconciler asked to be reconciled again.
+func (r *ReconcilerManager) HasRequeued() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.requeueAt) > 0
+}
+
+// ReconcileRequeued reconciles again, in order, only the reconcilers whose requested requeue time has come,
+// without reconciling the whole chain. These reconcilers were done in the last ReconcileAll,
+// so the reconcilers they depend on are done too.
+// Returns the time until the next requeued reconciler is due.
+func (r *ReconcilerManager) ReconcileRequeued(ctx *chetypes.DeployContext) (reconcile.Result, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var errs []error
+	for _, reconciler := range r.reconcilers {
+		requeueAt, ok := r.requeueAt[reconciler]
+		if !ok || requeueAt.After(r.now()) {
+			continue
+		}
+
+		result, done, err := reconciler.Reconcile(ctx)
+		if err != nil {
+			name := strings.Trim(reflect.TypeOf(reconciler).String(), "*")
+			errs = append(errs, errors.Wrap(err, fmt.Sprintf("%s reconciliation failed", name)))
+		}
+
+		if !done && result.RequeueAfter == 0 {
+			// Retried until done, or until the next reconciliation of the chain
+			result.RequeueAfter = time.Second
+		}
+		r.setRequeueAt(reconciler, result.RequeueAfter)
+	}
+
+	requeueAfter := time.Duration(0)
+	for _, requeueAt := range r.requeueAt {
+		if d := max(requeueAt.Sub(r.now()), time.Millisecond); requeueAfter == 0 || d < requeueAfter {
+			requeueAfter = d
+		}
+	}
+
+	if len(errs) > 0 {
+		return reconcile.Result{}, errs[0]
+	}
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+func (r *ReconcilerManager) setRequeueAt(reconciler Reconcilable, requeueAfter time.Duration) {
+	if requeueAfter > 0 {
+		r.requeueAt[reconciler] = r.now().Add(requeueAfter)
+	} else {
+		delete(r.requeueAt, reconciler)
+	}
+}
+
 // FinalizeAll invokes the Finalize method on all registered reconcilers.
 // Unlike ReconcileAll, this method continues executing all finalizers even if one fails,
 // ensuring all cleanup operations have a chance to run.
@@ -15,6 +15,7 @@ package reconciler
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/eclipse-che/che-operator/pkg/common/chetypes"
 	"github.com/eclipse-che/che-operator/pkg/common/test"
@@ -71,6 +72,69 @@ func TestReconcileAll_AllSucceed(t *testing.T) {
 	assert.Equal(t, reconcile.Result{}, result)
 }
 
+func TestReconcileRequeued_OnlyDueReconcilers(t *testing.T) {
+	manager := NewReconcilerManager()
+	ctx := test.NewCtxBuilder().Build()
+
+	now := time.Now()
+	manager.now = func() time.Time { return now }
+
+	calls := make([]int, 3)
+	for i, requeueAfter := range []time.Duration{time.Hour, 0, time.Minute} {
+		manager.AddReconciler(&mockReconciler{
+			reconcileFunc: func(ctx *chetypes.DeployContext) (reconcile.Result, bool, error) {
+				calls[i]++
+				return reconcile.Result{RequeueAfter: requeueAfter}, true, nil
+			},
+		})
+	}
+
+	// The chain itself is not requeued
+	result, done, err := manager.ReconcileAll(ctx)
+	assert.True(t, done)
+	assert.Nil(t, err)
+	assert.Equal(t, reconcile.Result{}, result)
+	assert.True(t, manager.HasRequeued())
+	assert.Equal(t, []int{1, 1, 1}, calls)
+
+	// Nothing is due yet
+	requeueResult, err := manager.ReconcileRequeued(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, reconcile.Result{RequeueAfter: time.Minute}, requeueResult)
+	assert.Equal(t, []int{1, 1, 1}, calls)
+
+	// Only the reconciler that asked to be requeued in a minute is reconciled
+	now = now.Add(time.Minute)
+	requeueResult, err = manager.ReconcileRequeued(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, reconcile.Result{RequeueAfter: time.Minute}, requeueResult)
+	assert.Equal(t, []int{1, 1, 2}, calls)
+}
+
+func TestReconcileRequeued_NotDueAfterChainReconciliation(t *testing.T) {
+	manager := NewReconcilerManager()
+	ctx := test.NewCtxBuilder().Build()
+
+	requeueAfter := time.Minute
+	manager.AddReconciler(&mockReconciler{
+		reconcileFunc: func(ctx *chetypes.DeployContext) (reconcile.Result, bool, error) {
+			return reconcile.Result{RequeueAfter: requeueAfter}, true, nil
+		},
+	})
+
+	_, _, _ = manager.ReconcileAll(ctx)
+	assert.True(t, manager.HasRequeued())
+
+	// The reconciler does not ask to be requeued anymore
+	requeueAfter = 0
+	_, _, _ = manager.ReconcileAll(ctx)
+	assert.False(t, manager.HasRequeued())
+
+	result, err := manager.ReconcileRequeued(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, reconcile.Result{}, result)
+}
+
 func TestReconcileAll_FirstReconcilerFails(t *testing.T) {
 	manager := NewReconcilerManager()
 	ctx := test.NewCtxBuilder().Build()
@@ -15,6 +15,7 @@ package dashboard
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -276,6 +277,58 @@ func TestDashboardDeploymentEnvVars(t *testing.T) {
 		})
 }
 
+func TestDashboardDeploymentIgnoresExternalRegistriesHealth(t *testing.T) {
+	ctx := test.NewCtxBuilder().WithCheCluster(&chev2.CheCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "eclipse-che",
+			Namespace: "eclipse-che",
+		},
+		Spec: chev2.CheClusterSpec{
+			Components: chev2.CheClusterComponents{
+				DevfileRegistry: chev2.DevfileRegistry{
+					ExternalDevfileRegistries: []chev2.ExternalDevfileRegistry{
+						{
+							Url: "https://registry-a.example.com",
+						},
+						{
+							Url: "https://registry-b.example.com",
+							ExternalRegistryOptions: chev2.ExternalRegistryOptions{
+								DisplayName: "Registry B",
+								Priority:    10,
+							},
+						},
+					},
+				},
+			},
+		},
+		Status: chev2.CheClusterStatus{
+			ExternalRegistries: []chev2.ExternalRegistryStatus{
+				{
+					Url:    "https://registry-a.example.com",
+					Type:   "DevfileRegistry",
+					Hidden: true,
+				},
+			},
+		},
+	}).Build()
+
+	deployment, err := NewDashboardReconciler().getDashboardDeploymentSpec(ctx)
+	assert.Nil(t, err)
+
+	// The dashboard reads the registries health from the CheCluster status,
+	// a health change must not roll out the dashboard
+	ctx.CheCluster.Status.ExternalRegistries[0].Hidden = false
+	ctx.CheCluster.Status.ExternalRegistries[0].Healthy = true
+
+	updatedDeployment, err := NewDashboardReconciler().getDashboardDeploymentSpec(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, deployment.Spec.Template, updatedDeployment.Spec.Template)
+
+	for _, env := range deployment.Spec.Template.Spec.Containers[0].Env {
+		assert.False(t, strings.HasPrefix(env.Name, "CHE_DASHBOARD_EXTERNAL_"), env.Name)
+	}
+}
+
 func TestDashboardDeploymentVolumes(t *testing.T) {
 	type resourcesTestCase struct {
 		name         string
@@ -14,6 +14,7 @@ package dashboard
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"slices"
 
@@ -121,6 +122,11 @@ func (d *DashboardReconciler) getDashboardDeploymentSpec(ctx *chetypes.DeployCon
 
 	// Mount CheCluster default values
 	envVars = append(envVars, utils.GetEnvsByRegExp("^CHE_DEFAULT_SPEC.*")...)
+	for i := range envVars {
+		if envVars[i].Name == "CHE_DEFAULT_SPEC_DEVENVIRONMENTS_DEFAULTCOMPONENTS" {
+			envVars[i].Value = applyImageMirrorsToComponents(ctx, envVars[i].Value)
+		}
+	}
 
 	if ctx.CheCluster.IsInternalOpenVSXRegistryEnabled() {
 		envVars = slices.DeleteFunc(envVars, func(envVar corev1.EnvVar) bool {
@@ -306,3 +312,34 @@ func (d *DashboardReconciler) provisionCustomPublicCA(volumes []corev1.Volume, v
 	})
 	return volumes, volumeMounts
 }
+
+// applyImageMirrorsToComponents applies the image mirrors to the container images
+// of the given JSON serialized devfile components.
+func applyImageMirrorsToComponents(ctx *chetypes.DeployContext, componentsJson string) string {
+	var components []map[string]interface{}
+	if err := json.Unmarshal([]byte(componentsJson), &components); err != nil {
+		return componentsJson
+	}
+
+	mirrored := false
+	for _, component := range components {
+		if container, ok := component["container"].(map[string]interface{}); ok {
+			if image, ok := container["image"].(string); ok {
+				if mirroredImage := defaults.ApplyImageMirrors(ctx.CheCluster, image); mirroredImage != image {
+					container["image"] = mirroredImage
+					mirrored = true
+				}
+			}
+		}
+	}
+
+	if !mirrored {
+		return componentsJson
+	}
+
+	data, err := json.Marshal(components)
+	if err != nil {
+		return componentsJson
+	}
+	return string(data)
+}
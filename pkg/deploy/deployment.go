@@ -14,6 +14,7 @@ package deploy
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"slices"
 	"sort"
@@ -31,6 +32,7 @@ import (
 	"github.com/eclipse-che/che-operator/pkg/common/chetypes"
 	"github.com/eclipse-che/che-operator/pkg/common/constants"
 	"github.com/eclipse-che/che-operator/pkg/common/infrastructure"
+	defaults "github.com/eclipse-che/che-operator/pkg/common/operator-defaults"
 	"github.com/eclipse-che/che-operator/pkg/common/test"
 	"github.com/eclipse-che/che-operator/pkg/common/utils"
 	"github.com/google/go-cmp/cmp"
@@ -71,6 +73,10 @@ func SyncDeploymentSpecToCluster(
 		return false, err
 	}
 
+	if err := restorePreviousPodTemplate(deployContext, deploymentSpec); err != nil {
+		return false, err
+	}
+
 	done, err := Sync(deployContext, deploymentSpec, deploymentDiffOpts)
 	if err != nil || !done {
 		// Failed to sync (update), let's delete and create instead
@@ -104,6 +110,30 @@ func SyncDeploymentSpecToCluster(
 	return provisioned, nil
 }
 
+// restorePreviousPodTemplate restores the pod template of the previous Che version, while the upgrade
+// pre-flight checks fail or when the upgrade is rolled back, see UpgradeReconciler.
+// Deployments added in the new version keep their pod template.
+func restorePreviousPodTemplate(ctx *chetypes.DeployContext, deployment *appsv1.Deployment) error {
+	if !ctx.CheCluster.IsPreviousCheVersionKept() {
+		return nil
+	}
+
+	previousPodTemplates, err := getPreviousPodTemplates(ctx)
+	if err != nil {
+		return err
+	}
+
+	if data, ok := previousPodTemplates[deployment.Name]; ok {
+		podTemplate := corev1.PodTemplateSpec{}
+		if err := json.Unmarshal([]byte(data), &podTemplate); err != nil {
+			return err
+		}
+		deployment.Spec.Template = podTemplate
+	}
+
+	return nil
+}
+
 // OverrideDeployment with custom settings
 func OverrideDeployment(
 	ctx *chetypes.DeployContext,
@@ -130,6 +160,11 @@ func OverrideDeployment(
 		if err := OverrideContainer(ctx.CheCluster.Namespace, container, overrideContainerSettings); err != nil {
 			return err
 		}
+
+		// Default images are already mirrored, mirror the ones set by the user as well
+		if overrideContainerSettings != nil && overrideContainerSettings.Image != "" {
+			container.Image = defaults.ApplyImageMirrors(ctx.CheCluster, container.Image)
+		}
 	}
 
 	if overrideDeploymentSettings != nil {
@@ -34,11 +34,14 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 const (
 	devWorkspaceConfigName = "devworkspace-config"
+	// profileDevWorkspaceConfigComponent labels the DevWorkspaceOperatorConfigs of the development environment profiles
+	profileDevWorkspaceConfigComponent = "devworkspace-config-profile"
 )
 
 type DevWorkspaceConfigReconciler struct {
@@ -74,13 +77,111 @@ func (d *DevWorkspaceConfigReconciler) Reconcile(ctx *chetypes.DeployContext) (r
 		return reconcile.Result{}, false, err
 	}
 
+	// the profile configurations are derived from the default one
+	config := dwoc.Config.DeepCopy()
+
 	done, err := deploy.Sync(ctx, dwoc)
 	if !done || err != nil {
 		return reconcile.Result{RequeueAfter: time.Second}, false, err
 	}
+
+	done, err = syncProfileConfigs(ctx, config)
+	if !done || err != nil {
+		return reconcile.Result{RequeueAfter: time.Second}, false, err
+	}
 	return reconcile.Result{}, true, nil
 }
 
+// GetDevWorkspaceConfigName returns the name of the DevWorkspaceOperatorConfig
+// the workspaces of the users matching the profile refer to.
+func GetDevWorkspaceConfigName(profile *chev2.DevEnvironmentProfile) string {
+	if profile == nil {
+		return devWorkspaceConfigName
+	}
+	return devWorkspaceConfigName + "-" + profile.Name
+}
+
+// syncProfileConfigs syncs a DevWorkspaceOperatorConfig for each development environment profile,
+// since the DevWorkspace Operator can not select the configuration by namespace.
+// The configurations of the removed profiles are deleted.
+func syncProfileConfigs(ctx *chetypes.DeployContext, config *controllerv1alpha1.OperatorConfiguration) (bool, error) {
+	profileNames := map[string]bool{}
+
+	for i := range ctx.CheCluster.Spec.DevEnvironments.Profiles {
+		profile := &ctx.CheCluster.Spec.DevEnvironments.Profiles[i]
+		name := GetDevWorkspaceConfigName(profile)
+		profileNames[name] = true
+
+		dwoc := &controllerv1alpha1.DevWorkspaceOperatorConfig{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: ctx.CheCluster.Namespace,
+			},
+		}
+		if _, err := deploy.GetNamespacedObject(ctx, name, dwoc); err != nil {
+			return false, err
+		}
+
+		dwoc.TypeMeta = metav1.TypeMeta{
+			Kind:       "DevWorkspaceOperatorConfig",
+			APIVersion: controllerv1alpha1.GroupVersion.String(),
+		}
+		dwoc.Labels = deploy.GetLabels(profileDevWorkspaceConfigComponent)
+		dwoc.Config = config.DeepCopy()
+
+		profileCtx := *ctx
+		profileCtx.CheCluster = getProfileCheCluster(ctx.CheCluster, profile)
+		if err := updateWorkspaceConfig(&profileCtx, dwoc.Config); err != nil {
+			return false, err
+		}
+
+		if done, err := deploy.Sync(ctx, dwoc); !done || err != nil {
+			return false, err
+		}
+	}
+
+	dwocs := &controllerv1alpha1.DevWorkspaceOperatorConfigList{}
+	if err := ctx.ClusterAPI.Client.List(
+		context.TODO(),
+		dwocs,
+		client.InNamespace(ctx.CheCluster.Namespace),
+		client.MatchingLabels{constants.KubernetesComponentLabelKey: profileDevWorkspaceConfigComponent},
+	); err != nil {
+		return false, err
+	}
+
+	for i := range dwocs.Items {
+		if !profileNames[dwocs.Items[i].Name] {
+			if done, err := deploy.DeleteNamespacedObject(ctx, dwocs.Items[i].Name, &controllerv1alpha1.DevWorkspaceOperatorConfig{}); !done || err != nil {
+				return false, err
+			}
+		}
+	}
+
+	return true, nil
+}
+
+// getProfileCheCluster returns a copy of the CheCluster with the workspace settings overridden by the profile.
+func getProfileCheCluster(cheCluster *chev2.CheCluster, profile *chev2.DevEnvironmentProfile) *chev2.CheCluster {
+	profileCheCluster := cheCluster.DeepCopy()
+	devEnvironments := &profileCheCluster.Spec.DevEnvironments
+
+	if profile.DefaultContainerResources != nil {
+		devEnvironments.DefaultContainerResources = profile.DefaultContainerResources
+	}
+	if profile.ContainerResourceCaps != nil {
+		devEnvironments.ContainerResourceCaps = profile.ContainerResourceCaps
+	}
+	if ptr.Deref(profile.DisableContainerBuildCapabilities, false) {
+		devEnvironments.DisableContainerBuildCapabilities = ptr.To(true)
+	}
+	if ptr.Deref(profile.DisableContainerRunCapabilities, false) {
+		devEnvironments.DisableContainerRunCapabilities = ptr.To(true)
+	}
+
+	return profileCheCluster
+}
+
 func (d *DevWorkspaceConfigReconciler) Finalize(ctx *chetypes.DeployContext) bool {
 	return true
 }
@@ -126,6 +227,8 @@ func updateWorkspaceConfig(ctx *chetypes.DeployContext, operatorConfig *controll
 
 	updateInitContainers(devEnvironments, operatorConfig.Workspace)
 
+	updateWorkspaceImages(cheCluster, operatorConfig.Workspace)
+
 	if err := updateTLSCertificateConfigmapRef(ctx, operatorConfig); err != nil {
 		return err
 	}
@@ -308,6 +411,23 @@ func updateInitContainers(devEnvironments *chev2.CheClusterDevEnvironments, work
 	workspaceConfig.InitContainers = devEnvironments.InitContainers
 }
 
+// updateWorkspaceImages applies the image mirrors to the images of the workspace configuration.
+func updateWorkspaceImages(cheCluster *chev2.CheCluster, workspaceConfig *controllerv1alpha1.WorkspaceConfig) {
+	if workspaceConfig.ProjectCloneConfig != nil && workspaceConfig.ProjectCloneConfig.Image != "" {
+		workspaceConfig.ProjectCloneConfig.Image = defaults.ApplyImageMirrors(cheCluster, workspaceConfig.ProjectCloneConfig.Image)
+	}
+
+	if len(workspaceConfig.InitContainers) > 0 {
+		// Init containers are shared with the CheCluster spec, copy them before updating
+		initContainers := make([]corev1.Container, len(workspaceConfig.InitContainers))
+		for i := range workspaceConfig.InitContainers {
+			initContainers[i] = *workspaceConfig.InitContainers[i].DeepCopy()
+			initContainers[i].Image = defaults.ApplyImageMirrors(cheCluster, initContainers[i].Image)
+		}
+		workspaceConfig.InitContainers = initContainers
+	}
+}
+
 func updateTLSCertificateConfigmapRef(ctx *chetypes.DeployContext, operatorConfig *controllerv1alpha1.OperatorConfiguration) error {
 	cm := &corev1.ConfigMap{}
 	exists, err := ctx.ClusterAPI.ClientWrapper.GetIgnoreNotFound(
@@ -26,6 +26,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -922,6 +923,43 @@ func TestReconcileDevWorkspaceConfigForInitContainers(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Create DevWorkspaceOperatorConfig with mirrored InitContainers",
+			cheCluster: &chev2.CheCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "eclipse-che",
+					Name:      "eclipse-che",
+				},
+				Spec: chev2.CheClusterSpec{
+					ContainerRegistry: chev2.CheClusterContainerRegistry{
+						Mirrors: []chev2.ImageMirror{
+							{
+								Source: "quay.io/eclipse",
+								Mirror: "mirror.example.com/eclipse",
+							},
+						},
+					},
+					DevEnvironments: chev2.CheClusterDevEnvironments{
+						InitContainers: []corev1.Container{
+							{
+								Name:  "init-container",
+								Image: "quay.io/eclipse/init:latest",
+							},
+						},
+					},
+				},
+			},
+			expectedOperatorConfig: &controllerv1alpha1.OperatorConfiguration{
+				Workspace: &controllerv1alpha1.WorkspaceConfig{
+					InitContainers: []corev1.Container{
+						{
+							Name:  "init-container",
+							Image: "mirror.example.com/eclipse/init:latest",
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "Clear InitContainers from DevWorkspaceOperatorConfig",
 			cheCluster: &chev2.CheCluster{
@@ -976,3 +1014,78 @@ func TestReconcileDevWorkspaceConfigForInitContainers(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileDevWorkspaceConfigForProfiles(t *testing.T) {
+	cheCluster := &chev2.CheCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "eclipse-che",
+			Name:      "eclipse-che",
+		},
+		Spec: chev2.CheClusterSpec{
+			DevEnvironments: chev2.CheClusterDevEnvironments{
+				DefaultContainerResources: &corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+				},
+				DisableContainerBuildCapabilities: ptr.To(true),
+				DisableContainerRunCapabilities:   ptr.To(false),
+				ContainerRunConfiguration: &chev2.ContainerRunConfiguration{
+					ContainerSecurityContext: &corev1.SecurityContext{
+						AllowPrivilegeEscalation: ptr.To(true),
+					},
+				},
+				Profiles: []chev2.DevEnvironmentProfile{
+					{
+						Name: "data-science",
+						DefaultContainerResources: &corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+						},
+						ContainerResourceCaps: &corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("16Gi")},
+						},
+					},
+					{
+						Name:                            "contractors",
+						DisableContainerRunCapabilities: ptr.To(true),
+					},
+				},
+			},
+		},
+	}
+
+	deployContext := test.NewCtxBuilder().WithCheCluster(cheCluster).Build()
+
+	devWorkspaceConfigReconciler := NewDevWorkspaceConfigReconciler()
+	test.EnsureReconcile(t, deployContext, devWorkspaceConfigReconciler.Reconcile)
+
+	getConfig := func(name string) *controllerv1alpha1.WorkspaceConfig {
+		dwoc := &controllerv1alpha1.DevWorkspaceOperatorConfig{}
+		assert.NoError(t, deployContext.ClusterAPI.Client.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: "eclipse-che"}, dwoc))
+		return dwoc.Config.Workspace
+	}
+
+	config := getConfig(devWorkspaceConfigName)
+	assert.Equal(t, resource.MustParse("1Gi"), config.DefaultContainerResources.Limits[corev1.ResourceMemory])
+	assert.Nil(t, config.ContainerResourceCaps)
+	assert.Equal(t, ptr.To(false), config.HostUsers)
+
+	config = getConfig("devworkspace-config-data-science")
+	assert.Equal(t, resource.MustParse("4Gi"), config.DefaultContainerResources.Limits[corev1.ResourceMemory])
+	assert.Equal(t, resource.MustParse("16Gi"), config.ContainerResourceCaps.Limits[corev1.ResourceMemory])
+	assert.Equal(t, ptr.To(false), config.HostUsers)
+	assert.Equal(t, ptr.To(true), config.ContainerSecurityContext.AllowPrivilegeEscalation)
+
+	// the container run security context is not applied when the profile disables the capabilities
+	config = getConfig("devworkspace-config-contractors")
+	assert.Equal(t, resource.MustParse("1Gi"), config.DefaultContainerResources.Limits[corev1.ResourceMemory])
+	assert.Nil(t, config.HostUsers)
+	assert.Nil(t, config.ContainerSecurityContext)
+
+	// the configuration of the removed profile is deleted
+	cheCluster.Spec.DevEnvironments.Profiles = cheCluster.Spec.DevEnvironments.Profiles[:1]
+	test.EnsureReconcile(t, deployContext, devWorkspaceConfigReconciler.Reconcile)
+
+	dwoc := &controllerv1alpha1.DevWorkspaceOperatorConfig{}
+	err := deployContext.ClusterAPI.Client.Get(context.TODO(), types.NamespacedName{Name: "devworkspace-config-contractors", Namespace: "eclipse-che"}, dwoc)
+	assert.True(t, errors.IsNotFound(err))
+	assert.NoError(t, deployContext.ClusterAPI.Client.Get(context.TODO(), types.NamespacedName{Name: "devworkspace-config-data-science", Namespace: "eclipse-che"}, dwoc))
+}
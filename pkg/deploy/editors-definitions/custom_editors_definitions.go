@@ -0,0 +1,258 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package editorsdefinitions
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/eclipse-che/che-operator/pkg/common/chetypes"
+	"github.com/eclipse-che/che-operator/pkg/common/constants"
+	"github.com/eclipse-che/che-operator/pkg/deploy"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// readCustomEditorDefinitions reads editor definitions provided in the ConfigMaps
+// of the Che namespace labeled with:
+// - app.kubernetes.io/part-of=che.eclipse.org
+// - app.kubernetes.io/component=custom-editor-definition
+// Every data entry of such a ConfigMap is an editor devfile. Invalid devfiles are skipped
+// and reported in the `CustomEditorDefinitionsValid` status condition.
+// Built-in editors are hidden by listing their ids, `<publisher>/<name>/<version>`,
+// in the comma separated `che.eclipse.org/hidden-editors` annotation.
+func readCustomEditorDefinitions(ctx *chetypes.DeployContext) (map[string][]byte, []string, error) {
+	editorDefinitions := make(map[string][]byte)
+	var hiddenEditors []string
+	var invalidEditors []string
+
+	configMaps := &corev1.ConfigMapList{}
+	if err := ctx.ClusterAPI.Client.List(
+		context.TODO(),
+		configMaps,
+		client.InNamespace(ctx.CheCluster.Namespace),
+		client.MatchingLabels{
+			constants.KubernetesPartOfLabelKey:    constants.CheEclipseOrg,
+			constants.KubernetesComponentLabelKey: constants.CustomEditorDefinitionComponentName,
+		},
+	); err != nil {
+		return editorDefinitions, hiddenEditors, err
+	}
+
+	sort.Slice(configMaps.Items, func(i, j int) bool {
+		return configMaps.Items[i].Name < configMaps.Items[j].Name
+	})
+
+	for _, cm := range configMaps.Items {
+		for _, editorId := range strings.Split(cm.Annotations[constants.CheEclipseOrgHiddenEditors], ",") {
+			if editorId = strings.TrimSpace(editorId); editorId != "" {
+				hiddenEditors = append(hiddenEditors, editorId)
+			}
+		}
+
+		for _, key := range slices.Sorted(maps.Keys(cm.Data)) {
+			var devfile map[string]interface{}
+			if err := yaml.Unmarshal([]byte(cm.Data[key]), &devfile); err != nil {
+				logger.Error(err, "Failed to unmarshal custom editor definition", "configmap", cm.Name, "key", key)
+				invalidEditors = append(invalidEditors, fmt.Sprintf("%s/%s: %s", cm.Name, key, err.Error()))
+				continue
+			}
+
+			if err := validateEditorDefinition(devfile); err != nil {
+				logger.Error(err, "Invalid custom editor definition", "configmap", cm.Name, "key", key)
+				invalidEditors = append(invalidEditors, fmt.Sprintf("%s/%s: %s", cm.Name, key, err.Error()))
+				continue
+			}
+
+			updateEditorDefinitionImages(ctx.CheCluster, devfile)
+			editorContent, err := yaml.Marshal(devfile)
+			if err != nil {
+				return editorDefinitions, hiddenEditors, err
+			}
+
+			editorDefinitions[cm.Name+"."+key] = editorContent
+		}
+	}
+
+	if err := syncCustomEditorDefinitionsCondition(ctx, len(configMaps.Items) > 0, invalidEditors); err != nil {
+		return editorDefinitions, hiddenEditors, err
+	}
+
+	return editorDefinitions, hiddenEditors, nil
+}
+
+// syncCustomEditorDefinitionsCondition reports the invalid custom editor definitions in the status condition.
+// The condition is removed if there are no custom editor definitions.
+func syncCustomEditorDefinitionsCondition(ctx *chetypes.DeployContext, hasCustomEditors bool, invalidEditors []string) error {
+	conditions := make([]metav1.Condition, len(ctx.CheCluster.Status.Conditions))
+	copy(conditions, ctx.CheCluster.Status.Conditions)
+
+	if !hasCustomEditors {
+		meta.RemoveStatusCondition(&conditions, chev2.CustomEditorDefinitionsValidCondition)
+	} else {
+		condition := metav1.Condition{
+			Type:               chev2.CustomEditorDefinitionsValidCondition,
+			ObservedGeneration: ctx.CheCluster.Generation,
+			Status:             metav1.ConditionTrue,
+			Reason:             chev2.CustomEditorDefinitionsValidReasonValid,
+			Message:            "All custom editor definitions are valid",
+		}
+
+		if len(invalidEditors) > 0 {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = chev2.CustomEditorDefinitionsValidReasonInvalid
+			condition.Message = fmt.Sprintf("Invalid custom editor definitions are skipped: %s", strings.Join(invalidEditors, "; "))
+		}
+
+		meta.SetStatusCondition(&conditions, condition)
+	}
+
+	if reflect.DeepEqual(ctx.CheCluster.Status.Conditions, conditions) {
+		return nil
+	}
+
+	ctx.CheCluster.Status.Conditions = conditions
+	return deploy.UpdateCheCRStatus(ctx, "Conditions", chev2.CustomEditorDefinitionsValidCondition)
+}
+
+// mergeEditorDefinitions returns built-in editor definitions except the hidden ones
+// and the ones overridden by a custom editor definition with the same id, along with
+// all custom editor definitions. If several custom editor definitions have the same id,
+// the first one in the alphabetical order of ConfigMap names and keys is used.
+func mergeEditorDefinitions(builtInEditorDefinitions map[string][]byte, customEditorDefinitions map[string][]byte, hiddenEditors []string) map[string][]byte {
+	editorDefinitions := make(map[string][]byte)
+	customEditorIds := make(map[string]bool)
+
+	customKeys := make([]string, 0, len(customEditorDefinitions))
+	for key := range customEditorDefinitions {
+		customKeys = append(customKeys, key)
+	}
+	sort.Strings(customKeys)
+
+	for _, key := range customKeys {
+		editorId, err := getEditorId(customEditorDefinitions[key])
+		if err != nil {
+			logger.Error(err, "Failed to read custom editor definition id", "key", key)
+			continue
+		}
+
+		if customEditorIds[editorId] {
+			logger.Info("Duplicated custom editor definition skipped", "id", editorId, "key", key)
+			continue
+		}
+
+		customEditorIds[editorId] = true
+		editorDefinitions[key] = customEditorDefinitions[key]
+	}
+
+	for fileName, content := range builtInEditorDefinitions {
+		editorId, err := getEditorId(content)
+		if err != nil {
+			logger.Error(err, "Failed to read editor definition id", "file", fileName)
+			continue
+		}
+
+		if customEditorIds[editorId] || slices.Contains(hiddenEditors, editorId) {
+			continue
+		}
+
+		editorDefinitions[fileName] = content
+	}
+
+	return editorDefinitions
+}
+
+// validateEditorDefinition checks that the devfile has all fields
+// required to serve it as an editor.
+func validateEditorDefinition(devfile map[string]interface{}) error {
+	if schemaVersion, ok := devfile["schemaVersion"].(string); !ok || schemaVersion == "" {
+		return fmt.Errorf("schemaVersion is not set")
+	}
+
+	metadata, ok := devfile["metadata"].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("metadata is not set")
+	}
+
+	if name, ok := metadata["name"].(string); !ok || name == "" {
+		return fmt.Errorf("metadata.name is not set")
+	}
+
+	attributes, ok := metadata["attributes"].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("metadata.attributes is not set")
+	}
+
+	if publisher, ok := attributes["publisher"].(string); !ok || publisher == "" {
+		return fmt.Errorf("metadata.attributes.publisher is not set")
+	}
+
+	if attributes["version"] == nil {
+		return fmt.Errorf("metadata.attributes.version is not set")
+	}
+
+	components, ok := devfile["components"].([]interface{})
+	if !ok || len(components) == 0 {
+		return fmt.Errorf("components are not set")
+	}
+
+	for i := range components {
+		component, ok := components[i].(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("components[%d] is not an object", i)
+		}
+
+		if name, ok := component["name"].(string); !ok || name == "" {
+			return fmt.Errorf("components[%d].name is not set", i)
+		}
+
+		if container, exists := component["container"]; exists {
+			container, ok := container.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("components[%d].container is not an object", i)
+			}
+
+			if image, ok := container["image"].(string); !ok || image == "" {
+				return fmt.Errorf("components[%d].container.image is not set", i)
+			}
+		}
+	}
+
+	return nil
+}
+
+// getEditorId returns editor id in the format `<publisher>/<name>/<version>`.
+func getEditorId(editorContent []byte) (string, error) {
+	var devfile map[string]interface{}
+	if err := yaml.Unmarshal(editorContent, &devfile); err != nil {
+		return "", err
+	}
+
+	if err := validateEditorDefinition(devfile); err != nil {
+		return "", err
+	}
+
+	metadata := devfile["metadata"].(map[string]interface{})
+	attributes := metadata["attributes"].(map[string]interface{})
+
+	return fmt.Sprintf("%s/%s/%v", attributes["publisher"], metadata["name"], attributes["version"]), nil
+}
@@ -0,0 +1,240 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package editorsdefinitions
+
+import (
+	"context"
+	"testing"
+
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/eclipse-che/che-operator/pkg/common/constants"
+	"github.com/eclipse-che/che-operator/pkg/common/test"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	customEditor = `
+schemaVersion: 2.2.2
+metadata:
+  name: in-house-editor
+  attributes:
+    version: latest
+    publisher: acme
+components:
+  - name: editor
+    container:
+      image: quay.io/acme/editor:latest
+`
+	overriddenEditor = `
+schemaVersion: 2.2.2
+metadata:
+  name: che-code
+  attributes:
+    version: 2022.1
+    publisher: test
+components:
+  - name: component-a
+    container:
+      image: quay.io/acme/che-code:2022.1
+`
+	invalidEditor = `
+schemaVersion: 2.2.2
+metadata:
+  name: invalid-editor
+components:
+  - name: editor
+    container:
+      image: quay.io/acme/editor:latest
+`
+)
+
+func TestSyncCustomEditorDefinitions(t *testing.T) {
+	cheCluster := &chev2.CheCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "eclipse-che",
+			Namespace: "eclipse-che",
+		},
+		Spec: chev2.CheClusterSpec{
+			ContainerRegistry: chev2.CheClusterContainerRegistry{
+				Mirrors: []chev2.ImageMirror{
+					{
+						Source: "quay.io/acme",
+						Mirror: "mirror.example.com/acme",
+					},
+				},
+			},
+		},
+	}
+
+	customEditorsCM := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "custom-editors",
+			Namespace: "eclipse-che",
+			Labels: map[string]string{
+				constants.KubernetesPartOfLabelKey:    constants.CheEclipseOrg,
+				constants.KubernetesComponentLabelKey: constants.CustomEditorDefinitionComponentName,
+			},
+		},
+		Data: map[string]string{
+			"in-house-editor.yaml": customEditor,
+			"che-code.yaml":        overriddenEditor,
+			"invalid-editor.yaml":  invalidEditor,
+		},
+	}
+
+	ctx := test.NewCtxBuilder().WithCheCluster(cheCluster).WithObjects(customEditorsCM).Build()
+
+	editorsDefinitionsReconciler := NewEditorsDefinitionsReconciler()
+	test.EnsureReconcile(t, ctx, editorsDefinitionsReconciler.Reconcile)
+
+	cm := &corev1.ConfigMap{}
+	err := ctx.ClusterAPI.Client.Get(context.TODO(), types.NamespacedName{Name: editorsDefinitionsConfigMapName, Namespace: "eclipse-che"}, cm)
+	assert.NoError(t, err)
+
+	// devfile-2.yaml is overridden by che-code.yaml, invalid-editor.yaml is skipped
+	assert.Len(t, cm.Data, 3)
+	assert.Contains(t, cm.Data, "devfile-1.yaml")
+	assert.Contains(t, cm.Data, "custom-editors.in-house-editor.yaml")
+	assert.Contains(t, cm.Data, "custom-editors.che-code.yaml")
+
+	var devfile map[string]interface{}
+	err = yaml.Unmarshal([]byte(cm.Data["custom-editors.in-house-editor.yaml"]), &devfile)
+	assert.NoError(t, err)
+
+	container := devfile["components"].([]interface{})[0].(map[string]interface{})["container"].(map[string]interface{})
+	assert.Equal(t, "mirror.example.com/acme/editor:latest", container["image"])
+
+	// The invalid definition is reported in the status
+	condition := meta.FindStatusCondition(ctx.CheCluster.Status.Conditions, chev2.CustomEditorDefinitionsValidCondition)
+	assert.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, chev2.CustomEditorDefinitionsValidReasonInvalid, condition.Reason)
+	assert.Contains(t, condition.Message, "custom-editors/invalid-editor.yaml: metadata.attributes is not set")
+
+	// Hide built-in editor
+	customEditorsCM.Annotations = map[string]string{
+		constants.CheEclipseOrgHiddenEditors: "test/che-code/1.2.3, unknown/editor/latest",
+	}
+	err = ctx.ClusterAPI.Client.Update(context.TODO(), customEditorsCM)
+	assert.NoError(t, err)
+
+	test.EnsureReconcile(t, ctx, editorsDefinitionsReconciler.Reconcile)
+
+	err = ctx.ClusterAPI.Client.Get(context.TODO(), types.NamespacedName{Name: editorsDefinitionsConfigMapName, Namespace: "eclipse-che"}, cm)
+	assert.NoError(t, err)
+
+	assert.Len(t, cm.Data, 2)
+	assert.Contains(t, cm.Data, "custom-editors.in-house-editor.yaml")
+	assert.Contains(t, cm.Data, "custom-editors.che-code.yaml")
+
+	// Fix the invalid definition
+	delete(customEditorsCM.Data, "invalid-editor.yaml")
+	err = ctx.ClusterAPI.Client.Update(context.TODO(), customEditorsCM)
+	assert.NoError(t, err)
+
+	test.EnsureReconcile(t, ctx, editorsDefinitionsReconciler.Reconcile)
+
+	condition = meta.FindStatusCondition(ctx.CheCluster.Status.Conditions, chev2.CustomEditorDefinitionsValidCondition)
+	assert.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+
+	// The condition is removed with the custom editor definitions
+	err = ctx.ClusterAPI.Client.Delete(context.TODO(), customEditorsCM)
+	assert.NoError(t, err)
+
+	test.EnsureReconcile(t, ctx, editorsDefinitionsReconciler.Reconcile)
+	assert.Nil(t, meta.FindStatusCondition(ctx.CheCluster.Status.Conditions, chev2.CustomEditorDefinitionsValidCondition))
+}
+
+func TestMergeEditorDefinitionsDuplicatedCustomEditors(t *testing.T) {
+	editorDefinitions := mergeEditorDefinitions(
+		map[string][]byte{},
+		map[string][]byte{
+			"b.editor.yaml": []byte(customEditor),
+			"a.editor.yaml": []byte(customEditor),
+		},
+		nil,
+	)
+
+	assert.Len(t, editorDefinitions, 1)
+	assert.Contains(t, editorDefinitions, "a.editor.yaml")
+}
+
+func TestValidateEditorDefinition(t *testing.T) {
+	type testCase struct {
+		name    string
+		devfile string
+		valid   bool
+	}
+
+	testCases := []testCase{
+		{
+			name:    "Valid editor definition",
+			devfile: customEditor,
+			valid:   true,
+		},
+		{
+			name:    "Missing publisher",
+			devfile: invalidEditor,
+			valid:   false,
+		},
+		{
+			name: "Missing components",
+			devfile: `
+schemaVersion: 2.2.2
+metadata:
+  name: editor
+  attributes:
+    version: latest
+    publisher: acme
+`,
+			valid: false,
+		},
+		{
+			name: "Missing container image",
+			devfile: `
+schemaVersion: 2.2.2
+metadata:
+  name: editor
+  attributes:
+    version: latest
+    publisher: acme
+components:
+  - name: editor
+    container:
+      memoryLimit: 1Gi
+`,
+			valid: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var devfile map[string]interface{}
+			err := yaml.Unmarshal([]byte(testCase.devfile), &devfile)
+			assert.NoError(t, err)
+
+			err = validateEditorDefinition(devfile)
+			if testCase.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
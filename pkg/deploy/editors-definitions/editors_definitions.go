@@ -18,7 +18,9 @@ import (
 	"path/filepath"
 	"regexp"
 
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
 	"github.com/eclipse-che/che-operator/pkg/common/diffs"
+	defaults "github.com/eclipse-che/che-operator/pkg/common/operator-defaults"
 	"github.com/eclipse-che/che-operator/pkg/common/reconciler"
 
 	"github.com/eclipse-che/che-operator/pkg/common/utils"
@@ -66,11 +68,18 @@ func (p *EditorsDefinitionsReconciler) Finalize(ctx *chetypes.DeployContext) boo
 }
 
 func (p *EditorsDefinitionsReconciler) syncEditors(ctx *chetypes.DeployContext) (bool, error) {
-	editorDefinitions, err := readEditorDefinitions()
+	editorDefinitions, err := readEditorDefinitions(ctx.CheCluster)
 	if err != nil {
 		return false, err
 	}
 
+	customEditorDefinitions, hiddenEditors, err := readCustomEditorDefinitions(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	editorDefinitions = mergeEditorDefinitions(editorDefinitions, customEditorDefinitions, hiddenEditors)
+
 	done, err := syncEditorDefinitions(ctx, editorDefinitions)
 	if !done {
 		return false, err
@@ -79,7 +88,7 @@ func (p *EditorsDefinitionsReconciler) syncEditors(ctx *chetypes.DeployContext)
 	return true, nil
 }
 
-func readEditorDefinitions() (map[string][]byte, error) {
+func readEditorDefinitions(cheCluster *chev2.CheCluster) (map[string][]byte, error) {
 	editorDefinitions := make(map[string][]byte)
 
 	files, err := os.ReadDir(editorsDefinitionsDir)
@@ -107,7 +116,12 @@ func readEditorDefinitions() (map[string][]byte, error) {
 				continue
 			}
 
-			updateEditorDefinitionImages(devfile)
+			if err = validateEditorDefinition(devfile); err != nil {
+				logger.Error(err, "Invalid editor definition", "file", fileName)
+				continue
+			}
+
+			updateEditorDefinitionImages(cheCluster, devfile)
 			editorContent, err = yaml.Marshal(devfile)
 			if err != nil {
 				return editorDefinitions, err
@@ -120,7 +134,7 @@ func readEditorDefinitions() (map[string][]byte, error) {
 	return editorDefinitions, nil
 }
 
-func updateEditorDefinitionImages(devfile map[string]interface{}) {
+func updateEditorDefinitionImages(cheCluster *chev2.CheCluster, devfile map[string]interface{}) {
 	notAllowedCharsReg, _ := regexp.Compile("[^a-zA-Z0-9]+")
 
 	metadata := devfile["metadata"].(map[string]interface{})
@@ -139,6 +153,10 @@ func updateEditorDefinitionImages(devfile map[string]interface{}) {
 			if imageEnvValue, ok := os.LookupEnv(imageEnvName); ok {
 				container["image"] = imageEnvValue
 			}
+
+			if image, ok := container["image"].(string); ok {
+				container["image"] = defaults.ApplyImageMirrors(cheCluster, image)
+			}
 		}
 	}
 }
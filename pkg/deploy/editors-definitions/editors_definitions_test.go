@@ -16,6 +16,7 @@ import (
 	"os"
 	"testing"
 
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
 	"github.com/eclipse-che/che-operator/pkg/common/test"
 	"github.com/stretchr/testify/assert"
 	"sigs.k8s.io/yaml"
@@ -32,7 +33,7 @@ func TestReadEditorDefinitions(t *testing.T) {
 		_ = os.Setenv("RELATED_IMAGE_editor_definition_che_code_2022_1_component_a", "")
 	}()
 
-	editorDefinitions, err := readEditorDefinitions()
+	editorDefinitions, err := readEditorDefinitions(&chev2.CheCluster{})
 	assert.NoError(t, err)
 	assert.NotEmpty(t, editorDefinitions)
 	assert.Equal(t, 2, len(editorDefinitions))
@@ -68,7 +69,7 @@ func TestReadEditorDefinitions(t *testing.T) {
 func TestSyncEditorDefinitions(t *testing.T) {
 	ctx := test.NewCtxBuilder().Build()
 
-	editorDefinitions, err := readEditorDefinitions()
+	editorDefinitions, err := readEditorDefinitions(&chev2.CheCluster{})
 	assert.NoError(t, err)
 	assert.NotEmpty(t, editorDefinitions)
 	assert.Len(t, editorDefinitions, 2)
@@ -0,0 +1,310 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package externalregistries
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/eclipse-che/che-operator/pkg/common/chetypes"
+	"github.com/eclipse-che/che-operator/pkg/common/reconciler"
+	"github.com/eclipse-che/che-operator/pkg/deploy"
+	"github.com/eclipse-che/che-operator/pkg/deploy/tls"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	DevfileRegistryType = "DevfileRegistry"
+	PluginRegistryType  = "PluginRegistry"
+
+	// probePeriod is the period to probe each registry
+	probePeriod = 2 * time.Minute
+	// pendingProbeCheckPeriod is the period to check for the first probes of the registries
+	pendingProbeCheckPeriod = 5 * time.Second
+	// failureThreshold is the number of consecutive failed probes after which a healthy registry becomes unhealthy
+	failureThreshold = 3
+)
+
+var logger = ctrl.Log.WithName("externalregistries")
+
+// externalRegistry is an external devfile or plug-in registry.
+type externalRegistry struct {
+	chev2.ExternalRegistryOptions
+	Type string
+	Url  string
+}
+
+// registryProbe is the result of the last probes of a registry.
+type registryProbe struct {
+	// probing is true while the registry is being probed
+	probing       bool
+	lastProbeTime time.Time
+	failures      int
+	healthy       bool
+	message       string
+}
+
+// ExternalRegistriesReconciler periodically probes the external devfile and plug-in registries
+// and reports their health in the CheCluster status.
+// The registries are probed in the background, so that the reconcile never waits for them.
+type ExternalRegistriesReconciler struct {
+	reconciler.Reconcilable
+
+	healthChecker RegistryHealthChecker
+
+	mu sync.Mutex
+	// probes are kept by registry type and URL.
+	// Reset on operator restart, registries are probed again then.
+	probes map[string]*registryProbe
+
+	// runAsync runs the probe, replaced in tests to run it synchronously
+	runAsync func(func())
+}
+
+func NewExternalRegistriesReconciler() *ExternalRegistriesReconciler {
+	return &ExternalRegistriesReconciler{
+		healthChecker: NewRegistryHealthChecker(),
+		probes:        map[string]*registryProbe{},
+		runAsync:      func(f func()) { go f() },
+	}
+}
+
+func (r *ExternalRegistriesReconciler) Reconcile(ctx *chetypes.DeployContext) (reconcile.Result, bool, error) {
+	registries := getExternalRegistries(ctx.CheCluster)
+
+	r.startProbes(ctx, registries)
+
+	r.mu.Lock()
+	pending := false
+	statuses := make([]chev2.ExternalRegistryStatus, 0, len(registries))
+	for _, registry := range registries {
+		probe := r.probes[getProbeKey(registry)]
+		if probe.lastProbeTime.IsZero() {
+			// Not probed yet
+			pending = true
+			continue
+		}
+
+		statuses = append(statuses, chev2.ExternalRegistryStatus{
+			Url:           registry.Url,
+			Type:          registry.Type,
+			Healthy:       probe.healthy,
+			Hidden:        !probe.healthy && registry.HideWhenUnhealthy,
+			Message:       probe.message,
+			LastProbeTime: &metav1.Time{Time: probe.lastProbeTime},
+		})
+	}
+	r.mu.Unlock()
+
+	if len(statuses) == 0 {
+		statuses = nil
+	}
+
+	if err := updateExternalRegistriesStatus(ctx, statuses); err != nil {
+		return reconcile.Result{}, false, err
+	}
+
+	if pending {
+		return reconcile.Result{RequeueAfter: pendingProbeCheckPeriod}, true, nil
+	} else if len(registries) == 0 {
+		return reconcile.Result{}, true, nil
+	}
+	return reconcile.Result{RequeueAfter: probePeriod}, true, nil
+}
+
+func (r *ExternalRegistriesReconciler) Finalize(_ *chetypes.DeployContext) bool {
+	return true
+}
+
+// startProbes starts probing the registries, which have not been probed for the probe period.
+// The credentials and the CA certificates are read beforehand, the registries are probed through the configured proxy.
+func (r *ExternalRegistriesReconciler) startProbes(ctx *chetypes.DeployContext, registries []externalRegistry) {
+	var toProbe []externalRegistry
+
+	r.mu.Lock()
+	probes := make(map[string]*registryProbe, len(registries))
+	for _, registry := range registries {
+		key := getProbeKey(registry)
+
+		probe, ok := r.probes[key]
+		if !ok {
+			probe = &registryProbe{}
+		}
+
+		if !probe.probing && time.Since(probe.lastProbeTime) >= probePeriod {
+			probe.probing = true
+			toProbe = append(toProbe, registry)
+		}
+
+		probes[key] = probe
+	}
+
+	// Registries removed from the CheCluster are forgotten
+	r.probes = probes
+	r.mu.Unlock()
+
+	if len(toProbe) == 0 {
+		return
+	}
+
+	trustedCABundle, trustedCABundleErr := tls.GetMergedCABundle(ctx)
+	proxy := *ctx.Proxy
+
+	for _, registry := range toProbe {
+		probe := probes[getProbeKey(registry)]
+
+		credentials, credentialsErr := getCredentials(ctx, registry)
+		caBundle, caBundleErr := getCABundle(ctx, registry)
+
+		r.runAsync(func() {
+			var err error
+			switch {
+			case trustedCABundleErr != nil:
+				err = trustedCABundleErr
+			case credentialsErr != nil:
+				err = credentialsErr
+			case caBundleErr != nil:
+				err = caBundleErr
+			default:
+				err = r.healthChecker.Check(registry.Url, credentials, append(trustedCABundle, caBundle...), &proxy)
+			}
+
+			r.mu.Lock()
+			defer r.mu.Unlock()
+
+			firstProbe := probe.lastProbeTime.IsZero()
+
+			probe.probing = false
+			// The status keeps the time with a second precision
+			probe.lastProbeTime = time.Now().UTC().Truncate(time.Second)
+			if err == nil {
+				probe.failures = 0
+				probe.healthy = true
+				probe.message = ""
+			} else {
+				probe.failures++
+				probe.message = err.Error()
+				// The first probe decides the initial health
+				if firstProbe || probe.failures >= failureThreshold {
+					probe.healthy = false
+				}
+			}
+		})
+	}
+}
+
+func getCredentials(ctx *chetypes.DeployContext, registry externalRegistry) (*RegistryCredentials, error) {
+	if registry.CredentialsSecretName == "" {
+		return nil, nil
+	}
+
+	secret := &corev1.Secret{}
+	if err := getObject(ctx, registry.CredentialsSecretName, secret); err != nil {
+		return nil, err
+	}
+
+	credentials := &RegistryCredentials{
+		Token:    string(secret.Data["token"]),
+		Username: string(secret.Data["username"]),
+		Password: string(secret.Data["password"]),
+	}
+	if credentials.Token == "" && (credentials.Username == "" || credentials.Password == "") {
+		return nil, fmt.Errorf("secret %s must contain either the token key or the username and password keys", registry.CredentialsSecretName)
+	}
+	return credentials, nil
+}
+
+// getCABundle returns the CA certificates of the registry, from all keys of the ConfigMap.
+func getCABundle(ctx *chetypes.DeployContext, registry externalRegistry) ([]byte, error) {
+	if registry.CABundleConfigMapName == "" {
+		return nil, nil
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := getObject(ctx, registry.CABundleConfigMapName, cm); err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(cm.Data))
+	for key := range cm.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	certificates := make([]string, 0, len(keys))
+	for _, key := range keys {
+		certificates = append(certificates, cm.Data[key])
+	}
+	return []byte("\n" + strings.Join(certificates, "\n")), nil
+}
+
+func getObject(ctx *chetypes.DeployContext, name string, obj client.Object) error {
+	exists, err := ctx.ClusterAPI.ClientWrapper.GetIgnoreNotFound(
+		context.TODO(),
+		types.NamespacedName{Name: name, Namespace: ctx.CheCluster.Namespace},
+		obj,
+	)
+	if err != nil {
+		return err
+	} else if !exists {
+		return fmt.Errorf("%s %s not found", reflect.TypeOf(obj).Elem().Name(), name)
+	}
+	return nil
+}
+
+func getProbeKey(registry externalRegistry) string {
+	return registry.Type + "|" + registry.Url
+}
+
+func updateExternalRegistriesStatus(ctx *chetypes.DeployContext, statuses []chev2.ExternalRegistryStatus) error {
+	if reflect.DeepEqual(ctx.CheCluster.Status.ExternalRegistries, statuses) {
+		return nil
+	}
+
+	ctx.CheCluster.Status.ExternalRegistries = statuses
+	return deploy.UpdateCheCRStatus(ctx, "externalRegistries", fmt.Sprintf("%d registries", len(statuses)))
+}
+
+func getExternalRegistries(cheCluster *chev2.CheCluster) []externalRegistry {
+	var registries []externalRegistry
+	for _, registry := range cheCluster.Spec.Components.DevfileRegistry.ExternalDevfileRegistries {
+		if registry.Url != "" {
+			registries = append(registries, externalRegistry{
+				ExternalRegistryOptions: registry.ExternalRegistryOptions,
+				Type:                    DevfileRegistryType,
+				Url:                     registry.Url,
+			})
+		}
+	}
+	for _, registry := range cheCluster.Spec.Components.PluginRegistry.ExternalPluginRegistries {
+		if registry.Url != "" {
+			registries = append(registries, externalRegistry{
+				ExternalRegistryOptions: registry.ExternalRegistryOptions,
+				Type:                    PluginRegistryType,
+				Url:                     registry.Url,
+			})
+		}
+	}
+	return registries
+}
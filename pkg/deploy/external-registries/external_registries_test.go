@@ -0,0 +1,257 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package externalregistries
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/eclipse-che/che-operator/pkg/common/chetypes"
+	"github.com/eclipse-che/che-operator/pkg/common/test"
+	"github.com/eclipse-che/che-operator/pkg/deploy/tls"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type fakeRegistryHealthChecker struct {
+	// unhealthy registries by URL
+	unhealthy map[string]bool
+	// credentials received by URL
+	credentials map[string]*RegistryCredentials
+	// CA bundles received by URL
+	caBundles map[string]string
+}
+
+func (c *fakeRegistryHealthChecker) Check(url string, credentials *RegistryCredentials, caBundle []byte, _ *chetypes.Proxy) error {
+	c.credentials[url] = credentials
+	c.caBundles[url] = string(caBundle)
+	if c.unhealthy[url] {
+		return fmt.Errorf("registry %s is not available", url)
+	}
+	return nil
+}
+
+func TestExternalRegistriesReconciler(t *testing.T) {
+	ctx := test.NewCtxBuilder().WithCheCluster(
+		&chev2.CheCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "eclipse-che",
+				Namespace: "eclipse-che",
+			},
+			Spec: chev2.CheClusterSpec{
+				Components: chev2.CheClusterComponents{
+					DevfileRegistry: chev2.DevfileRegistry{
+						ExternalDevfileRegistries: []chev2.ExternalDevfileRegistry{
+							{
+								Url: "https://registry-a.example.com",
+							},
+							{
+								Url: "https://registry-b.example.com",
+								ExternalRegistryOptions: chev2.ExternalRegistryOptions{
+									DisplayName:           "Registry B",
+									Priority:              10,
+									CredentialsSecretName: "registry-b-credentials",
+									HideWhenUnhealthy:     true,
+								},
+							},
+						},
+					},
+					PluginRegistry: chev2.PluginRegistry{
+						ExternalPluginRegistries: []chev2.ExternalPluginRegistry{
+							{
+								Url: "https://plugins.example.com",
+								ExternalRegistryOptions: chev2.ExternalRegistryOptions{
+									CredentialsSecretName: "missing-credentials",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	).WithObjects(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      tls.CheMergedCABundleCertsCMName,
+				Namespace: "eclipse-che",
+			},
+			Data: map[string]string{
+				tls.CheMergedCABundleCertsCMKey: "trusted-certificates",
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "registry-b-credentials",
+				Namespace: "eclipse-che",
+			},
+			Data: map[string][]byte{
+				"token": []byte("secret-token"),
+			},
+		},
+	).Build()
+
+	healthChecker := &fakeRegistryHealthChecker{
+		unhealthy:   map[string]bool{},
+		credentials: map[string]*RegistryCredentials{},
+		caBundles:   map[string]string{},
+	}
+
+	reconciler := NewExternalRegistriesReconciler()
+	reconciler.healthChecker = healthChecker
+	reconciler.runAsync = func(f func()) { f() }
+	test.EnsureReconcile(t, ctx, reconciler.Reconcile)
+
+	statuses := ctx.CheCluster.Status.ExternalRegistries
+	assert.Len(t, statuses, 3)
+	assert.Equal(t, DevfileRegistryType, statuses[0].Type)
+	assert.True(t, statuses[0].Healthy)
+	assert.True(t, statuses[1].Healthy)
+	assert.Equal(t, "secret-token", healthChecker.credentials["https://registry-b.example.com"].Token)
+	assert.Equal(t, "trusted-certificates", healthChecker.caBundles["https://registry-a.example.com"])
+
+	// The credentials Secret does not exist
+	assert.Equal(t, PluginRegistryType, statuses[2].Type)
+	assert.False(t, statuses[2].Healthy)
+	assert.False(t, statuses[2].Hidden)
+	assert.Contains(t, statuses[2].Message, "missing-credentials not found")
+
+	// Registry B fails, it becomes unhealthy after several consecutive failed probes
+	healthChecker.unhealthy["https://registry-b.example.com"] = true
+	for i := 1; i <= failureThreshold; i++ {
+		reconciler.probes[DevfileRegistryType+"|https://registry-b.example.com"].lastProbeTime = time.Now().Add(-probePeriod - time.Second)
+		test.EnsureReconcile(t, ctx, reconciler.Reconcile)
+
+		assert.Equal(t, i < failureThreshold, ctx.CheCluster.Status.ExternalRegistries[1].Healthy)
+		assert.NotEmpty(t, ctx.CheCluster.Status.ExternalRegistries[1].Message)
+	}
+	assert.True(t, ctx.CheCluster.Status.ExternalRegistries[1].Hidden)
+
+	// Registry B recovers
+	delete(healthChecker.unhealthy, "https://registry-b.example.com")
+	reconciler.probes[DevfileRegistryType+"|https://registry-b.example.com"].lastProbeTime = time.Now().Add(-probePeriod - time.Second)
+	test.EnsureReconcile(t, ctx, reconciler.Reconcile)
+
+	assert.True(t, ctx.CheCluster.Status.ExternalRegistries[1].Healthy)
+	assert.False(t, ctx.CheCluster.Status.ExternalRegistries[1].Hidden)
+	assert.Empty(t, ctx.CheCluster.Status.ExternalRegistries[1].Message)
+
+	// Remove all registries
+	ctx.CheCluster.Spec.Components.DevfileRegistry.ExternalDevfileRegistries = nil
+	ctx.CheCluster.Spec.Components.PluginRegistry.ExternalPluginRegistries = nil
+	assert.NoError(t, ctx.ClusterAPI.Client.Update(context.TODO(), ctx.CheCluster))
+	test.EnsureReconcile(t, ctx, reconciler.Reconcile)
+
+	assert.Empty(t, ctx.CheCluster.Status.ExternalRegistries)
+	assert.Empty(t, reconciler.probes)
+}
+
+func TestExternalRegistriesProbedInBackground(t *testing.T) {
+	ctx := test.NewCtxBuilder().WithCheCluster(
+		&chev2.CheCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "eclipse-che",
+				Namespace: "eclipse-che",
+			},
+			Spec: chev2.CheClusterSpec{
+				Components: chev2.CheClusterComponents{
+					DevfileRegistry: chev2.DevfileRegistry{
+						ExternalDevfileRegistries: []chev2.ExternalDevfileRegistry{
+							{Url: "https://registry-a.example.com"},
+						},
+					},
+				},
+			},
+		},
+	).Build()
+
+	var probes []func()
+
+	reconciler := NewExternalRegistriesReconciler()
+	reconciler.healthChecker = &fakeRegistryHealthChecker{credentials: map[string]*RegistryCredentials{}, caBundles: map[string]string{}}
+	reconciler.runAsync = func(f func()) { probes = append(probes, f) }
+
+	// The reconcile doesn't wait for the probe
+	result, done, err := reconciler.Reconcile(ctx)
+	assert.NoError(t, err)
+	assert.True(t, done)
+	assert.Equal(t, pendingProbeCheckPeriod, result.RequeueAfter)
+	assert.Empty(t, ctx.CheCluster.Status.ExternalRegistries)
+	assert.Len(t, probes, 1)
+
+	// The probe is not started twice
+	_, _, err = reconciler.Reconcile(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, probes, 1)
+
+	probes[0]()
+
+	result, _, err = reconciler.Reconcile(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, probePeriod, result.RequeueAfter)
+	assert.Len(t, ctx.CheCluster.Status.ExternalRegistries, 1)
+	assert.True(t, ctx.CheCluster.Status.ExternalRegistries[0].Healthy)
+	assert.Len(t, probes, 1)
+}
+
+func TestRegistryHealthChecker(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/public":
+			w.WriteHeader(http.StatusOK)
+		case "/private":
+			if r.Header.Get("Authorization") != "Bearer secret-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	healthChecker := NewRegistryHealthChecker()
+
+	// The server certificate is not trusted
+	assert.Error(t, healthChecker.Check(server.URL+"/public", nil, nil, nil))
+
+	assert.NoError(t, healthChecker.Check(server.URL+"/public", nil, caBundle, nil))
+	assert.Error(t, healthChecker.Check(server.URL+"/unknown", nil, caBundle, nil))
+
+	assert.Error(t, healthChecker.Check(server.URL+"/private", nil, caBundle, nil))
+	assert.NoError(t, healthChecker.Check(server.URL+"/private", &RegistryCredentials{Token: "secret-token"}, caBundle, nil))
+
+	assert.Error(t, healthChecker.Check(server.URL+"/public", nil, []byte("invalid"), nil))
+}
+
+func TestRegistryHealthCheckerWithProxy(t *testing.T) {
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The proxy receives the absolute URL of the registry
+		if r.URL.Host != "registry.invalid" {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer proxy.Close()
+
+	healthChecker := NewRegistryHealthChecker()
+
+	assert.NoError(t, healthChecker.Check("http://registry.invalid/public", nil, nil, &chetypes.Proxy{HttpProxy: proxy.URL}))
+	assert.Error(t, healthChecker.Check("http://registry.invalid/public", nil, nil, &chetypes.Proxy{HttpProxy: proxy.URL, NoProxy: "registry.invalid"}))
+}
@@ -0,0 +1,90 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package externalregistries
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/eclipse-che/che-operator/pkg/common/chetypes"
+	"github.com/eclipse-che/che-operator/pkg/deploy"
+)
+
+// RegistryCredentials are the credentials to access a registry,
+// either a bearer token or a username and password.
+type RegistryCredentials struct {
+	Token    string
+	Username string
+	Password string
+}
+
+// RegistryHealthChecker probes the registry URL.
+type RegistryHealthChecker interface {
+	// Check returns an error if the registry does not respond with a successful status code.
+	// The registry certificate is verified with the system and the given PEM-encoded CA certificates,
+	// the registry is accessed through the given proxy unless it is empty.
+	Check(url string, credentials *RegistryCredentials, caBundle []byte, proxy *chetypes.Proxy) error
+}
+
+type registryHealthChecker struct {
+	timeout time.Duration
+}
+
+func NewRegistryHealthChecker() RegistryHealthChecker {
+	return &registryHealthChecker{
+		timeout: time.Second * 10,
+	}
+}
+
+func (c *registryHealthChecker) Check(registryUrl string, credentials *RegistryCredentials, caBundle []byte, proxy *chetypes.Proxy) error {
+	transport, err := deploy.NewHTTPTransport(caBundle, proxy)
+	if err != nil {
+		return err
+	}
+
+	httpClient := &http.Client{
+		Transport: transport,
+		Timeout:   c.timeout,
+	}
+
+	request, err := http.NewRequest(http.MethodGet, registryUrl, nil)
+	if err != nil {
+		return err
+	}
+
+	if credentials != nil {
+		if credentials.Token != "" {
+			request.Header.Set("Authorization", "Bearer "+credentials.Token)
+		} else {
+			request.SetBasicAuth(credentials.Username, credentials.Password)
+		}
+	}
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := response.Body.Close(); err != nil {
+			logger.Error(err, "unable to close response body")
+		}
+	}()
+
+	if response.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("unexpected status code %d from %s", response.StatusCode, registryUrl)
+	}
+
+	return nil
+}
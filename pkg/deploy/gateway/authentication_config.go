@@ -0,0 +1,129 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package gateway
+
+import (
+	"github.com/eclipse-che/che-operator/pkg/common/chetypes"
+	"github.com/eclipse-che/che-operator/pkg/common/infrastructure"
+	"github.com/eclipse-che/che-operator/pkg/deploy"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// AuthenticationConfigCMName is the ConfigMap with the API server structured authentication configuration
+	// mapping the OIDC token claims the same way as Che, to pass to the cluster API server with the `--authentication-config` flag.
+	AuthenticationConfigCMName = "che-authentication-config"
+	AuthenticationConfigCMKey  = "authentication-config.yaml"
+)
+
+// authenticationConfiguration is the apiserver.config.k8s.io/v1beta1 AuthenticationConfiguration,
+// the API server types are not a dependency of the operator.
+type authenticationConfiguration struct {
+	metav1.TypeMeta `json:",inline"`
+	JWT             []jwtAuthenticator `json:"jwt"`
+}
+
+type jwtAuthenticator struct {
+	Issuer        jwtIssuer        `json:"issuer"`
+	ClaimMappings jwtClaimMappings `json:"claimMappings"`
+}
+
+type jwtIssuer struct {
+	URL                  string   `json:"url"`
+	Audiences            []string `json:"audiences"`
+	CertificateAuthority string   `json:"certificateAuthority,omitempty"`
+}
+
+type jwtClaimMappings struct {
+	Username jwtPrefixedClaimOrExpression  `json:"username"`
+	Groups   *jwtPrefixedClaimOrExpression `json:"groups,omitempty"`
+}
+
+type jwtPrefixedClaimOrExpression struct {
+	Claim      string  `json:"claim,omitempty"`
+	Prefix     *string `json:"prefix,omitempty"`
+	Expression string  `json:"expression,omitempty"`
+}
+
+// isAuthenticationConfigEnabled returns true if the claim mappings are published as a structured authentication configuration.
+// On OpenShift, the cluster `Authentication` resource configures the API server.
+func isAuthenticationConfigEnabled(ctx *chetypes.DeployContext) bool {
+	return !infrastructure.IsOpenShift() && ctx.CheCluster.Spec.Networking.Auth.ClaimMappings != nil && ctx.Authentication.IssuerURL != ""
+}
+
+func getAuthenticationConfigSpec(ctx *chetypes.DeployContext) (*corev1.ConfigMap, error) {
+	authentication := ctx.Authentication
+
+	claimMappings := jwtClaimMappings{
+		Username: getPrefixedClaimOrExpression(authentication.UsernameClaim, authentication.UsernamePrefix, authentication.UsernameExpression),
+	}
+	// The API server requires a username claim, `sub` is its default
+	if claimMappings.Username.Claim == "" && claimMappings.Username.Expression == "" {
+		claimMappings.Username = getPrefixedClaimOrExpression("sub", "", "")
+	}
+	if authentication.GroupsClaim != "" || authentication.GroupsExpression != "" {
+		groups := getPrefixedClaimOrExpression(authentication.GroupsClaim, authentication.GroupsPrefix, authentication.GroupsExpression)
+		claimMappings.Groups = &groups
+	}
+
+	config := authenticationConfiguration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "apiserver.config.k8s.io/v1beta1",
+			Kind:       "AuthenticationConfiguration",
+		},
+		JWT: []jwtAuthenticator{
+			{
+				Issuer: jwtIssuer{
+					URL:                  authentication.IssuerURL,
+					Audiences:            []string{authentication.ClientId},
+					CertificateAuthority: authentication.IssuerCA,
+				},
+				ClaimMappings: claimMappings,
+			},
+		},
+	}
+
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      AuthenticationConfigCMName,
+			Namespace: ctx.CheCluster.Namespace,
+			Labels:    deploy.GetLabels(GatewayServiceName),
+		},
+		Data: map[string]string{
+			AuthenticationConfigCMKey: string(data),
+		},
+	}, nil
+}
+
+// getPrefixedClaimOrExpression returns the claim mapping of the API server,
+// which requires the prefix to be set along with a claim and not to be set along with an expression.
+func getPrefixedClaimOrExpression(claim string, prefix string, expression string) jwtPrefixedClaimOrExpression {
+	if expression != "" {
+		return jwtPrefixedClaimOrExpression{Expression: expression}
+	}
+	if claim != "" {
+		return jwtPrefixedClaimOrExpression{Claim: claim, Prefix: &prefix}
+	}
+	return jwtPrefixedClaimOrExpression{}
+}
@@ -108,6 +108,7 @@ func syncAll(deployContext *chetypes.DeployContext) (bool, error) {
 	}
 
 	if oauthSecret, err := getGatewaySecretSpec(deployContext); err == nil {
+		setOauthProxyClientSecret(deployContext, oauthSecret)
 		if done, err := deploy.Sync(deployContext, oauthSecret, secretDiffOpts); !done {
 			return done, err
 		}
@@ -120,6 +121,24 @@ func syncAll(deployContext *chetypes.DeployContext) (bool, error) {
 		return false, err
 	}
 
+	if err := syncOauthProxyTLSVerificationCondition(deployContext); err != nil {
+		return false, err
+	}
+
+	if isAuthenticationConfigEnabled(deployContext) {
+		if authenticationConfig, err := getAuthenticationConfigSpec(deployContext); err == nil {
+			if done, err := deploy.Sync(deployContext, authenticationConfig, configMapDiffOpts); !done {
+				return done, err
+			}
+		} else {
+			return false, err
+		}
+	} else {
+		if done, err := deploy.DeleteNamespacedObject(deployContext, AuthenticationConfigCMName, &corev1.ConfigMap{}); !done {
+			return done, err
+		}
+	}
+
 	kubeRbacProxyConfig := getGatewayKubeRbacProxyConfigSpec(instance)
 	if done, err := deploy.Sync(deployContext, &kubeRbacProxyConfig, configMapDiffOpts); !done {
 		return done, err
@@ -180,7 +199,11 @@ func getGatewaySecretSpec(deployContext *chetypes.DeployContext) (*corev1.Secret
 			logrus.Info("che-gateway-secret found, but does not contain `cookie_secret` value. Regenerating...")
 			return generateOauthSecretSpec(deployContext), nil
 		}
-		return secret, nil
+
+		// keep the existing secrets, the object read from the cluster can't be used as a blueprint
+		blueprint := generateOauthSecretSpec(deployContext)
+		blueprint.Data = secret.Data
+		return blueprint, nil
 	} else if err == nil && !exists {
 		return generateOauthSecretSpec(deployContext), nil
 	} else {
@@ -654,6 +677,10 @@ func getVolumesSpec(instance *chev2.CheCluster) []corev1.Volume {
 		getOauthProxyConfigVolume(),
 		getKubeRbacProxyConfigVolume())
 
+	if isOauthProxySecureModeEnabled(instance) || isKubeRbacProxyOIDCEnabled(instance) {
+		volumes = append(volumes, getIdentityProviderCAVolume())
+	}
+
 	if instance.IsAccessTokenConfigured() {
 		volumes = append(volumes, corev1.Volume{
 			Name: "header-rewrite-traefik-plugin",
@@ -23,6 +23,7 @@ import (
 	chev2 "github.com/eclipse-che/che-operator/api/v2"
 	"github.com/eclipse-che/che-operator/pkg/common/chetypes"
 	"github.com/eclipse-che/che-operator/pkg/common/constants"
+	"github.com/eclipse-che/che-operator/pkg/common/infrastructure"
 	defaults "github.com/eclipse-che/che-operator/pkg/common/operator-defaults"
 	"github.com/eclipse-che/che-operator/pkg/common/test"
 	"github.com/stretchr/testify/assert"
@@ -356,3 +357,106 @@ func TestKubeRbacProxyLogLevelDefault(t *testing.T) {
 	assert.Equal(t, constants.GatewayAuthorizationContainerName, containers[3].Name)
 	assert.Equal(t, "--v=0", containers[3].Args[3])
 }
+
+func TestKubeRbacProxyOIDCArgs(t *testing.T) {
+	checluster := &chev2.CheCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "eclipse-che",
+			Namespace: "eclipse-che",
+		},
+		Spec: chev2.CheClusterSpec{
+			Networking: chev2.CheClusterSpecNetworking{
+				Auth: chev2.Auth{
+					IdentityProviderURL: "https://oidc.example.com",
+					OAuthClientName:     "che-client",
+					ClaimMappings: &chev2.ClaimMappings{
+						Username: &chev2.ClaimMapping{Claim: "preferred_username", Prefix: "oidc:"},
+						Groups:   &chev2.ClaimMapping{Claim: "groups"},
+					},
+				},
+			},
+		},
+	}
+	infrastructure.InitializeForTesting(infrastructure.Kubernetes)
+	defer infrastructure.InitializeForTesting(infrastructure.OpenShiftV4)
+
+	ctx := test.NewCtxBuilder().WithCheCluster(checluster).Build()
+	ctx.Authentication.UsernameClaim = "preferred_username"
+	ctx.Authentication.UsernamePrefix = "oidc:"
+	ctx.Authentication.GroupsClaim = "groups"
+
+	// TokenReview API is used by default
+	container := getKubeRbacProxyContainerSpec(ctx)
+	for _, arg := range container.Args {
+		assert.NotContains(t, arg, "--oidc-")
+	}
+
+	ctx.CheCluster.Spec.Networking.Auth.Gateway.KubeRbacProxy = &chev2.KubeRbacProxy{OIDCAuthentication: true}
+	container = getKubeRbacProxyContainerSpec(ctx)
+	assert.Contains(t, container.Args, "--oidc-issuer=https://oidc.example.com")
+	assert.Contains(t, container.Args, "--oidc-clientID=che-client")
+	assert.Contains(t, container.Args, "--oidc-username-claim=preferred_username")
+	assert.Contains(t, container.Args, "--oidc-username-prefix=oidc:")
+	assert.Contains(t, container.Args, "--oidc-groups-claim=groups")
+	assert.Contains(t, getVolumesSpec(ctx.CheCluster), getIdentityProviderCAVolume())
+
+	// kube-rbac-proxy can't evaluate claim expressions
+	ctx.CheCluster.Spec.Networking.Auth.ClaimMappings.Groups = &chev2.ClaimMapping{Expression: "claims.roles"}
+	container = getKubeRbacProxyContainerSpec(ctx)
+	for _, arg := range container.Args {
+		assert.NotContains(t, arg, "--oidc-")
+	}
+}
+
+func TestAuthenticationConfig(t *testing.T) {
+	checluster := &chev2.CheCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "eclipse-che",
+			Namespace: "eclipse-che",
+		},
+		Spec: chev2.CheClusterSpec{
+			Networking: chev2.CheClusterSpecNetworking{
+				Auth: chev2.Auth{
+					IdentityProviderURL: "https://oidc.example.com",
+					OAuthClientName:     "che-client",
+					ClaimMappings: &chev2.ClaimMappings{
+						Username: &chev2.ClaimMapping{Claim: "preferred_username", Prefix: "oidc:"},
+						Groups:   &chev2.ClaimMapping{Expression: "claims.roles"},
+					},
+				},
+			},
+		},
+	}
+	infrastructure.InitializeForTesting(infrastructure.Kubernetes)
+	defer infrastructure.InitializeForTesting(infrastructure.OpenShiftV4)
+
+	ctx := test.NewCtxBuilder().WithCheCluster(checluster).Build()
+	ctx.Authentication.IssuerURL = "https://oidc.example.com"
+	ctx.Authentication.ClientId = "che-client"
+	ctx.Authentication.UsernameClaim = "preferred_username"
+	ctx.Authentication.UsernamePrefix = "oidc:"
+	ctx.Authentication.GroupsExpression = "claims.roles"
+
+	_, err := syncAll(ctx)
+	assert.Nil(t, err)
+
+	cm := &corev1.ConfigMap{}
+	err = ctx.ClusterAPI.Client.Get(context.TODO(), types.NamespacedName{Name: AuthenticationConfigCMName, Namespace: "eclipse-che"}, cm)
+	assert.Nil(t, err)
+
+	config := &authenticationConfiguration{}
+	err = yaml.Unmarshal([]byte(cm.Data[AuthenticationConfigCMKey]), config)
+	assert.Nil(t, err)
+	assert.Equal(t, "AuthenticationConfiguration", config.Kind)
+	assert.Len(t, config.JWT, 1)
+	assert.Equal(t, "https://oidc.example.com", config.JWT[0].Issuer.URL)
+	assert.Equal(t, []string{"che-client"}, config.JWT[0].Issuer.Audiences)
+	assert.Equal(t, jwtPrefixedClaimOrExpression{Claim: "preferred_username", Prefix: ptr.To("oidc:")}, config.JWT[0].ClaimMappings.Username)
+	assert.Equal(t, &jwtPrefixedClaimOrExpression{Expression: "claims.roles"}, config.JWT[0].ClaimMappings.Groups)
+
+	// The configuration is removed along with the claim mappings
+	ctx.CheCluster.Spec.Networking.Auth.ClaimMappings = nil
+	_, err = syncAll(ctx)
+	assert.Nil(t, err)
+	assert.False(t, test.IsObjectExists(ctx.ClusterAPI.Client, types.NamespacedName{Name: AuthenticationConfigCMName, Namespace: "eclipse-che"}, &corev1.ConfigMap{}))
+}
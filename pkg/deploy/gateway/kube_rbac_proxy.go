@@ -16,6 +16,7 @@ import (
 	"strconv"
 
 	"github.com/eclipse-che/che-operator/pkg/common/chetypes"
+	"github.com/eclipse-che/che-operator/pkg/common/infrastructure"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
 	chev2 "github.com/eclipse-che/che-operator/api/v2"
@@ -60,22 +61,31 @@ func getKubeRbacProxyContainerSpec(ctx *chetypes.DeployContext) corev1.Container
 		logLevel = *ctx.CheCluster.Spec.Networking.Auth.Gateway.KubeRbacProxy.LogLevel
 	}
 
+	volumeMounts := []corev1.VolumeMount{
+		{
+			Name:      "kube-rbac-proxy-config",
+			MountPath: "/etc/kube-rbac-proxy",
+		},
+	}
+	if isKubeRbacProxyOIDCEnabled(ctx.CheCluster) {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      identityProviderCAVolumeName,
+			MountPath: identityProviderCAMountPath,
+			ReadOnly:  true,
+		})
+	}
+
 	return corev1.Container{
 		Name:            "kube-rbac-proxy",
 		Image:           image,
 		ImagePullPolicy: corev1.PullIfNotPresent,
-		Args: []string{
+		Args: append([]string{
 			"--insecure-listen-address=0.0.0.0:8089",
 			"--upstream=http://127.0.0.1:8090/ping",
 			"--config-file=/etc/kube-rbac-proxy/authorization-config.yaml",
 			"--v=" + strconv.FormatInt(int64(logLevel), 10),
-		},
-		VolumeMounts: []corev1.VolumeMount{
-			{
-				Name:      "kube-rbac-proxy-config",
-				MountPath: "/etc/kube-rbac-proxy",
-			},
-		},
+		}, getKubeRbacProxyOIDCArgs(ctx)...),
+		VolumeMounts: volumeMounts,
 		Resources: corev1.ResourceRequirements{
 			Limits: corev1.ResourceList{
 				corev1.ResourceMemory: resource.MustParse("512Mi"),
@@ -135,3 +145,45 @@ func getKubeRbacProxyConfigVolume() corev1.Volume {
 		},
 	}
 }
+
+// isKubeRbacProxyOIDCEnabled returns true if kube-rbac-proxy authenticates users with the OIDC tokens directly
+// instead of the TokenReview API, to apply the same claim mappings as Che server.
+// kube-rbac-proxy can't evaluate claim expressions, the API server evaluates them instead.
+func isKubeRbacProxyOIDCEnabled(cheCluster *chev2.CheCluster) bool {
+	kubeRbacProxy := cheCluster.Spec.Networking.Auth.Gateway.KubeRbacProxy
+	return !infrastructure.IsOpenShift() && kubeRbacProxy != nil && kubeRbacProxy.OIDCAuthentication && !hasClaimExpressions(cheCluster)
+}
+
+func hasClaimExpressions(cheCluster *chev2.CheCluster) bool {
+	claimMappings := cheCluster.Spec.Networking.Auth.ClaimMappings
+	return claimMappings != nil &&
+		((claimMappings.Username != nil && claimMappings.Username.Expression != "") ||
+			(claimMappings.Groups != nil && claimMappings.Groups.Expression != ""))
+}
+
+func getKubeRbacProxyOIDCArgs(ctx *chetypes.DeployContext) []string {
+	if !isKubeRbacProxyOIDCEnabled(ctx.CheCluster) {
+		return nil
+	}
+
+	args := []string{
+		"--oidc-issuer=" + ctx.Authentication.IssuerURL,
+		"--oidc-clientID=" + ctx.Authentication.ClientId,
+	}
+	if ctx.Authentication.UsernameClaim != "" {
+		args = append(args, "--oidc-username-claim="+ctx.Authentication.UsernameClaim)
+	}
+	if ctx.Authentication.UsernamePrefix != "" {
+		args = append(args, "--oidc-username-prefix="+ctx.Authentication.UsernamePrefix)
+	}
+	if ctx.Authentication.GroupsClaim != "" {
+		args = append(args, "--oidc-groups-claim="+ctx.Authentication.GroupsClaim)
+	}
+	if ctx.Authentication.GroupsPrefix != "" {
+		args = append(args, "--oidc-groups-prefix="+ctx.Authentication.GroupsPrefix)
+	}
+	if caFiles := getIdentityProviderCAFiles(ctx); len(caFiles) > 0 {
+		args = append(args, "--oidc-ca-file="+caFiles[0])
+	}
+	return args
+}
@@ -105,14 +105,12 @@ https_address = ""
 provider = "oidc"
 redirect_url = "https://%s/oauth/callback"
 oidc_issuer_url = "%s"
-insecure_oidc_skip_issuer_verification = true
-ssl_insecure_skip_verify = true
+%s
 upstreams = [
 	"http://127.0.0.1:8081/"
 ]
 client_id = "%s"
-client_secret = "%s"
-cookie_secret = "%s"
+%s
 cookie_expire = "%s"
 email_domains = "*"
 cookie_httponly = false
@@ -125,9 +123,9 @@ cookie_domains = "%s"
 `, GatewayServicePort,
 		ctx.CheHost,
 		ctx.Authentication.IssuerURL,
+		oauthProxyTLSConfig(ctx),
 		ctx.Authentication.ClientId,
-		string(ctx.Authentication.ClientSecret),
-		cookieSecret,
+		oauthProxySecretsConfig(ctx, cookieSecret),
 		cookieExpireAsString(ctx.CheCluster),
 		utils.Whitelist(ctx.CheHost),
 		utils.Whitelist(ctx.CheHost),
@@ -192,17 +190,26 @@ func getOauthProxyContainerSpec(ctx *chetypes.DeployContext) corev1.Container {
 		args = append(args, "--ping-path=/ping", "--exclude-logging-path=/ping")
 	}
 
+	volumeMounts := []corev1.VolumeMount{
+		{
+			Name:      "oauth-proxy-config",
+			MountPath: "/etc/oauth-proxy",
+		},
+	}
+	if isOauthProxySecureModeEnabled(ctx.CheCluster) {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      identityProviderCAVolumeName,
+			MountPath: identityProviderCAMountPath,
+			ReadOnly:  true,
+		})
+	}
+
 	return corev1.Container{
 		Name:            "oauth-proxy",
 		Image:           image,
 		ImagePullPolicy: corev1.PullIfNotPresent,
 		Args:            args,
-		VolumeMounts: []corev1.VolumeMount{
-			{
-				Name:      "oauth-proxy-config",
-				MountPath: "/etc/oauth-proxy",
-			},
-		},
+		VolumeMounts:    volumeMounts,
 		Resources: corev1.ResourceRequirements{
 			Limits: corev1.ResourceList{
 				corev1.ResourceMemory: resource.MustParse("512Mi"),
@@ -216,7 +223,7 @@ func getOauthProxyContainerSpec(ctx *chetypes.DeployContext) corev1.Container {
 		Ports: []corev1.ContainerPort{
 			{ContainerPort: GatewayServicePort, Protocol: "TCP"},
 		},
-		Env: []corev1.EnvVar{
+		Env: append([]corev1.EnvVar{
 			{
 				Name:  "http_proxy",
 				Value: ctx.Proxy.HttpProxy,
@@ -233,7 +240,7 @@ func getOauthProxyContainerSpec(ctx *chetypes.DeployContext) corev1.Container {
 				Name:  "CM_REVISION",
 				Value: configMapRevision,
 			},
-		},
+		}, getOauthProxySecretsEnv(ctx)...),
 		ReadinessProbe: &corev1.Probe{
 			ProbeHandler: corev1.ProbeHandler{
 				HTTPGet: &corev1.HTTPGetAction{
@@ -13,8 +13,17 @@
 package gateway
 
 import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/eclipse-che/che-operator/pkg/deploy/tls"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/ptr"
 
 	chev2 "github.com/eclipse-che/che-operator/api/v2"
@@ -122,3 +131,180 @@ func TestAccessTokenDefinedForKubernetesOauthProxyConfig(t *testing.T) {
 	assert.Contains(t, config, "pass_access_token = true")
 	assert.NotContains(t, config, "pass_authorization_header = true")
 }
+
+func TestInsecureKubernetesOauthProxyConfig(t *testing.T) {
+	ctx := test.NewCtxBuilder().Build()
+	ctx.Authentication.ClientSecret = []byte("client-secret")
+	infrastructure.InitializeForTesting(infrastructure.Kubernetes)
+
+	config := kubernetesOauthProxyConfig(ctx, "cookie-secret")
+	assert.Contains(t, config, "insecure_oidc_skip_issuer_verification = true")
+	assert.Contains(t, config, "ssl_insecure_skip_verify = true")
+	assert.Contains(t, config, "client_secret = \"client-secret\"")
+	assert.Contains(t, config, "cookie_secret = \"cookie-secret\"")
+	assert.NotContains(t, config, "provider_ca_files")
+}
+
+func TestSecureKubernetesOauthProxyConfig(t *testing.T) {
+	caBundleCM := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      tls.CheMergedCABundleCertsCMName,
+			Namespace: "eclipse-che",
+		},
+		Data: map[string]string{tls.CheMergedCABundleCertsCMKey: generateTestCertificate(t)},
+	}
+	ctx := test.NewCtxBuilder().WithCheCluster(getSecureModeCheCluster()).WithObjects(caBundleCM).Build()
+	ctx.Authentication.ClientSecret = []byte("client-secret")
+	infrastructure.InitializeForTesting(infrastructure.Kubernetes)
+
+	config := kubernetesOauthProxyConfig(ctx, "cookie-secret")
+	assert.NotContains(t, config, "insecure_oidc_skip_issuer_verification")
+	assert.NotContains(t, config, "ssl_insecure_skip_verify")
+	assert.NotContains(t, config, "client-secret")
+	assert.NotContains(t, config, "cookie-secret")
+	assert.Contains(t, config, "use_system_trust_store = true")
+	assert.Contains(t, config, "provider_ca_files = [\n\t\"/etc/identity-provider-ca/tls-ca-bundle.pem\"\n]")
+}
+
+func TestSecureKubernetesOauthProxyGateway(t *testing.T) {
+	ctx := test.NewCtxBuilder().WithCheCluster(getSecureModeCheCluster()).Build()
+	ctx.Authentication.ClientSecret = []byte("client-secret")
+	infrastructure.InitializeForTesting(infrastructure.Kubernetes)
+
+	done, err := SyncGatewayToCluster(ctx)
+	assert.True(t, done)
+	assert.NoError(t, err)
+
+	secret := &corev1.Secret{}
+	err = ctx.ClusterAPI.Client.Get(context.TODO(), types.NamespacedName{Name: gatewayOauthSecretName, Namespace: "eclipse-che"}, secret)
+	assert.NoError(t, err)
+	assert.Equal(t, "client-secret", string(secret.Data[oauthProxyClientSecretKey]))
+	assert.NotEmpty(t, secret.Data[oauthProxyCookieSecretKey])
+
+	container := getOauthProxyContainerSpec(ctx)
+	assert.Contains(t, container.VolumeMounts, corev1.VolumeMount{Name: identityProviderCAVolumeName, MountPath: identityProviderCAMountPath, ReadOnly: true})
+	envNames := map[string]bool{}
+	for _, env := range container.Env {
+		envNames[env.Name] = true
+	}
+	assert.True(t, envNames["OAUTH2_PROXY_CLIENT_SECRET"])
+	assert.True(t, envNames["OAUTH2_PROXY_COOKIE_SECRET"])
+	assert.Contains(t, getVolumesSpec(ctx.CheCluster), getIdentityProviderCAVolume())
+
+	condition := meta.FindStatusCondition(ctx.CheCluster.Status.Conditions, chev2.OAuthProxyTLSVerificationCondition)
+	assert.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, chev2.OAuthProxyTLSVerificationReasonVerified, condition.Reason)
+
+	// disable secure mode
+	ctx.CheCluster.Spec.Networking.Auth.Gateway.OAuthProxy.SecureMode = false
+	assert.NoError(t, ctx.ClusterAPI.Client.Update(context.TODO(), ctx.CheCluster))
+
+	// the changed objects are updated one per sync
+	for i, done := 0, false; i < 10 && !done; i++ {
+		done, err = SyncGatewayToCluster(ctx)
+		assert.NoError(t, err)
+	}
+
+	err = ctx.ClusterAPI.Client.Get(context.TODO(), types.NamespacedName{Name: gatewayOauthSecretName, Namespace: "eclipse-che"}, secret)
+	assert.NoError(t, err)
+	assert.NotContains(t, secret.Data, oauthProxyClientSecretKey)
+
+	condition = meta.FindStatusCondition(ctx.CheCluster.Status.Conditions, chev2.OAuthProxyTLSVerificationCondition)
+	assert.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, chev2.OAuthProxyTLSVerificationReasonSecureModeDisabled, condition.Reason)
+}
+
+func TestOauthProxyTLSVerificationCondition(t *testing.T) {
+	type testCase struct {
+		name           string
+		issuerCA       string
+		caBundle       string
+		expectedStatus metav1.ConditionStatus
+		expectedReason string
+	}
+
+	certificate := generateTestCertificate(t)
+	testCases := []testCase{
+		{
+			name:           "No CA configured",
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: chev2.OAuthProxyTLSVerificationReasonVerified,
+		},
+		{
+			name:           "Valid CA",
+			issuerCA:       certificate,
+			caBundle:       certificate,
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: chev2.OAuthProxyTLSVerificationReasonVerified,
+		},
+		{
+			name:           "Invalid issuer CA",
+			issuerCA:       "not a certificate",
+			caBundle:       certificate,
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: chev2.OAuthProxyTLSVerificationReasonInvalidIssuerCA,
+		},
+		{
+			name:           "Invalid CA bundle",
+			caBundle:       "-----BEGIN CERTIFICATE-----\nYmxh\n-----END CERTIFICATE-----\n",
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: chev2.OAuthProxyTLSVerificationReasonInvalidCABundle,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			caBundleCM := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      tls.CheMergedCABundleCertsCMName,
+					Namespace: "eclipse-che",
+				},
+				Data: map[string]string{},
+			}
+			if testCase.caBundle != "" {
+				caBundleCM.Data[tls.CheMergedCABundleCertsCMKey] = testCase.caBundle
+			}
+
+			ctx := test.NewCtxBuilder().WithCheCluster(getSecureModeCheCluster()).WithObjects(caBundleCM).Build()
+			ctx.Authentication.IssuerCA = testCase.issuerCA
+			infrastructure.InitializeForTesting(infrastructure.Kubernetes)
+
+			err := syncOauthProxyTLSVerificationCondition(ctx)
+			assert.NoError(t, err)
+
+			condition := meta.FindStatusCondition(ctx.CheCluster.Status.Conditions, chev2.OAuthProxyTLSVerificationCondition)
+			assert.NotNil(t, condition)
+			assert.Equal(t, testCase.expectedStatus, condition.Status)
+			assert.Equal(t, testCase.expectedReason, condition.Reason)
+		})
+	}
+}
+
+func getSecureModeCheCluster() *chev2.CheCluster {
+	return &chev2.CheCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "eclipse-che",
+			Namespace: "eclipse-che",
+		},
+		Spec: chev2.CheClusterSpec{
+			Networking: chev2.CheClusterSpecNetworking{
+				Auth: chev2.Auth{
+					Gateway: chev2.Gateway{
+						OAuthProxy: &chev2.OAuthProxy{
+							SecureMode: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func generateTestCertificate(t *testing.T) string {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+}
@@ -0,0 +1,229 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package gateway
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"reflect"
+	"strings"
+
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/eclipse-che/che-operator/pkg/common/chetypes"
+	"github.com/eclipse-che/che-operator/pkg/common/infrastructure"
+	"github.com/eclipse-che/che-operator/pkg/deploy"
+	"github.com/eclipse-che/che-operator/pkg/deploy/tls"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	identityProviderCAVolumeName = "identity-provider-ca"
+	identityProviderCAMountPath  = "/etc/identity-provider-ca"
+
+	oauthProxyClientSecretKey = "client_secret"
+	oauthProxyCookieSecretKey = "cookie_secret"
+)
+
+// isOauthProxySecureModeEnabled returns true if oauth-proxy verifies the TLS certificate
+// of the Identity Provider and reads the secrets from the gateway secret.
+// The secure mode is specific to Kubernetes.
+func isOauthProxySecureModeEnabled(cheCluster *chev2.CheCluster) bool {
+	return !infrastructure.IsOpenShiftOAuthEnabled() && cheCluster.IsOAuthProxySecureModeEnabled()
+}
+
+// oauthProxyTLSConfig returns the oauth-proxy settings for connecting to the Identity Provider.
+func oauthProxyTLSConfig(ctx *chetypes.DeployContext) string {
+	if !isOauthProxySecureModeEnabled(ctx.CheCluster) {
+		return "insecure_oidc_skip_issuer_verification = true\nssl_insecure_skip_verify = true"
+	}
+
+	config := "use_system_trust_store = true"
+	if caFiles := getIdentityProviderCAFiles(ctx); len(caFiles) > 0 {
+		config += fmt.Sprintf("\nprovider_ca_files = [\n\t\"%s\"\n]", strings.Join(caFiles, "\",\n\t\""))
+	}
+	return config
+}
+
+// oauthProxySecretsConfig returns the oauth-proxy settings for the client and cookie secrets.
+// In the secure mode, the secrets are provided by the environment variables instead.
+func oauthProxySecretsConfig(ctx *chetypes.DeployContext, cookieSecret string) string {
+	if isOauthProxySecureModeEnabled(ctx.CheCluster) {
+		return ""
+	}
+
+	return fmt.Sprintf("client_secret = \"%s\"\ncookie_secret = \"%s\"", string(ctx.Authentication.ClientSecret), cookieSecret)
+}
+
+// getIdentityProviderCAFiles returns the CA bundle files trusted when connecting to the Identity Provider.
+// The issuer CA is a part of the merged CA bundle, see tls.CertificatesReconciler.
+func getIdentityProviderCAFiles(ctx *chetypes.DeployContext) []string {
+	cm := &corev1.ConfigMap{}
+	exists, err := deploy.GetNamespacedObject(ctx, tls.CheMergedCABundleCertsCMName, cm)
+	if err != nil || !exists || cm.Data[tls.CheMergedCABundleCertsCMKey] == "" {
+		return nil
+	}
+
+	return []string{identityProviderCAMountPath + "/" + tls.CheMergedCABundleCertsCMKey}
+}
+
+// setOauthProxyClientSecret adds the OIDC client secret to the gateway secret in the secure mode
+// and removes it otherwise.
+func setOauthProxyClientSecret(ctx *chetypes.DeployContext, secret *corev1.Secret) {
+	if isOauthProxySecureModeEnabled(ctx.CheCluster) {
+		secret.Data[oauthProxyClientSecretKey] = ctx.Authentication.ClientSecret
+	} else {
+		delete(secret.Data, oauthProxyClientSecretKey)
+	}
+}
+
+func getOauthProxySecretsEnv(ctx *chetypes.DeployContext) []corev1.EnvVar {
+	if !isOauthProxySecureModeEnabled(ctx.CheCluster) {
+		return nil
+	}
+
+	// append env var with Secret revision to restore pod automatically when secrets have been changed
+	secret := &corev1.Secret{}
+	exists, _ := deploy.GetNamespacedObject(ctx, gatewayOauthSecretName, secret)
+	secretRevision := map[bool]string{true: secret.GetResourceVersion(), false: ""}[exists]
+
+	return []corev1.EnvVar{
+		{
+			Name: "OAUTH2_PROXY_CLIENT_SECRET",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: gatewayOauthSecretName},
+					Key:                  oauthProxyClientSecretKey,
+				},
+			},
+		},
+		{
+			Name: "OAUTH2_PROXY_COOKIE_SECRET",
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: gatewayOauthSecretName},
+					Key:                  oauthProxyCookieSecretKey,
+				},
+			},
+		},
+		{
+			Name:  "SECRET_REVISION",
+			Value: secretRevision,
+		},
+	}
+}
+
+func getIdentityProviderCAVolume() corev1.Volume {
+	return corev1.Volume{
+		Name: identityProviderCAVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: tls.CheMergedCABundleCertsCMName,
+				},
+				Optional: ptr.To(true),
+			},
+		},
+	}
+}
+
+// syncOauthProxyTLSVerificationCondition validates the CA certificates used by oauth-proxy
+// to verify the Identity Provider and reports the result in the status condition.
+// An invalid CA does not disable the verification, it is only reported.
+func syncOauthProxyTLSVerificationCondition(ctx *chetypes.DeployContext) error {
+	conditions := make([]metav1.Condition, len(ctx.CheCluster.Status.Conditions))
+	copy(conditions, ctx.CheCluster.Status.Conditions)
+
+	if infrastructure.IsOpenShiftOAuthEnabled() {
+		meta.RemoveStatusCondition(&conditions, chev2.OAuthProxyTLSVerificationCondition)
+	} else {
+		meta.SetStatusCondition(&conditions, getOauthProxyTLSVerificationCondition(ctx))
+	}
+
+	if reflect.DeepEqual(ctx.CheCluster.Status.Conditions, conditions) {
+		return nil
+	}
+
+	ctx.CheCluster.Status.Conditions = conditions
+	return deploy.UpdateCheCRStatus(ctx, "Conditions", chev2.OAuthProxyTLSVerificationCondition)
+}
+
+func getOauthProxyTLSVerificationCondition(ctx *chetypes.DeployContext) metav1.Condition {
+	condition := metav1.Condition{
+		Type:               chev2.OAuthProxyTLSVerificationCondition,
+		ObservedGeneration: ctx.CheCluster.Generation,
+	}
+
+	if !ctx.CheCluster.IsOAuthProxySecureModeEnabled() {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = chev2.OAuthProxyTLSVerificationReasonSecureModeDisabled
+		condition.Message = "TLS certificate of the Identity Provider is not verified, enable `spec.networking.auth.gateway.oAuthProxy.secureMode`"
+		return condition
+	}
+
+	if ctx.Authentication.IssuerCA != "" {
+		if err := validateCertificates(ctx.Authentication.IssuerCA); err != nil {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = chev2.OAuthProxyTLSVerificationReasonInvalidIssuerCA
+			condition.Message = fmt.Sprintf("Invalid issuer CA: %s", err.Error())
+			return condition
+		}
+	}
+
+	cm := &corev1.ConfigMap{}
+	exists, err := deploy.GetNamespacedObject(ctx, tls.CheMergedCABundleCertsCMName, cm)
+	if err == nil && exists && cm.Data[tls.CheMergedCABundleCertsCMKey] != "" {
+		if err := validateCertificates(cm.Data[tls.CheMergedCABundleCertsCMKey]); err != nil {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = chev2.OAuthProxyTLSVerificationReasonInvalidCABundle
+			condition.Message = fmt.Sprintf("Invalid CA bundle in ConfigMap %s: %s", tls.CheMergedCABundleCertsCMName, err.Error())
+			return condition
+		}
+	}
+
+	condition.Status = metav1.ConditionTrue
+	condition.Reason = chev2.OAuthProxyTLSVerificationReasonVerified
+	condition.Message = "TLS certificate of the Identity Provider is verified"
+	return condition
+}
+
+// validateCertificates checks that the PEM data contains at least one certificate
+// and that all the certificates can be parsed.
+func validateCertificates(data string) error {
+	rest := []byte(data)
+	count := 0
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		if _, err := x509.ParseCertificate(block.Bytes); err != nil {
+			return fmt.Errorf("failed to parse certificate #%d: %w", count+1, err)
+		}
+		count++
+	}
+
+	if count == 0 {
+		return fmt.Errorf("no PEM encoded certificates found")
+	}
+	return nil
+}
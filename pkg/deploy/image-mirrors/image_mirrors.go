@@ -0,0 +1,160 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package imagemirrors
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/eclipse-che/che-operator/pkg/common/chetypes"
+	"github.com/eclipse-che/che-operator/pkg/common/infrastructure"
+	defaults "github.com/eclipse-che/che-operator/pkg/common/operator-defaults"
+	"github.com/eclipse-che/che-operator/pkg/common/reconciler"
+	"github.com/eclipse-che/che-operator/pkg/common/utils"
+	"github.com/eclipse-che/che-operator/pkg/deploy"
+	configv1 "github.com/openshift/api/config/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var (
+	logger = ctrl.Log.WithName("image-mirrors")
+)
+
+// ImageMirrorsReconciler records the mirrors of the cluster `ImageDigestMirrorSet` objects
+// and the images rewritten by the mirrors in the CheCluster status.
+type ImageMirrorsReconciler struct {
+	reconciler.Reconcilable
+}
+
+func NewImageMirrorsReconciler() *ImageMirrorsReconciler {
+	return &ImageMirrorsReconciler{}
+}
+
+func (r *ImageMirrorsReconciler) Reconcile(ctx *chetypes.DeployContext) (reconcile.Result, bool, error) {
+	if err := r.syncImageDigestMirrors(ctx); err != nil {
+		return reconcile.Result{}, false, err
+	}
+
+	resolvedImages := getResolvedImages(ctx.CheCluster)
+	if !reflect.DeepEqual(resolvedImages, ctx.CheCluster.Status.ResolvedImages) {
+		ctx.CheCluster.Status.ResolvedImages = resolvedImages
+		if err := deploy.UpdateCheCRStatus(ctx, "resolvedImages", fmt.Sprintf("%d images", len(resolvedImages))); err != nil {
+			return reconcile.Result{}, false, err
+		}
+	}
+
+	return reconcile.Result{}, true, nil
+}
+
+func (r *ImageMirrorsReconciler) Finalize(ctx *chetypes.DeployContext) bool {
+	return true
+}
+
+func (r *ImageMirrorsReconciler) syncImageDigestMirrors(ctx *chetypes.DeployContext) error {
+	var mirrors []chev2.ImageMirror
+	if ctx.CheCluster.Spec.ContainerRegistry.UseImageDigestMirrorSets && infrastructure.IsOpenShift() {
+		var err error
+		if mirrors, err = readImageDigestMirrors(ctx); err != nil {
+			return err
+		}
+	}
+
+	if reflect.DeepEqual(mirrors, ctx.CheCluster.Status.ImageDigestMirrors) {
+		return nil
+	}
+
+	ctx.CheCluster.Status.ImageDigestMirrors = mirrors
+	return deploy.UpdateCheCRStatus(ctx, "imageDigestMirrors", fmt.Sprintf("%d mirrors", len(mirrors)))
+}
+
+// readImageDigestMirrors returns the first mirror of each source of the cluster `ImageDigestMirrorSet` objects.
+func readImageDigestMirrors(ctx *chetypes.DeployContext) ([]chev2.ImageMirror, error) {
+	imageDigestMirrorSets := &configv1.ImageDigestMirrorSetList{}
+	if err := ctx.ClusterAPI.NonCachingClient.List(context.TODO(), imageDigestMirrorSets); err != nil {
+		if meta.IsNoMatchError(err) {
+			logger.Info("ImageDigestMirrorSet API is not available on the cluster")
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	mirrors := map[string]string{}
+	for _, imageDigestMirrorSet := range imageDigestMirrorSets.Items {
+		for _, imageDigestMirrors := range imageDigestMirrorSet.Spec.ImageDigestMirrors {
+			if len(imageDigestMirrors.Mirrors) > 0 {
+				mirrors[imageDigestMirrors.Source] = string(imageDigestMirrors.Mirrors[0])
+			}
+		}
+	}
+
+	var imageMirrors []chev2.ImageMirror
+	for source, mirror := range mirrors {
+		imageMirrors = append(imageMirrors, chev2.ImageMirror{Source: source, Mirror: mirror})
+	}
+
+	sort.Slice(imageMirrors, func(i, j int) bool {
+		return imageMirrors[i].Source < imageMirrors[j].Source
+	})
+
+	return imageMirrors, nil
+}
+
+// getResolvedImages returns the images managed by the Operator which are rewritten by the mirrors.
+func getResolvedImages(cheCluster *chev2.CheCluster) []chev2.ResolvedImage {
+	images := map[string]bool{}
+	for _, env := range utils.GetEnvsByRegExp("^RELATED_IMAGE_.*") {
+		images[env.Value] = true
+	}
+
+	var defaultComponents []map[string]interface{}
+	if err := json.Unmarshal([]byte(defaults.GetDevEnvironmentsDefaultComponents()), &defaultComponents); err == nil {
+		for _, component := range defaultComponents {
+			if container, ok := component["container"].(map[string]interface{}); ok {
+				if image, ok := container["image"].(string); ok {
+					images[image] = true
+				}
+			}
+		}
+	}
+
+	if cheCluster.Spec.DevEnvironments.ProjectCloneContainer != nil {
+		images[cheCluster.Spec.DevEnvironments.ProjectCloneContainer.Image] = true
+	}
+
+	for _, container := range cheCluster.Spec.DevEnvironments.InitContainers {
+		images[container.Image] = true
+	}
+
+	var resolvedImages []chev2.ResolvedImage
+	for image := range images {
+		if image == "" {
+			continue
+		}
+
+		if mirroredImage := defaults.ApplyImageMirrors(cheCluster, image); mirroredImage != image {
+			resolvedImages = append(resolvedImages, chev2.ResolvedImage{Source: image, Image: mirroredImage})
+		}
+	}
+
+	sort.Slice(resolvedImages, func(i, j int) bool {
+		return resolvedImages[i].Source < resolvedImages[j].Source
+	})
+
+	return resolvedImages
+}
@@ -0,0 +1,113 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package imagemirrors
+
+import (
+	"testing"
+
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	defaults "github.com/eclipse-che/che-operator/pkg/common/operator-defaults"
+	"github.com/eclipse-che/che-operator/pkg/common/test"
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestResolvedImages(t *testing.T) {
+	cheCluster := &chev2.CheCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "eclipse-che",
+			Namespace: "eclipse-che",
+		},
+		Spec: chev2.CheClusterSpec{
+			ContainerRegistry: chev2.CheClusterContainerRegistry{
+				Mirrors: []chev2.ImageMirror{
+					{Source: "quay.io/eclipse", Mirror: "mirror.example.com/eclipse"},
+					{Source: "quay.io/eclipse/che-server", Mirror: "mirror.example.com/che-server"},
+				},
+			},
+			DevEnvironments: chev2.CheClusterDevEnvironments{
+				InitContainers: []corev1.Container{
+					{Name: "init", Image: "quay.io/eclipse/init:latest"},
+				},
+			},
+		},
+	}
+
+	ctx := test.NewCtxBuilder().WithCheCluster(cheCluster).Build()
+
+	test.EnsureReconcile(t, ctx, NewImageMirrorsReconciler().Reconcile)
+
+	assert.Contains(t, ctx.CheCluster.Status.ResolvedImages, chev2.ResolvedImage{
+		Source: "quay.io/eclipse/che-server:next",
+		Image:  "mirror.example.com/che-server:next",
+	})
+	assert.Contains(t, ctx.CheCluster.Status.ResolvedImages, chev2.ResolvedImage{
+		Source: "quay.io/eclipse/che-dashboard:next",
+		Image:  "mirror.example.com/eclipse/che-dashboard:next",
+	})
+	assert.Contains(t, ctx.CheCluster.Status.ResolvedImages, chev2.ResolvedImage{
+		Source: "quay.io/eclipse/init:latest",
+		Image:  "mirror.example.com/eclipse/init:latest",
+	})
+	for _, resolvedImage := range ctx.CheCluster.Status.ResolvedImages {
+		assert.NotContains(t, resolvedImage.Source, "quay.io/che-incubator")
+	}
+
+	assert.Equal(t, "mirror.example.com/che-server:next", defaults.GetCheServerImage(ctx.CheCluster))
+}
+
+func TestImageDigestMirrorSets(t *testing.T) {
+	imageDigestMirrorSet := &configv1.ImageDigestMirrorSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "mirrors"},
+		Spec: configv1.ImageDigestMirrorSetSpec{
+			ImageDigestMirrors: []configv1.ImageDigestMirrors{
+				{
+					Source:  "quay.io/devfile",
+					Mirrors: []configv1.ImageMirror{"mirror.example.com/devfile", "backup.example.com/devfile"},
+				},
+			},
+		},
+	}
+
+	cheCluster := &chev2.CheCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "eclipse-che",
+			Namespace: "eclipse-che",
+		},
+		Spec: chev2.CheClusterSpec{
+			ContainerRegistry: chev2.CheClusterContainerRegistry{
+				UseImageDigestMirrorSets: true,
+			},
+		},
+	}
+
+	ctx := test.NewCtxBuilder().WithCheCluster(cheCluster).WithObjects([]client.Object{imageDigestMirrorSet}...).Build()
+
+	test.EnsureReconcile(t, ctx, NewImageMirrorsReconciler().Reconcile)
+
+	assert.Equal(t, []chev2.ImageMirror{{Source: "quay.io/devfile", Mirror: "mirror.example.com/devfile"}}, ctx.CheCluster.Status.ImageDigestMirrors)
+
+	// Only the images referenced by digest are mirrored
+	assert.Equal(t, "mirror.example.com/devfile/udi@sha256:1234", defaults.ApplyImageMirrors(ctx.CheCluster, "quay.io/devfile/udi@sha256:1234"))
+	assert.Equal(t, "quay.io/devfile/udi:latest", defaults.ApplyImageMirrors(ctx.CheCluster, "quay.io/devfile/udi:latest"))
+
+	// Not applied if disabled
+	ctx.CheCluster.Spec.ContainerRegistry.UseImageDigestMirrorSets = false
+	assert.Equal(t, "quay.io/devfile/udi@sha256:1234", defaults.ApplyImageMirrors(ctx.CheCluster, "quay.io/devfile/udi@sha256:1234"))
+
+	test.EnsureReconcile(t, ctx, NewImageMirrorsReconciler().Reconcile)
+	assert.Empty(t, ctx.CheCluster.Status.ImageDigestMirrors)
+}
@@ -0,0 +1,210 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package imagepuller
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/eclipse-che/che-operator/pkg/common/chetypes"
+	"github.com/eclipse-che/che-operator/pkg/common/constants"
+	"github.com/eclipse-che/che-operator/pkg/common/diffs"
+	defaults "github.com/eclipse-che/che-operator/pkg/common/operator-defaults"
+	"github.com/eclipse-che/che-operator/pkg/common/utils"
+	"github.com/eclipse-che/che-operator/pkg/deploy"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	sleepVolumeName = "sleep"
+	sleepMountPath  = "/che-image-puller"
+	// sleepDuration is the duration the pre-pulling containers are kept running before being restarted
+	sleepDuration = "720h"
+)
+
+// syncBuiltInImagePuller syncs the DaemonSet pre-pulling the images on the cluster nodes.
+// Every image is run as a container executing the `sleep` binary copied from the image puller image,
+// so the images don't need to provide any binaries.
+func (ip *ImagePuller) syncBuiltInImagePuller(images []string, ctx *chetypes.DeployContext) (bool, error) {
+	if specImages := strings.TrimSpace(ctx.CheCluster.Spec.Components.ImagePuller.Spec.Images); specImages != "" {
+		images = parseSpecField(specImages)
+	}
+
+	// A DaemonSet without containers is invalid, nothing to pre-pull
+	if len(images) == 0 {
+		return ip.uninstallBuiltInImagePuller(ctx)
+	}
+
+	daemonSet := getBuiltInImagePullerDaemonSetSpec(images, ctx)
+	done, err := deploy.Sync(ctx, daemonSet, diffs.DaemonSet)
+	if err != nil {
+		return false, fmt.Errorf("failed to sync DaemonSet %s/%s: %w", daemonSet.Namespace, daemonSet.Name, err)
+	}
+
+	return done, nil
+}
+
+func (ip *ImagePuller) uninstallBuiltInImagePuller(ctx *chetypes.DeployContext) (bool, error) {
+	return deploy.DeleteNamespacedObject(ctx, getBuiltInImagePullerName(ctx), &appsv1.DaemonSet{})
+}
+
+func getBuiltInImagePullerDaemonSetSpec(images []string, ctx *chetypes.DeployContext) *appsv1.DaemonSet {
+	labels, selector := deploy.GetLabelsAndSelector(constants.BuiltInImagePullerComponentName)
+	builtIn := ctx.CheCluster.Spec.Components.ImagePuller.BuiltIn
+
+	maxUnavailable := intstr.FromInt32(1)
+	var nodeSelector map[string]string
+	var tolerations []corev1.Toleration
+	pullerImage := defaults.GetKubernetesImagePullerImage(ctx.CheCluster)
+	if builtIn != nil {
+		nodeSelector = builtIn.NodeSelector
+		tolerations = builtIn.Tolerations
+		pullerImage = utils.GetValue(builtIn.Image, pullerImage)
+		if builtIn.MaxUnavailable != nil {
+			maxUnavailable = *builtIn.MaxUnavailable
+		}
+	}
+
+	securityContext := &corev1.SecurityContext{
+		AllowPrivilegeEscalation: ptr.To(false),
+		Capabilities: &corev1.Capabilities{
+			Drop: []corev1.Capability{"ALL"},
+		},
+		RunAsNonRoot: ptr.To(true),
+		SeccompProfile: &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeRuntimeDefault,
+		},
+	}
+
+	resources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("5m"),
+			corev1.ResourceMemory: resource.MustParse("10Mi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("20m"),
+			corev1.ResourceMemory: resource.MustParse("20Mi"),
+		},
+	}
+
+	sleepVolumeMount := corev1.VolumeMount{
+		Name:      sleepVolumeName,
+		MountPath: sleepMountPath,
+	}
+
+	containers := make([]corev1.Container, 0, len(images))
+	for index, image := range images {
+		containers = append(containers, corev1.Container{
+			Name:            getContainerName(image, index),
+			Image:           image,
+			Command:         []string{sleepMountPath + "/sleep"},
+			Args:            []string{sleepDuration},
+			Resources:       resources,
+			SecurityContext: securityContext,
+			VolumeMounts:    []corev1.VolumeMount{sleepVolumeMount},
+		})
+	}
+
+	daemonSet := &appsv1.DaemonSet{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "DaemonSet",
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getBuiltInImagePullerName(ctx),
+			Namespace: ctx.CheCluster.Namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: selector},
+			UpdateStrategy: appsv1.DaemonSetUpdateStrategy{
+				Type: appsv1.RollingUpdateDaemonSetStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDaemonSet{
+					MaxUnavailable: &maxUnavailable,
+				},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					InitContainers: []corev1.Container{
+						{
+							Name:            "copy-sleep",
+							Image:           pullerImage,
+							Command:         []string{"cp", "/bin/sleep", sleepMountPath + "/sleep"},
+							Resources:       resources,
+							SecurityContext: securityContext,
+							VolumeMounts:    []corev1.VolumeMount{sleepVolumeMount},
+						},
+					},
+					Containers:                    containers,
+					NodeSelector:                  nodeSelector,
+					Tolerations:                   tolerations,
+					AutomountServiceAccountToken:  ptr.To(false),
+					TerminationGracePeriodSeconds: ptr.To(int64(0)),
+					Volumes: []corev1.Volume{
+						{
+							Name: sleepVolumeName,
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// The workspace images may default to the root user, a non-root user is set on Kubernetes,
+	// while OpenShift assigns one from the namespace range
+	deploy.EnsurePodSecurityStandards(
+		&daemonSet.Spec.Template.Spec,
+		constants.DefaultSecurityContextRunAsUser,
+		constants.DefaultSecurityContextFsGroup,
+	)
+
+	return daemonSet
+}
+
+func getBuiltInImagePullerName(ctx *chetypes.DeployContext) string {
+	return ctx.CheCluster.Name + "-image-puller"
+}
+
+// parseSpecField returns the images from the `name1=image1;name2=image2;` formatted string.
+func parseSpecField(specField string) []string {
+	var images []string
+	for _, entry := range strings.Split(specField, ";") {
+		_, image, found := strings.Cut(entry, "=")
+		if image = strings.TrimSpace(image); found && image != "" {
+			images = append(images, image)
+		}
+	}
+	return images
+}
+
+// getContainerName returns a unique container name for the image at the given index.
+func getContainerName(image string, index int) string {
+	suffix := fmt.Sprintf("-%d", index)
+	name := getImageEntryName(image)
+	if len(name)+len(suffix) > validation.DNS1123LabelMaxLength {
+		name = strings.TrimRight(name[:validation.DNS1123LabelMaxLength-len(suffix)], "-")
+	}
+	return name + suffix
+}
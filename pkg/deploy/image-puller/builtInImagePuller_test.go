@@ -0,0 +1,183 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package imagepuller
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	chev1alpha1 "github.com/che-incubator/kubernetes-image-puller-operator/api/v1alpha1"
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/eclipse-che/che-operator/pkg/common/chetypes"
+	"github.com/eclipse-che/che-operator/pkg/common/test"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	fakeDiscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newTestImagePuller() *ImagePuller {
+	return &ImagePuller{
+		externalImages: &ExternalImagesProvider{
+			imagesFilePath: filepath.Join(os.TempDir(), externalImagesStoreFileName),
+			fetchRawDataFunc: func(url string) ([]byte, error) {
+				return os.ReadFile("image-puller-resources-test/imagepuller_testcase_1.json")
+			},
+		},
+		workspaceImages: NewWorkspaceImagesProvider(),
+	}
+}
+
+func disableKubernetesImagePuller(ctx *chetypes.DeployContext) {
+	ctx.ClusterAPI.DiscoveryClient.(*fakeDiscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{}
+}
+
+func TestBuiltInImagePuller(t *testing.T) {
+	cheCluster := InitCheCluster(chev2.ImagePuller{
+		Enable: true,
+		BuiltIn: &chev2.BuiltInImagePuller{
+			NodeSelector: map[string]string{"node-role.kubernetes.io/worker": ""},
+			Tolerations: []corev1.Toleration{
+				{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "workspaces", Effect: corev1.TaintEffectNoSchedule},
+			},
+			MaxUnavailable: ptr.To(intstr.FromString("20%")),
+		},
+	})
+
+	ctx := test.NewCtxBuilder().WithCheCluster(cheCluster).Build()
+	disableKubernetesImagePuller(ctx)
+
+	test.EnsureReconcile(t, ctx, newTestImagePuller().Reconcile)
+
+	daemonSet := &appsv1.DaemonSet{}
+	assert.NoError(t, ctx.ClusterAPI.Client.Get(context.TODO(), types.NamespacedName{Namespace: "eclipse-che", Name: "eclipse-che-image-puller"}, daemonSet))
+
+	podSpec := daemonSet.Spec.Template.Spec
+	assert.Equal(t, map[string]string{"node-role.kubernetes.io/worker": ""}, podSpec.NodeSelector)
+	assert.Equal(t, "dedicated", podSpec.Tolerations[0].Key)
+	assert.Equal(t, "20%", daemonSet.Spec.UpdateStrategy.RollingUpdate.MaxUnavailable.String())
+
+	assert.Len(t, podSpec.InitContainers, 1)
+	assert.Equal(t, "quay.io/eclipse/kubernetes-image-puller:next", podSpec.InitContainers[0].Image)
+
+	assert.Len(t, podSpec.Containers, 2)
+	assert.Equal(t, "image-1-0", podSpec.Containers[0].Name)
+	assert.Equal(t, "image_1", podSpec.Containers[0].Image)
+	assert.Equal(t, []string{"/che-image-puller/sleep"}, podSpec.Containers[0].Command)
+	assert.Equal(t, "image-2-1", podSpec.Containers[1].Name)
+	assert.Equal(t, "image_2", podSpec.Containers[1].Image)
+
+	for _, container := range append(podSpec.InitContainers, podSpec.Containers...) {
+		assert.True(t, *container.SecurityContext.RunAsNonRoot, container.Name)
+		assert.False(t, *container.SecurityContext.AllowPrivilegeEscalation, container.Name)
+		assert.Equal(t, corev1.SeccompProfileTypeRuntimeDefault, container.SecurityContext.SeccompProfile.Type, container.Name)
+	}
+
+	// Kubernetes Image Puller custom resource is not created
+	err := ctx.ClusterAPI.Client.Get(context.TODO(), types.NamespacedName{Namespace: "eclipse-che", Name: "eclipse-che-image-puller"}, &chev1alpha1.KubernetesImagePuller{})
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestBuiltInImagePullerWithCustomImages(t *testing.T) {
+	cheCluster := InitCheCluster(chev2.ImagePuller{
+		Enable: true,
+		Spec: chev1alpha1.KubernetesImagePullerSpec{
+			Images: "java=quay.io/devfile/java:17;node=quay.io/devfile/node:20;",
+		},
+	})
+
+	ctx := test.NewCtxBuilder().WithCheCluster(cheCluster).Build()
+	disableKubernetesImagePuller(ctx)
+
+	test.EnsureReconcile(t, ctx, newTestImagePuller().Reconcile)
+
+	daemonSet := &appsv1.DaemonSet{}
+	assert.NoError(t, ctx.ClusterAPI.Client.Get(context.TODO(), types.NamespacedName{Namespace: "eclipse-che", Name: "eclipse-che-image-puller"}, daemonSet))
+
+	containers := daemonSet.Spec.Template.Spec.Containers
+	assert.Len(t, containers, 2)
+	assert.Equal(t, "quay.io/devfile/java:17", containers[0].Image)
+	assert.Equal(t, "quay.io/devfile/node:20", containers[1].Image)
+	assert.Equal(t, "1", daemonSet.Spec.UpdateStrategy.RollingUpdate.MaxUnavailable.String())
+}
+
+func TestBuiltInImagePullerRemoved(t *testing.T) {
+	type testCase struct {
+		name                        string
+		imagePuller                 chev2.ImagePuller
+		kubernetesImagePullerExists bool
+	}
+
+	testCases := []testCase{
+		{
+			name:                        "Kubernetes Image Puller Operator is installed",
+			imagePuller:                 chev2.ImagePuller{Enable: true},
+			kubernetesImagePullerExists: true,
+		},
+		{
+			name:        "Image puller is disabled",
+			imagePuller: chev2.ImagePuller{Enable: false},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			daemonSet := &appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "eclipse-che-image-puller", Namespace: "eclipse-che"},
+			}
+
+			ctx := test.NewCtxBuilder().WithCheCluster(InitCheCluster(testCase.imagePuller)).WithObjects([]client.Object{daemonSet}...).Build()
+
+			test.EnsureReconcile(t, ctx, newTestImagePuller().Reconcile)
+
+			err := ctx.ClusterAPI.Client.Get(context.TODO(), types.NamespacedName{Namespace: "eclipse-che", Name: "eclipse-che-image-puller"}, &appsv1.DaemonSet{})
+			assert.True(t, errors.IsNotFound(err))
+
+			err = ctx.ClusterAPI.Client.Get(context.TODO(), types.NamespacedName{Namespace: "eclipse-che", Name: "eclipse-che-image-puller"}, &chev1alpha1.KubernetesImagePuller{})
+			assert.Equal(t, testCase.kubernetesImagePullerExists, err == nil)
+		})
+	}
+}
+
+func TestBuiltInImagePullerWithoutImages(t *testing.T) {
+	daemonSet := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "eclipse-che-image-puller", Namespace: "eclipse-che"},
+	}
+
+	ctx := test.NewCtxBuilder().WithCheCluster(InitCheCluster(chev2.ImagePuller{Enable: true})).WithObjects(daemonSet).Build()
+	disableKubernetesImagePuller(ctx)
+
+	done, err := newTestImagePuller().syncBuiltInImagePuller([]string{}, ctx)
+	assert.NoError(t, err)
+	assert.True(t, done)
+
+	err = ctx.ClusterAPI.Client.Get(context.TODO(), types.NamespacedName{Namespace: "eclipse-che", Name: "eclipse-che-image-puller"}, &appsv1.DaemonSet{})
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestGetContainerName(t *testing.T) {
+	assert.Equal(t, "java-0", getContainerName("quay.io/devfile/java:17", 0))
+
+	longName := getContainerName("quay.io/devfile/"+strings.Repeat("a", 70)+":latest", 12)
+	assert.Len(t, longName, 63)
+	assert.True(t, strings.HasSuffix(longName, "-12"))
+}
@@ -14,9 +14,9 @@ package imagepuller
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -25,6 +25,7 @@ import (
 	"github.com/eclipse-che/che-operator/pkg/common/diffs"
 	"github.com/eclipse-che/che-operator/pkg/common/infrastructure"
 	k8s_client "github.com/eclipse-che/che-operator/pkg/common/k8s-client"
+	defaults "github.com/eclipse-che/che-operator/pkg/common/operator-defaults"
 	"github.com/eclipse-che/che-operator/pkg/common/reconciler"
 	"github.com/eclipse-che/che-operator/pkg/common/utils"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -52,12 +53,14 @@ const (
 
 type ImagePuller struct {
 	reconciler.Reconcilable
-	externalImages *ExternalImagesProvider
+	externalImages  *ExternalImagesProvider
+	workspaceImages *WorkspaceImagesProvider
 }
 
 func NewImagePuller() *ImagePuller {
 	return &ImagePuller{
-		externalImages: NewExternalImagesProvider(),
+		externalImages:  NewExternalImagesProvider(),
+		workspaceImages: NewWorkspaceImagesProvider(),
 	}
 }
 
@@ -76,13 +79,39 @@ func (ip *ImagePuller) Reconcile(ctx *chetypes.DeployContext) (reconcile.Result,
 	}
 
 	if ctx.CheCluster.Spec.Components.ImagePuller.Enable {
-		if !infrastructure.IsKubernetesImagePullerEnabled(ctx.ClusterAPI.DiscoveryClient) {
-			errMsg := "kubernetes Image Puller is not installed, in order to enable the property admin should install the operator first"
-			return reconcile.Result{}, false, errors.New(errMsg)
+		images := slices.Clone(externalImages)
+		workspaceImagesConfig := ctx.CheCluster.Spec.Components.ImagePuller.WorkspaceImages
+		if workspaceImagesConfig != nil {
+			workspaceImages, err := ip.workspaceImages.Get(ctx, workspaceImagesConfig)
+			if err != nil {
+				return reconcile.Result{}, false, fmt.Errorf("failed to collect workspace images: %w", err)
+			}
+
+			images = mergeImages(externalImages, workspaceImages)
 		}
 
-		if done, err := ip.syncKubernetesImagePuller(externalImages, ctx); !done {
-			return reconcile.Result{RequeueAfter: time.Second}, false, err
+		for i := range images {
+			images[i] = defaults.ApplyImageMirrors(ctx.CheCluster, images[i])
+		}
+
+		if infrastructure.IsKubernetesImagePullerEnabled(ctx.ClusterAPI.DiscoveryClient) {
+			if done, err := ip.uninstallBuiltInImagePuller(ctx); !done {
+				return reconcile.Result{RequeueAfter: time.Second}, false, err
+			}
+
+			if done, err := ip.syncKubernetesImagePuller(images, ctx); !done {
+				return reconcile.Result{RequeueAfter: time.Second}, false, err
+			}
+		} else {
+			// Kubernetes Image Puller Operator is not installed, pre-pull images with the built-in DaemonSet
+			if done, err := ip.syncBuiltInImagePuller(images, ctx); !done {
+				return reconcile.Result{RequeueAfter: time.Second}, false, err
+			}
+		}
+
+		// Collect the workspace images periodically to follow the workspaces usage
+		if workspaceImagesConfig != nil {
+			return reconcile.Result{RequeueAfter: workspaceImagesRefreshPeriod}, true, nil
 		}
 	} else {
 		if done, err := ip.uninstallImagePuller(ctx); !done {
@@ -106,6 +135,10 @@ func (ip *ImagePuller) uninstallImagePuller(ctx *chetypes.DeployContext) (bool,
 		return false, err
 	}
 
+	if done, err := ip.uninstallBuiltInImagePuller(ctx); !done {
+		return false, err
+	}
+
 	if infrastructure.IsKubernetesImagePullerEnabled(ctx.ClusterAPI.DiscoveryClient) {
 		if done, err := deploy.DeleteByKeyWithClient(
 			ctx.ClusterAPI.NonCachingClient,
@@ -165,23 +198,38 @@ func getImagePullerCustomResourceName(ctx *chetypes.DeployContext) string {
 	return ctx.CheCluster.Name + "-image-puller"
 }
 
+// mergeImages appends the images that are not in the list yet, preserving the order.
+func mergeImages(images []string, extraImages []string) []string {
+	merged := slices.Clone(images)
+	for _, image := range extraImages {
+		if !slices.Contains(merged, image) {
+			merged = append(merged, image)
+		}
+	}
+	return merged
+}
+
 func convertToSpecField(images []string) string {
 	specField := ""
 	for index, image := range images {
-		imageName, _ := utils.GetImageNameAndTag(image)
-		imageNameEntries := strings.Split(imageName, "/")
-		name, err := convertToRFC1123(imageNameEntries[len(imageNameEntries)-1])
-		if err != nil {
-			name = "image"
-		}
-
 		// Adding index makes the name unique
-		specField += fmt.Sprintf("%s-%d=%s;", name, index, image)
+		specField += fmt.Sprintf("%s-%d=%s;", getImageEntryName(image), index, image)
 	}
 
 	return specField
 }
 
+// getImageEntryName returns the image name in RFC 1123 format, or `image` if it can't be converted.
+func getImageEntryName(image string) string {
+	imageName, _ := utils.GetImageNameAndTag(image)
+	imageNameEntries := strings.Split(imageName, "/")
+	name, err := convertToRFC1123(imageNameEntries[len(imageNameEntries)-1])
+	if err != nil {
+		return "image"
+	}
+	return name
+}
+
 // convertToRFC1123 converts input string to RFC 1123 format ([a-z0-9]([-a-z0-9]*[a-z0-9])?) max 63 characters, if possible
 func convertToRFC1123(str string) (string, error) {
 	result := strings.ToLower(str)
@@ -118,7 +118,23 @@ func TestImagePullerConfiguration(t *testing.T) {
 			}),
 		},
 		{
-			name: "case #5: Delete KubernetesImagePuller",
+			name: "case #5: KubernetesImagePuller with workspace images",
+			cheCluster: InitCheCluster(chev2.ImagePuller{
+				Enable:          true,
+				WorkspaceImages: &chev2.ImagePullerWorkspaceImages{},
+			}),
+			testCaseFilePath: "image-puller-resources-test/imagepuller_testcase_1.json",
+			initObjects: []client.Object{
+				InitDevWorkspace("ws-1", true, nil, "image_1", "quay.io/ide:latest"),
+			},
+			expectedImagePuller: InitImagePuller(chev1alpha1.KubernetesImagePullerSpec{
+				DeploymentName: defaultDeploymentName,
+				ConfigMapName:  defaultConfigMapName,
+				Images:         "image-1-0=image_1;image-2-1=image_2;ide-2=quay.io/ide:latest;",
+			}),
+		},
+		{
+			name: "case #6: Delete KubernetesImagePuller",
 			cheCluster: InitCheCluster(chev2.ImagePuller{
 				Enable: false,
 			}),
@@ -143,6 +159,7 @@ func TestImagePullerConfiguration(t *testing.T) {
 						return os.ReadFile(testCase.testCaseFilePath)
 					},
 				},
+				workspaceImages: NewWorkspaceImagesProvider(),
 			}
 
 			test.EnsureReconcile(t, ctx, ip.Reconcile)
@@ -0,0 +1,201 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package imagepuller
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	dw "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	dwconstants "github.com/devfile/devworkspace-operator/pkg/constants"
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/eclipse-che/che-operator/pkg/common/chetypes"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// workspaceImagesRefreshPeriod is the period the images used by the workspaces are collected again
+	workspaceImagesRefreshPeriod = 30 * time.Minute
+
+	defaultMaxWorkspaceImages = 20
+	defaultDaysSinceLastStart = 7
+)
+
+// defaultUnknownImageSize is counted for the images not pulled on any node yet
+var defaultUnknownImageSize = resource.MustParse("1Gi")
+
+// WorkspaceImagesProvider collects the images used by the running and recently started workspaces
+// across all user namespaces, and ranks them by popularity.
+// The images are collected again once per workspaceImagesRefreshPeriod or when the configuration changes.
+type WorkspaceImagesProvider struct {
+	images      []string
+	config      *chev2.ImagePullerWorkspaceImages
+	collectedAt time.Time
+
+	// exposed for testing purpose only
+	now func() time.Time
+}
+
+func NewWorkspaceImagesProvider() *WorkspaceImagesProvider {
+	return &WorkspaceImagesProvider{
+		now: time.Now,
+	}
+}
+
+// Get returns the most used workspace images fitting into the configured size budget,
+// ordered by the number of workspaces using them.
+func (p *WorkspaceImagesProvider) Get(ctx *chetypes.DeployContext, config *chev2.ImagePullerWorkspaceImages) ([]string, error) {
+	now := p.now()
+	if p.config != nil && equality.Semantic.DeepEqual(p.config, config) && now.Sub(p.collectedAt) < workspaceImagesRefreshPeriod {
+		return p.images, nil
+	}
+
+	images, err := p.collect(ctx, config, now)
+	if err != nil {
+		return []string{}, err
+	}
+
+	p.images = images
+	p.config = config.DeepCopy()
+	p.collectedAt = now
+	return images, nil
+}
+
+// collect lists the workspaces and selects their images according to the configuration.
+func (p *WorkspaceImagesProvider) collect(ctx *chetypes.DeployContext, config *chev2.ImagePullerWorkspaceImages, now time.Time) ([]string, error) {
+	workspaces := &dw.DevWorkspaceList{}
+	if err := ctx.ClusterAPI.NonCachingClient.List(context.TODO(), workspaces); err != nil {
+		return []string{}, err
+	}
+
+	daysSinceLastStart := ptr.Deref(config.DaysSinceLastStart, defaultDaysSinceLastStart)
+	startedAfter := now.Add(-time.Duration(daysSinceLastStart) * 24 * time.Hour)
+
+	usage := map[string]int{}
+	for i := range workspaces.Items {
+		workspace := &workspaces.Items[i]
+		if !workspace.Spec.Started && !isStartedAfter(workspace, startedAfter) {
+			continue
+		}
+
+		for _, image := range getWorkspaceImages(workspace) {
+			usage[image]++
+		}
+	}
+
+	images := make([]string, 0, len(usage))
+	for image := range usage {
+		images = append(images, image)
+	}
+
+	sort.Slice(images, func(i, j int) bool {
+		if usage[images[i]] != usage[images[j]] {
+			return usage[images[i]] > usage[images[j]]
+		}
+		return images[i] < images[j]
+	})
+
+	var imageSizes map[string]int64
+	var unknownImageSize int64
+	if config.MaxTotalSize != nil {
+		var err error
+		if imageSizes, err = getImageSizes(ctx); err != nil {
+			return []string{}, err
+		}
+
+		// An image not pulled on any node yet must not bypass the size budget
+		unknownImageSize = defaultUnknownImageSize.Value()
+		if config.UnknownImageSize != nil {
+			unknownImageSize = config.UnknownImageSize.Value()
+		}
+	}
+
+	maxImages := int(ptr.Deref(config.MaxImages, defaultMaxWorkspaceImages))
+	totalSize := int64(0)
+
+	selectedImages := make([]string, 0, maxImages)
+	for _, image := range images {
+		if len(selectedImages) >= maxImages {
+			break
+		}
+
+		if config.MaxTotalSize != nil {
+			size, known := imageSizes[image]
+			if !known {
+				size = unknownImageSize
+			}
+
+			if totalSize+size > config.MaxTotalSize.Value() {
+				continue
+			}
+			totalSize += size
+		}
+
+		selectedImages = append(selectedImages, image)
+	}
+
+	return selectedImages, nil
+}
+
+// isStartedAfter checks if the workspace was last started after the given time.
+func isStartedAfter(workspace *dw.DevWorkspace, t time.Time) bool {
+	startedAt, err := strconv.ParseInt(workspace.GetAnnotations()[dwconstants.DevWorkspaceStartedAtAnnotation], 10, 64)
+	if err != nil {
+		return false
+	}
+
+	return time.Unix(0, startedAt).After(t)
+}
+
+// getWorkspaceImages returns the distinct container images of the workspace.
+func getWorkspaceImages(workspace *dw.DevWorkspace) []string {
+	var images []string
+	for _, component := range workspace.Spec.Template.Components {
+		if component.Container == nil {
+			continue
+		}
+
+		image := strings.TrimSpace(component.Container.Image)
+		if image != "" && !slices.Contains(images, image) {
+			images = append(images, image)
+		}
+	}
+
+	return images
+}
+
+// getImageSizes returns the size of the images pulled on the cluster nodes.
+func getImageSizes(ctx *chetypes.DeployContext) (map[string]int64, error) {
+	nodes := &corev1.NodeList{}
+	if err := ctx.ClusterAPI.NonCachingClient.List(context.TODO(), nodes); err != nil {
+		return nil, err
+	}
+
+	sizes := map[string]int64{}
+	for _, node := range nodes.Items {
+		for _, image := range node.Status.Images {
+			for _, name := range image.Names {
+				sizes[name] = max(sizes[name], image.SizeBytes)
+			}
+		}
+	}
+
+	return sizes, nil
+}
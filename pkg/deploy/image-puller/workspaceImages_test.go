@@ -0,0 +1,209 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package imagepuller
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	dw "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	dwconstants "github.com/devfile/devworkspace-operator/pkg/constants"
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/eclipse-che/che-operator/pkg/common/test"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var testNow = time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+func TestGetWorkspaceImages(t *testing.T) {
+	type testCase struct {
+		name           string
+		config         *chev2.ImagePullerWorkspaceImages
+		initObjects    []client.Object
+		expectedImages []string
+	}
+
+	startedRecently := ptr.To(testNow.Add(-2 * 24 * time.Hour))
+	startedLongAgo := ptr.To(testNow.Add(-30 * 24 * time.Hour))
+
+	testCases := []testCase{
+		{
+			name:   "Images ranked by the number of workspaces",
+			config: &chev2.ImagePullerWorkspaceImages{},
+			initObjects: []client.Object{
+				InitDevWorkspace("ws-1", true, nil, "image-a", "image-b"),
+				InitDevWorkspace("ws-2", true, nil, "image-b", "image-b"),
+				InitDevWorkspace("ws-3", false, startedRecently, "image-c", "image-b"),
+				InitDevWorkspace("ws-4", false, startedRecently, "image-c"),
+			},
+			expectedImages: []string{"image-b", "image-c", "image-a"},
+		},
+		{
+			name:   "Images of the workspaces not used anymore are dropped",
+			config: &chev2.ImagePullerWorkspaceImages{DaysSinceLastStart: ptr.To(int32(7))},
+			initObjects: []client.Object{
+				InitDevWorkspace("ws-1", true, nil, "image-a"),
+				InitDevWorkspace("ws-2", false, startedLongAgo, "image-b"),
+				InitDevWorkspace("ws-3", false, nil, "image-c"),
+			},
+			expectedImages: []string{"image-a"},
+		},
+		{
+			name:   "Top N images",
+			config: &chev2.ImagePullerWorkspaceImages{MaxImages: ptr.To(int32(1))},
+			initObjects: []client.Object{
+				InitDevWorkspace("ws-1", true, nil, "image-a", "image-b"),
+				InitDevWorkspace("ws-2", true, nil, "image-b"),
+			},
+			expectedImages: []string{"image-b"},
+		},
+		{
+			name:   "Images within the size budget",
+			config: &chev2.ImagePullerWorkspaceImages{MaxTotalSize: ptr.To(resource.MustParse("1Gi"))},
+			initObjects: []client.Object{
+				InitDevWorkspace("ws-1", true, nil, "image-a", "image-b", "image-c", "image-d"),
+				InitDevWorkspace("ws-2", true, nil, "image-a", "image-b"),
+				InitDevWorkspace("ws-3", true, nil, "image-a"),
+				&corev1.Node{
+					ObjectMeta: metav1.ObjectMeta{Name: "node"},
+					Status: corev1.NodeStatus{
+						Images: []corev1.ContainerImage{
+							{Names: []string{"image-a", "image-a@sha256:1"}, SizeBytes: 600 * 1024 * 1024},
+							{Names: []string{"image-b"}, SizeBytes: 500 * 1024 * 1024},
+							{Names: []string{"image-c"}, SizeBytes: 400 * 1024 * 1024},
+						},
+					},
+				},
+			},
+			expectedImages: []string{"image-a", "image-c"},
+		},
+		{
+			name: "Images of unknown size within the size budget",
+			config: &chev2.ImagePullerWorkspaceImages{
+				MaxTotalSize:     ptr.To(resource.MustParse("1Gi")),
+				UnknownImageSize: ptr.To(resource.MustParse("24Mi")),
+			},
+			initObjects: []client.Object{
+				InitDevWorkspace("ws-1", true, nil, "image-a", "image-b", "image-c", "image-d", "image-e"),
+				InitDevWorkspace("ws-2", true, nil, "image-a", "image-b"),
+				InitDevWorkspace("ws-3", true, nil, "image-a"),
+				&corev1.Node{
+					ObjectMeta: metav1.ObjectMeta{Name: "node"},
+					Status: corev1.NodeStatus{
+						Images: []corev1.ContainerImage{
+							{Names: []string{"image-a", "image-a@sha256:1"}, SizeBytes: 600 * 1024 * 1024},
+							{Names: []string{"image-b"}, SizeBytes: 500 * 1024 * 1024},
+							{Names: []string{"image-c"}, SizeBytes: 400 * 1024 * 1024},
+						},
+					},
+				},
+			},
+			expectedImages: []string{"image-a", "image-c", "image-d"},
+		},
+		{
+			name:   "Images of unknown size only",
+			config: &chev2.ImagePullerWorkspaceImages{MaxTotalSize: ptr.To(resource.MustParse("2Gi"))},
+			initObjects: []client.Object{
+				InitDevWorkspace("ws-1", true, nil, "image-a", "image-b", "image-c"),
+			},
+			expectedImages: []string{"image-a", "image-b"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctx := test.NewCtxBuilder().WithObjects(testCase.initObjects...).Build()
+
+			p := &WorkspaceImagesProvider{now: func() time.Time { return testNow }}
+
+			images, err := p.Get(ctx, testCase.config)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expectedImages, images)
+		})
+	}
+}
+
+func TestGetWorkspaceImagesRefresh(t *testing.T) {
+	ctx := test.NewCtxBuilder().WithObjects(InitDevWorkspace("ws-1", true, nil, "image-a")).Build()
+
+	now := testNow
+	p := &WorkspaceImagesProvider{now: func() time.Time { return now }}
+	config := &chev2.ImagePullerWorkspaceImages{}
+
+	images, err := p.Get(ctx, config)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"image-a"}, images)
+
+	assert.NoError(t, ctx.ClusterAPI.Client.Create(context.TODO(), InitDevWorkspace("ws-2", true, nil, "image-b")))
+
+	// The collected images are reused within the refresh period
+	now = testNow.Add(workspaceImagesRefreshPeriod - time.Minute)
+	images, err = p.Get(ctx, config)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"image-a"}, images)
+
+	// The images are collected again once the configuration changes
+	images, err = p.Get(ctx, &chev2.ImagePullerWorkspaceImages{MaxImages: ptr.To(int32(1))})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"image-a"}, images)
+
+	images, err = p.Get(ctx, config)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"image-a", "image-b"}, images)
+
+	assert.NoError(t, ctx.ClusterAPI.Client.Create(context.TODO(), InitDevWorkspace("ws-3", true, nil, "image-c")))
+
+	// The images are collected again after the refresh period
+	now = now.Add(workspaceImagesRefreshPeriod)
+	images, err = p.Get(ctx, config)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"image-a", "image-b", "image-c"}, images)
+}
+
+func InitDevWorkspace(name string, started bool, startedAt *time.Time, images ...string) *dw.DevWorkspace {
+	workspace := &dw.DevWorkspace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "user-che",
+		},
+		Spec: dw.DevWorkspaceSpec{
+			Started: started,
+		},
+	}
+
+	if startedAt != nil {
+		workspace.Annotations = map[string]string{
+			dwconstants.DevWorkspaceStartedAtAnnotation: strconv.FormatInt(startedAt.UnixNano(), 10),
+		}
+	}
+
+	for i, image := range images {
+		workspace.Spec.Template.Components = append(workspace.Spec.Template.Components, dw.Component{
+			Name: "container-" + strconv.Itoa(i),
+			ComponentUnion: dw.ComponentUnion{
+				Container: &dw.ContainerComponent{
+					Container: dw.Container{Image: image},
+				},
+			},
+		})
+	}
+
+	return workspace
+}
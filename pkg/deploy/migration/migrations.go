@@ -0,0 +1,101 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package migration
+
+import (
+	"github.com/eclipse-che/che-operator/pkg/common/chetypes"
+)
+
+// Migration is a step run by the operator to migrate the CheCluster or the objects of a previous version.
+// Migrations must be idempotent: a migration can be interrupted by an error or an operator restart
+// and is run again then.
+type Migration struct {
+	// ID identifies the migration in the status, it must not be changed once released.
+	ID string
+	// MinVersion and MaxVersion restrict the migration to upgrades from the Che versions in [MinVersion, MaxVersion).
+	// Empty means unbounded. Migrations always apply to development versions, such as `next`.
+	MinVersion string
+	MaxVersion string
+	// OnInstallation runs the migration on installation as well, otherwise it is skipped.
+	OnInstallation bool
+	// OnOperatorStart runs the migration again once each time the operator starts,
+	// to migrate the objects created in the meantime.
+	OnOperatorStart bool
+	// Migrate runs the migration and returns true if anything was changed.
+	// Changes made to the CheCluster spec are saved by the caller.
+	Migrate func(ctx *chetypes.DeployContext) (bool, error)
+
+	// legacyField is the field recorded as processed in the `che.eclipse.org/checluster-defaults-cleanup` annotation
+	// by the operator versions before migrations were recorded in the status.
+	legacyField string
+}
+
+// getMigrations returns the registered migrations in the order they run.
+// New migrations are appended to the end of the list.
+func getMigrations() []Migration {
+	return []Migration{
+		{
+			// Objects must be labeled to be cached by the operator
+			ID:              "add-part-of-label-to-user-defined-objects",
+			OnInstallation:  true,
+			OnOperatorStart: true,
+			Migrate:         addPartOfCheLabeltoUserDefinedObjects,
+		},
+		{
+			ID:              "add-part-of-label-to-objects-with-instance-labels",
+			OnInstallation:  true,
+			OnOperatorStart: true,
+			Migrate:         addPartOfCheLabelToObjectsWithInstanceLabels,
+		},
+		// The following migrations clean up the values set by the operator in the past as defaults.
+		// The purpose of this are the following:
+		//   - productization needs, downstream version of the operator can have different defaults
+		//   - possibility to change defaults, it allows to have new values after upgrading the operator, because
+		//     previous ones are not relevant anymore and can't be changed once the CR is created
+		{
+			ID:          "cleanup-default-editor",
+			Migrate:     cleanUpDevEnvironmentsDefaultEditor,
+			legacyField: "spec.devEnvironments.defaultEditor",
+		},
+		{
+			ID:          "cleanup-default-components",
+			Migrate:     cleanUpDevEnvironmentsDefaultComponents,
+			legacyField: "spec.devEnvironments.defaultComponents",
+		},
+		{
+			ID:          "cleanup-disable-container-build-capabilities",
+			Migrate:     cleanUpDevEnvironmentsDisableContainerBuildCapabilities,
+			legacyField: "spec.devEnvironments.disableContainerBuildCapabilities",
+		},
+		{
+			ID:          "cleanup-dashboard-header-message",
+			Migrate:     cleanUpDashboardHeaderMessage,
+			legacyField: "spec.components.dashboard.headerMessage",
+		},
+		{
+			ID:          "cleanup-openvsx-url",
+			Migrate:     cleanUpPluginRegistryOpenVSXURL,
+			legacyField: "spec.components.pluginRegistry.openVSXURL",
+		},
+		{
+			ID:          "cleanup-containers-resources",
+			Migrate:     cleanUpContainersResources,
+			legacyField: "containers.resources",
+		},
+		{
+			ID:          "add-chown-capability-to-container-run-configuration",
+			Migrate:     updateDevEnvironmentsContainerRunConfiguration,
+			legacyField: "spec.devEnvironments.containerRunConfiguration.containerSecurityContext.capabilities.add",
+		},
+	}
+}
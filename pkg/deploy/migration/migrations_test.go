@@ -107,7 +107,7 @@ func TestCheClusterDefaultsCleanerShouldNotChangeValuesOnInstallation(t *testing
 			cheClusterCopy := testCase.cheCluster.DeepCopy()
 
 			ctx := test.NewCtxBuilder().WithCheCluster(testCase.cheCluster).Build()
-			cheClusterDefaultsCleanup := NewCheClusterDefaultsCleaner()
+			cheClusterDefaultsCleanup := NewMigrator()
 
 			test.EnsureReconcile(t, ctx, cheClusterDefaultsCleanup.Reconcile)
 
@@ -189,14 +189,13 @@ func TestCheClusterDefaultsCleanerDefaultEditor(t *testing.T) {
 			infrastructure.InitializeForTesting(testCase.infra)
 
 			ctx := test.NewCtxBuilder().WithCheCluster(testCase.cheCluster).Build()
-			cheClusterDefaultsCleanup := NewCheClusterDefaultsCleaner()
+			cheClusterDefaultsCleanup := NewMigrator()
 
 			test.EnsureReconcile(t, ctx, cheClusterDefaultsCleanup.Reconcile)
 
 			assert.Equal(t, testCase.expectedDefaultEditor, ctx.CheCluster.Spec.DevEnvironments.DefaultEditor)
 
-			cheClusterFields := cheClusterDefaultsCleanup.getProcessedFields(ctx)
-			assert.Equal(t, "true", cheClusterFields["spec.devEnvironments.defaultEditor"])
+			assert.True(t, isMigrationRecorded(ctx, "cleanup-default-editor"))
 
 			test.EnsureReconcile(t, ctx, cheClusterDefaultsCleanup.Reconcile)
 
@@ -306,14 +305,13 @@ func TestCheClusterDefaultsCleanerDefaultComponents(t *testing.T) {
 			infrastructure.InitializeForTesting(testCase.infra)
 
 			ctx := test.NewCtxBuilder().WithCheCluster(testCase.cheCluster).Build()
-			cheClusterDefaultsCleanup := NewCheClusterDefaultsCleaner()
+			cheClusterDefaultsCleanup := NewMigrator()
 
 			test.EnsureReconcile(t, ctx, cheClusterDefaultsCleanup.Reconcile)
 
 			assert.Equal(t, testCase.expectedDefaultComponents, ctx.CheCluster.Spec.DevEnvironments.DefaultComponents)
 
-			cheClusterFields := cheClusterDefaultsCleanup.getProcessedFields(ctx)
-			assert.Equal(t, "true", cheClusterFields["spec.devEnvironments.defaultComponents"])
+			assert.True(t, isMigrationRecorded(ctx, "cleanup-default-components"))
 
 			test.EnsureReconcile(t, ctx, cheClusterDefaultsCleanup.Reconcile)
 
@@ -395,14 +393,13 @@ func TestCheClusterDefaultsCleanerOpenVSXURL(t *testing.T) {
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
 			ctx := test.NewCtxBuilder().WithCheCluster(testCase.cheCluster).Build()
-			cheClusterDefaultsCleanup := NewCheClusterDefaultsCleaner()
+			cheClusterDefaultsCleanup := NewMigrator()
 
 			test.EnsureReconcile(t, ctx, cheClusterDefaultsCleanup.Reconcile)
 
 			assert.Equal(t, testCase.expectedOpenVSXURL, ctx.CheCluster.Spec.Components.PluginRegistry.OpenVSXURL)
 
-			cheClusterFields := cheClusterDefaultsCleanup.getProcessedFields(ctx)
-			assert.Equal(t, "true", cheClusterFields["spec.components.pluginRegistry.openVSXURL"])
+			assert.True(t, isMigrationRecorded(ctx, "cleanup-openvsx-url"))
 
 			test.EnsureReconcile(t, ctx, cheClusterDefaultsCleanup.Reconcile)
 
@@ -503,14 +500,13 @@ func TestCheClusterDefaultsCleanerDashboardHeaderMessage(t *testing.T) {
 			infrastructure.InitializeForTesting(testCase.infra)
 
 			ctx := test.NewCtxBuilder().WithCheCluster(testCase.cheCluster).Build()
-			cheClusterDefaultsCleanup := NewCheClusterDefaultsCleaner()
+			cheClusterDefaultsCleanup := NewMigrator()
 
 			test.EnsureReconcile(t, ctx, cheClusterDefaultsCleanup.Reconcile)
 
 			assert.Equal(t, testCase.expectedHeaderMessage, ctx.CheCluster.Spec.Components.Dashboard.HeaderMessage)
 
-			cheClusterFields := cheClusterDefaultsCleanup.getProcessedFields(ctx)
-			assert.Equal(t, "true", cheClusterFields["spec.components.dashboard.headerMessage"])
+			assert.True(t, isMigrationRecorded(ctx, "cleanup-dashboard-header-message"))
 
 			test.EnsureReconcile(t, ctx, cheClusterDefaultsCleanup.Reconcile)
 
@@ -568,14 +564,13 @@ func TestCheClusterDefaultsCleanerDisableContainerBuildCapabilities(t *testing.T
 			infrastructure.InitializeForTesting(testCase.infra)
 
 			ctx := test.NewCtxBuilder().WithCheCluster(testCase.cheCluster).Build()
-			cheClusterDefaultsCleanup := NewCheClusterDefaultsCleaner()
+			cheClusterDefaultsCleanup := NewMigrator()
 
 			test.EnsureReconcile(t, ctx, cheClusterDefaultsCleanup.Reconcile)
 
 			assert.Equal(t, testCase.expectedDisableContainerBuildCapabilities, ctx.CheCluster.Spec.DevEnvironments.DisableContainerBuildCapabilities)
 
-			cheClusterFields := cheClusterDefaultsCleanup.getProcessedFields(ctx)
-			assert.Equal(t, "true", cheClusterFields["spec.devEnvironments.disableContainerBuildCapabilities"])
+			assert.True(t, isMigrationRecorded(ctx, "cleanup-disable-container-build-capabilities"))
 
 			// run twice to check that fields are not changed
 			test.EnsureReconcile(t, ctx, cheClusterDefaultsCleanup.Reconcile)
@@ -706,14 +701,13 @@ func TestCheClusterDefaultsCleanerContainerResources(t *testing.T) {
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
 			ctx := test.NewCtxBuilder().WithCheCluster(testCase.cheCluster).Build()
-			cheClusterDefaultsCleanup := NewCheClusterDefaultsCleaner()
+			cheClusterDefaultsCleanup := NewMigrator()
 
 			test.EnsureReconcile(t, ctx, cheClusterDefaultsCleanup.Reconcile)
 
 			assert.Equal(t, testCase.expectedDeployment, ctx.CheCluster.Spec.Components.CheServer.Deployment)
 
-			cheClusterFields := cheClusterDefaultsCleanup.getProcessedFields(ctx)
-			assert.Equal(t, "true", cheClusterFields["containers.resources"])
+			assert.True(t, isMigrationRecorded(ctx, "cleanup-containers-resources"))
 
 			// run twice to check that fields are not changed
 			test.EnsureReconcile(t, ctx, cheClusterDefaultsCleanup.Reconcile)
@@ -859,7 +853,7 @@ func TestCheClusterDefaultsCleanerContainerRunConfiguration(t *testing.T) {
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
 			ctx := test.NewCtxBuilder().WithCheCluster(testCase.cheCluster).Build()
-			defaultsCleanup := NewCheClusterDefaultsCleaner()
+			defaultsCleanup := NewMigrator()
 
 			// run twice, to ensure nothing changes
 			for i := 0; i < 2; i++ {
@@ -878,8 +872,7 @@ func TestCheClusterDefaultsCleanerContainerRunConfiguration(t *testing.T) {
 					assert.Equal(t, testCase.expectedCapabilities, runConfiguration.ContainerSecurityContext.Capabilities.Add)
 				}
 
-				fields := defaultsCleanup.getProcessedFields(ctx)
-				assert.Equal(t, "true", fields["spec.devEnvironments.containerRunConfiguration.containerSecurityContext.capabilities.add"])
+				assert.True(t, isMigrationRecorded(ctx, "add-chown-capability-to-container-run-configuration"))
 			}
 		})
 	}
@@ -0,0 +1,249 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package migration
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/blang/semver/v4"
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/eclipse-che/che-operator/pkg/common/chetypes"
+	defaults "github.com/eclipse-che/che-operator/pkg/common/operator-defaults"
+	"github.com/eclipse-che/che-operator/pkg/common/reconciler"
+	"github.com/eclipse-che/che-operator/pkg/deploy"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var logger = ctrl.Log.WithName("migration")
+
+const (
+	// legacyMigrationsAnnotation is the annotation the processed fields were recorded in
+	// by the operator versions before migrations were recorded in the status
+	legacyMigrationsAnnotation = "che.eclipse.org/checluster-defaults-cleanup"
+
+	// dryRunCheckPeriod is the period to check whether the dry-run is disabled
+	dryRunCheckPeriod = time.Minute
+)
+
+// Migrator runs the registered migrations once, in order, and records them in the CheCluster status.
+type Migrator struct {
+	reconciler.Reconcilable
+
+	migrations []Migration
+	// startMigrationsDone holds the migrations run since the operator started, see Migration.OnOperatorStart
+	startMigrationsDone map[string]bool
+}
+
+func NewMigrator() *Migrator {
+	return &Migrator{
+		migrations:          getMigrations(),
+		startMigrationsDone: map[string]bool{},
+	}
+}
+
+func (m *Migrator) Reconcile(ctx *chetypes.DeployContext) (reconcile.Result, bool, error) {
+	status := &chev2.MigrationsStatus{}
+	if ctx.CheCluster.Status.Migrations != nil {
+		status = ctx.CheCluster.Status.Migrations.DeepCopy()
+	}
+
+	m.importLegacyRecords(ctx, status)
+
+	pending := m.getPendingMigrations(status)
+
+	if ctx.CheCluster.Spec.Upgrade != nil && ctx.CheCluster.Spec.Upgrade.MigrationsDryRun {
+		if result, paused, err := m.dryRun(ctx, status, pending); paused || err != nil {
+			return result, false, err
+		}
+	}
+	status.Pending = nil
+
+	migrated := false
+	for _, migration := range pending {
+		result := chev2.MigrationResultSkipped
+
+		if isApplicable(ctx.CheCluster, migration) {
+			done, err := migration.Migrate(ctx)
+			if err != nil {
+				// Keep the migrations completed so far
+				if updateErr := updateMigrationsStatus(ctx, status); updateErr != nil {
+					logger.Error(updateErr, "Failed to update migrations status")
+				}
+				return reconcile.Result{}, false, fmt.Errorf("migration %s failed: %w", migration.ID, err)
+			}
+
+			result = chev2.MigrationResultNotRequired
+			if done {
+				// Save changes made to the CheCluster spec, if any
+				if err := ctx.ClusterAPI.Client.Update(context.TODO(), ctx.CheCluster); err != nil {
+					return reconcile.Result{}, false, err
+				}
+
+				logger.Info("Migration applied", "id", migration.ID)
+				result = chev2.MigrationResultApplied
+				migrated = true
+			}
+		}
+
+		recordMigration(status, migration.ID, result)
+		if migration.OnOperatorStart {
+			m.startMigrationsDone[migration.ID] = true
+		}
+	}
+
+	if err := removeLegacyAnnotation(ctx); err != nil {
+		return reconcile.Result{}, false, err
+	}
+
+	if err := updateMigrationsStatus(ctx, status); err != nil {
+		return reconcile.Result{}, false, err
+	}
+
+	if migrated {
+		// Give some time for the migrated objects to be cached and rerun reconcile
+		return reconcile.Result{RequeueAfter: 5 * time.Second}, false, nil
+	}
+	return reconcile.Result{}, true, nil
+}
+
+func (m *Migrator) Finalize(_ *chetypes.DeployContext) bool {
+	return true
+}
+
+// dryRun reports the migrations to be run, and returns true if the reconciliation is paused.
+// Migrations run on every operator start, which are already recorded, are not reported.
+func (m *Migrator) dryRun(ctx *chetypes.DeployContext, status *chev2.MigrationsStatus, pending []Migration) (reconcile.Result, bool, error) {
+	status.Pending = nil
+	for _, migration := range pending {
+		if migration.OnOperatorStart && getMigrationRecord(status, migration.ID) != nil {
+			continue
+		}
+		if isApplicable(ctx.CheCluster, migration) {
+			status.Pending = append(status.Pending, migration.ID)
+		}
+	}
+
+	if err := updateMigrationsStatus(ctx, status); err != nil {
+		return reconcile.Result{}, false, err
+	}
+
+	if len(status.Pending) > 0 {
+		logger.Info("Migrations dry-run, reconciliation is paused", "pending", status.Pending)
+		return reconcile.Result{RequeueAfter: dryRunCheckPeriod}, true, nil
+	}
+	return reconcile.Result{}, false, nil
+}
+
+// getPendingMigrations returns the migrations not recorded yet,
+// or not run since the operator started if they run on every operator start.
+func (m *Migrator) getPendingMigrations(status *chev2.MigrationsStatus) []Migration {
+	var pending []Migration
+	for _, migration := range m.migrations {
+		if getMigrationRecord(status, migration.ID) == nil || (migration.OnOperatorStart && !m.startMigrationsDone[migration.ID]) {
+			pending = append(pending, migration)
+		}
+	}
+	return pending
+}
+
+// importLegacyRecords records the migrations processed by the operator versions
+// before migrations were recorded in the status.
+func (m *Migrator) importLegacyRecords(ctx *chetypes.DeployContext, status *chev2.MigrationsStatus) {
+	data := ctx.CheCluster.GetAnnotations()[legacyMigrationsAnnotation]
+	if data == "" {
+		return
+	}
+
+	fields := map[string]string{}
+	if err := json.Unmarshal([]byte(data), &fields); err != nil {
+		logger.Error(err, "Failed to unmarshal annotation", "annotation", legacyMigrationsAnnotation)
+		return
+	}
+
+	for _, migration := range m.migrations {
+		if migration.legacyField != "" && fields[migration.legacyField] == "true" && getMigrationRecord(status, migration.ID) == nil {
+			recordMigration(status, migration.ID, chev2.MigrationResultImported)
+		}
+	}
+}
+
+// isApplicable returns true if the migration applies to the installed Che version.
+func isApplicable(cheCluster *chev2.CheCluster, migration Migration) bool {
+	if cheCluster.IsCheBeingInstalled() {
+		return migration.OnInstallation
+	}
+
+	installedVersion, err := semver.ParseTolerant(cheCluster.Status.CheVersion)
+	if err != nil {
+		// Development version
+		return true
+	}
+
+	if migration.MinVersion != "" && installedVersion.LT(semver.MustParse(migration.MinVersion)) {
+		return false
+	}
+	if migration.MaxVersion != "" && installedVersion.GE(semver.MustParse(migration.MaxVersion)) {
+		return false
+	}
+	return true
+}
+
+func getMigrationRecord(status *chev2.MigrationsStatus, id string) *chev2.MigrationRecord {
+	for i := range status.Completed {
+		if status.Completed[i].ID == id {
+			return &status.Completed[i]
+		}
+	}
+	return nil
+}
+
+func recordMigration(status *chev2.MigrationsStatus, id string, result string) {
+	record := chev2.MigrationRecord{
+		ID:              id,
+		Result:          result,
+		OperatorVersion: defaults.GetCheVersion(),
+		CompletionTime:  &metav1.Time{Time: time.Now().UTC().Truncate(time.Second)},
+	}
+
+	if existing := getMigrationRecord(status, id); existing != nil {
+		*existing = record
+	} else {
+		status.Completed = append(status.Completed, record)
+	}
+}
+
+func removeLegacyAnnotation(ctx *chetypes.DeployContext) error {
+	annotations := ctx.CheCluster.GetAnnotations()
+	if _, ok := annotations[legacyMigrationsAnnotation]; !ok {
+		return nil
+	}
+
+	delete(annotations, legacyMigrationsAnnotation)
+	ctx.CheCluster.SetAnnotations(annotations)
+	return ctx.ClusterAPI.Client.Update(context.TODO(), ctx.CheCluster)
+}
+
+func updateMigrationsStatus(ctx *chetypes.DeployContext, status *chev2.MigrationsStatus) error {
+	if reflect.DeepEqual(ctx.CheCluster.Status.Migrations, status) {
+		return nil
+	}
+
+	ctx.CheCluster.Status.Migrations = status
+	return deploy.UpdateCheCRStatus(ctx, "migrations", fmt.Sprintf("%d completed, %d pending", len(status.Completed), len(status.Pending)))
+}
@@ -0,0 +1,214 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package migration
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/eclipse-che/che-operator/pkg/common/chetypes"
+	defaults "github.com/eclipse-che/che-operator/pkg/common/operator-defaults"
+	"github.com/eclipse-che/che-operator/pkg/common/test"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMigrator(t *testing.T) {
+	ctx := test.NewCtxBuilder().WithCheCluster(
+		&chev2.CheCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "eclipse-che",
+				Namespace: "eclipse-che",
+			},
+			Status: chev2.CheClusterStatus{
+				CheVersion: "7.90.0",
+			},
+		},
+	).Build()
+
+	runs := map[string]int{}
+	migrate := func(id string, changed bool, err error) func(ctx *chetypes.DeployContext) (bool, error) {
+		return func(ctx *chetypes.DeployContext) (bool, error) {
+			runs[id]++
+			if changed {
+				ctx.CheCluster.Spec.DevEnvironments.DefaultEditor = id
+			}
+			return changed, err
+		}
+	}
+
+	migrations := []Migration{
+		{ID: "applied", Migrate: migrate("applied", true, nil)},
+		{ID: "not-required", Migrate: migrate("not-required", false, nil)},
+		{ID: "too-old", MinVersion: "7.95.0", Migrate: migrate("too-old", true, nil)},
+		{ID: "too-new", MaxVersion: "7.90.0", Migrate: migrate("too-new", true, nil)},
+		{ID: "every-start", OnOperatorStart: true, Migrate: migrate("every-start", false, nil)},
+	}
+	migrator := &Migrator{migrations: migrations, startMigrationsDone: map[string]bool{}}
+
+	// Dry-run reports pending migrations and pauses the reconciliation
+	ctx.CheCluster.Spec.Upgrade = &chev2.CheClusterUpgrade{MigrationsDryRun: true}
+	assert.NoError(t, ctx.ClusterAPI.Client.Update(context.TODO(), ctx.CheCluster))
+
+	_, done, err := migrator.Reconcile(ctx)
+	assert.NoError(t, err)
+	assert.False(t, done)
+	assert.Equal(t, []string{"applied", "not-required", "every-start"}, ctx.CheCluster.Status.Migrations.Pending)
+	assert.Empty(t, ctx.CheCluster.Status.Migrations.Completed)
+	assert.Empty(t, runs)
+
+	// Run migrations
+	ctx.CheCluster.Spec.Upgrade = nil
+	assert.NoError(t, ctx.ClusterAPI.Client.Update(context.TODO(), ctx.CheCluster))
+
+	test.EnsureReconcile(t, ctx, migrator.Reconcile)
+
+	assert.Equal(t, "applied", ctx.CheCluster.Spec.DevEnvironments.DefaultEditor)
+	assert.Empty(t, ctx.CheCluster.Status.Migrations.Pending)
+	assert.Equal(t, map[string]int{"applied": 1, "not-required": 1, "every-start": 1}, runs)
+	assertMigrationResult(t, ctx, "applied", chev2.MigrationResultApplied)
+	assertMigrationResult(t, ctx, "not-required", chev2.MigrationResultNotRequired)
+	assertMigrationResult(t, ctx, "too-old", chev2.MigrationResultSkipped)
+	assertMigrationResult(t, ctx, "too-new", chev2.MigrationResultSkipped)
+	assertMigrationResult(t, ctx, "every-start", chev2.MigrationResultNotRequired)
+
+	// Migrations are not run again
+	test.EnsureReconcile(t, ctx, migrator.Reconcile)
+	assert.Equal(t, map[string]int{"applied": 1, "not-required": 1, "every-start": 1}, runs)
+
+	// Migrations run on every operator start are run again after a restart, even in dry-run
+	ctx.CheCluster.Spec.Upgrade = &chev2.CheClusterUpgrade{MigrationsDryRun: true}
+	assert.NoError(t, ctx.ClusterAPI.Client.Update(context.TODO(), ctx.CheCluster))
+
+	migrator = &Migrator{migrations: migrations, startMigrationsDone: map[string]bool{}}
+	test.EnsureReconcile(t, ctx, migrator.Reconcile)
+	assert.Empty(t, ctx.CheCluster.Status.Migrations.Pending)
+	assert.Equal(t, map[string]int{"applied": 1, "not-required": 1, "every-start": 2}, runs)
+
+	test.EnsureReconcile(t, ctx, migrator.Reconcile)
+	assert.Equal(t, map[string]int{"applied": 1, "not-required": 1, "every-start": 2}, runs)
+}
+
+func TestMigratorOnInstallation(t *testing.T) {
+	ctx := test.NewCtxBuilder().WithCheCluster(
+		&chev2.CheCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "eclipse-che",
+				Namespace: "eclipse-che",
+			},
+		},
+	).Build()
+
+	runs := map[string]int{}
+	migrator := &Migrator{
+		migrations: []Migration{
+			{
+				ID: "upgrade-only",
+				Migrate: func(ctx *chetypes.DeployContext) (bool, error) {
+					runs["upgrade-only"]++
+					return false, nil
+				},
+			},
+			{
+				ID:             "on-installation",
+				OnInstallation: true,
+				Migrate: func(ctx *chetypes.DeployContext) (bool, error) {
+					runs["on-installation"]++
+					return false, nil
+				},
+			},
+		},
+	}
+
+	test.EnsureReconcile(t, ctx, migrator.Reconcile)
+
+	assert.Equal(t, map[string]int{"on-installation": 1}, runs)
+	assertMigrationResult(t, ctx, "upgrade-only", chev2.MigrationResultSkipped)
+	assertMigrationResult(t, ctx, "on-installation", chev2.MigrationResultNotRequired)
+}
+
+func TestMigratorFailure(t *testing.T) {
+	ctx := test.NewCtxBuilder().WithCheCluster(
+		&chev2.CheCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "eclipse-che",
+				Namespace: "eclipse-che",
+			},
+			Status: chev2.CheClusterStatus{
+				CheVersion: "next",
+			},
+		},
+	).Build()
+
+	failure := errors.New("failure")
+	migrator := &Migrator{
+		migrations: []Migration{
+			{
+				ID:      "succeeded",
+				Migrate: func(ctx *chetypes.DeployContext) (bool, error) { return false, nil },
+			},
+			{
+				ID:      "failed",
+				Migrate: func(ctx *chetypes.DeployContext) (bool, error) { return false, failure },
+			},
+		},
+	}
+
+	_, done, err := migrator.Reconcile(ctx)
+	assert.ErrorIs(t, err, failure)
+	assert.False(t, done)
+	assertMigrationResult(t, ctx, "succeeded", chev2.MigrationResultNotRequired)
+	assert.False(t, isMigrationRecorded(ctx, "failed"))
+}
+
+func TestMigratorImportsLegacyAnnotation(t *testing.T) {
+	ctx := test.NewCtxBuilder().WithCheCluster(
+		&chev2.CheCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "eclipse-che",
+				Namespace: "eclipse-che",
+				Annotations: map[string]string{
+					legacyMigrationsAnnotation: `{"spec.devEnvironments.defaultEditor":"true"}`,
+				},
+			},
+			Spec: chev2.CheClusterSpec{
+				DevEnvironments: chev2.CheClusterDevEnvironments{
+					DefaultEditor: defaults.GetDevEnvironmentsDefaultEditor(),
+				},
+			},
+			Status: chev2.CheClusterStatus{
+				CheVersion: "next",
+			},
+		},
+	).Build()
+
+	test.EnsureReconcile(t, ctx, NewMigrator().Reconcile)
+
+	// The default editor is not cleaned up again
+	assert.Equal(t, defaults.GetDevEnvironmentsDefaultEditor(), ctx.CheCluster.Spec.DevEnvironments.DefaultEditor)
+	assertMigrationResult(t, ctx, "cleanup-default-editor", chev2.MigrationResultImported)
+	assert.True(t, isMigrationRecorded(ctx, "cleanup-default-components"))
+	assert.NotContains(t, ctx.CheCluster.Annotations, legacyMigrationsAnnotation)
+}
+
+func isMigrationRecorded(ctx *chetypes.DeployContext, id string) bool {
+	return ctx.CheCluster.Status.Migrations != nil && getMigrationRecord(ctx.CheCluster.Status.Migrations, id) != nil
+}
+
+func assertMigrationResult(t *testing.T, ctx *chetypes.DeployContext, id string, result string) {
+	assert.True(t, isMigrationRecorded(ctx, id))
+	assert.Equal(t, result, getMigrationRecord(ctx.CheCluster.Status.Migrations, id).Result)
+	assert.Equal(t, defaults.GetCheVersion(), getMigrationRecord(ctx.CheCluster.Status.Migrations, id).OperatorVersion)
+}
@@ -15,13 +15,11 @@ package migration
 import (
 	"context"
 	"fmt"
-	"time"
 
 	"github.com/eclipse-che/che-operator/pkg/common/chetypes"
 	"github.com/eclipse-che/che-operator/pkg/common/constants"
 	"github.com/eclipse-che/che-operator/pkg/common/infrastructure"
 	defaults "github.com/eclipse-che/che-operator/pkg/common/operator-defaults"
-	"github.com/eclipse-che/che-operator/pkg/common/reconciler"
 	"github.com/eclipse-che/che-operator/pkg/common/utils"
 	"github.com/eclipse-che/che-operator/pkg/deploy"
 	oauthv1 "github.com/openshift/api/oauth/v1"
@@ -39,54 +37,24 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
-	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
-type Migrator struct {
-	reconciler.Reconcilable
-
-	migrationDone bool
-}
-
-func NewMigrator() *Migrator {
-	return &Migrator{
-		migrationDone: false,
-	}
-}
-
-func (m *Migrator) Reconcile(ctx *chetypes.DeployContext) (reconcile.Result, bool, error) {
-	if m.migrationDone {
-		return reconcile.Result{}, true, nil
-	}
-
-	done, err := m.migrate(ctx)
-	if done && err == nil {
-		m.migrationDone = true
-		// Give some time for the migration resources to be flushed and rerun reconcile
-		return reconcile.Result{RequeueAfter: 5 * time.Second}, false, err
-	}
-	return reconcile.Result{}, done, err
-}
-
-func (m *Migrator) Finalize(ctx *chetypes.DeployContext) bool {
-	return true
-}
-
-func (m *Migrator) migrate(ctx *chetypes.DeployContext) (bool, error) {
-	if err := addPartOfCheLabeltoUserDefinedObjects(ctx); err != nil {
-		return false, err
-	}
-
+// addPartOfCheLabelToObjectsWithInstanceLabels adds 'app.kubernetes.io/part-of=che.eclipse.org' label
+// to the objects created by previous operator versions, which are labeled with the Che flavor only.
+func addPartOfCheLabelToObjectsWithInstanceLabels(ctx *chetypes.DeployContext) (bool, error) {
 	cheFlavor := defaults.GetCheFlavor()
-	if err := addPartOfCheLabelForObjectsWithLabel(ctx, constants.KubernetesInstanceLabelKey, cheFlavor); err != nil {
+
+	migratedByInstanceLabel, err := addPartOfCheLabelForObjectsWithLabel(ctx, constants.KubernetesInstanceLabelKey, cheFlavor)
+	if err != nil {
 		return false, err
 	}
 
-	if err := addPartOfCheLabelForObjectsWithLabel(ctx, "app", cheFlavor); err != nil {
+	migratedByAppLabel, err := addPartOfCheLabelForObjectsWithLabel(ctx, "app", cheFlavor)
+	if err != nil {
 		return false, err
 	}
 
-	return true, nil
+	return migratedByInstanceLabel || migratedByAppLabel, nil
 }
 
 // addPartOfCheLabeltoUserDefinedObjects processes the following objects to add 'app.kubernetes.io/part-of=che.eclipse.org' label:
@@ -97,77 +65,76 @@ func (m *Migrator) migrate(ctx *chetypes.DeployContext) (bool, error) {
 // - spec.networking.tlsSecretName
 // Note, most of the objects above are autogenerated and do not require any migration,
 // but to handle the case when some were created manually or operator updated, the check is done here.
-func addPartOfCheLabeltoUserDefinedObjects(ctx *chetypes.DeployContext) error {
+func addPartOfCheLabeltoUserDefinedObjects(ctx *chetypes.DeployContext) (bool, error) {
+	var secretNames []string
+	var configMapNames []string
+
 	if !infrastructure.IsOpenShift() {
 		// Kubernetes only
-		tlsSecretName := utils.GetValue(ctx.CheCluster.Spec.Networking.TlsSecretName, constants.DefaultCheTLSSecretName)
-		if err := addPartOfCheLabelToSecret(ctx, tlsSecretName); err != nil {
-			return err
-		}
+		secretNames = append(secretNames, utils.GetValue(ctx.CheCluster.Spec.Networking.TlsSecretName, constants.DefaultCheTLSSecretName))
 	}
 
 	// TLS
-	if err := addPartOfCheLabelToSecret(ctx, constants.DefaultSelfSignedCertificateSecretName); err != nil {
-		return err
-	}
+	secretNames = append(secretNames, constants.DefaultSelfSignedCertificateSecretName)
 
 	// Proxy credentials
 	if ctx.CheCluster.Spec.Components.CheServer.Proxy != nil {
-		proxyCredentialsSecret := utils.GetValue(ctx.CheCluster.Spec.Components.CheServer.Proxy.CredentialsSecretName, constants.DefaultProxyCredentialsSecret)
-		if err := addPartOfCheLabelToSecret(ctx, proxyCredentialsSecret); err != nil {
-			return err
-		}
+		secretNames = append(secretNames, utils.GetValue(ctx.CheCluster.Spec.Components.CheServer.Proxy.CredentialsSecretName, constants.DefaultProxyCredentialsSecret))
 	}
 
 	// Legacy config map with additional CA certificates
-	if err := addPartOfCheLabelToConfigMap(ctx, constants.DefaultCaBundleCertsCMName); err != nil {
-		return err
-	}
+	configMapNames = append(configMapNames, constants.DefaultCaBundleCertsCMName)
 
 	// Config map with CA certificates for git
 	if ctx.CheCluster.Spec.DevEnvironments.TrustedCerts != nil {
-		gitTrustedCertsConfigMapName := utils.GetValue(ctx.CheCluster.Spec.DevEnvironments.TrustedCerts.GitTrustedCertsConfigMapName, constants.DefaultGitSelfSignedCertsConfigMapName)
-		if err := addPartOfCheLabelToConfigMap(ctx, gitTrustedCertsConfigMapName); err != nil {
-			return err
-		}
+		configMapNames = append(configMapNames, utils.GetValue(ctx.CheCluster.Spec.DevEnvironments.TrustedCerts.GitTrustedCertsConfigMapName, constants.DefaultGitSelfSignedCertsConfigMapName))
 	}
 
-	return nil
-}
+	migrated := false
+	for _, secretName := range secretNames {
+		done, err := addPartOfCheLabelToObject(ctx, secretName, &corev1.Secret{})
+		if err != nil {
+			return false, err
+		}
+		migrated = migrated || done
+	}
 
-func addPartOfCheLabelToSecret(ctx *chetypes.DeployContext, secretName string) error {
-	return addPartOfCheLabelToObject(ctx, secretName, &corev1.Secret{})
-}
+	for _, configMapName := range configMapNames {
+		done, err := addPartOfCheLabelToObject(ctx, configMapName, &corev1.ConfigMap{})
+		if err != nil {
+			return false, err
+		}
+		migrated = migrated || done
+	}
 
-func addPartOfCheLabelToConfigMap(ctx *chetypes.DeployContext, configMapName string) error {
-	return addPartOfCheLabelToObject(ctx, configMapName, &corev1.ConfigMap{})
+	return migrated, nil
 }
 
 // addPartOfCheLabelToObject adds 'app.kubernetes.io/part-of=che.eclipse.org' label to the object with given name to be cached by operator's k8s client.
 // As the function doesn't know the kind of the object with given name an empty object should be passed,
 // for example: addPartOfCheLabelToObject(ctx, "my-secret", &corev1.Secret{})
-func addPartOfCheLabelToObject(ctx *chetypes.DeployContext, objectName string, obj client.Object) error {
+func addPartOfCheLabelToObject(ctx *chetypes.DeployContext, objectName string, obj client.Object) (bool, error) {
 	// Check if the object is already migrated
 	if exists, _ := deploy.GetNamespacedObject(ctx, objectName, obj); exists {
 		// Default client sees the object in cache, no need in adding anything
-		return nil
+		return false, nil
 	}
 
 	err := ctx.ClusterAPI.NonCachingClient.Get(context.TODO(), types.NamespacedName{Namespace: ctx.CheCluster.Namespace, Name: objectName}, obj)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			// The object doesn't exist in cluster, nothing to do
-			return nil
+			return false, nil
 		}
-		return err
+		return false, err
 	}
 
 	if err := ctx.ClusterAPI.NonCachingClient.Update(context.TODO(), setPartOfLabel(obj)); err != nil {
-		return err
+		return false, err
 	}
 	logrus.Info(getObjectMigratedMessage(obj))
 
-	return nil
+	return true, nil
 }
 
 func setPartOfLabel(obj client.Object) client.Object {
@@ -182,18 +149,18 @@ func setPartOfLabel(obj client.Object) client.Object {
 
 // addPartOfCheLabelForObjectsWithLabel searches for objects in Che installation namespace,
 // that have given label and adds 'app.kubernetes.io/part-of=che.eclipse.org'
-func addPartOfCheLabelForObjectsWithLabel(ctx *chetypes.DeployContext, labelKey string, labelValue string) error {
+func addPartOfCheLabelForObjectsWithLabel(ctx *chetypes.DeployContext, labelKey string, labelValue string) (bool, error) {
 	// Prepare selector for all instance=che objects in the installation namespace
 	instanceCheSelectorRequirement, err := labels.NewRequirement(labelKey, selection.Equals, []string{labelValue})
 	if err != nil {
 		logrus.Error(getFailedToCreateSelectorErrorMessage())
-		return err
+		return false, err
 	}
 	// Do not migrate already migrated objects
 	notPartOfCheSelectorRequirement, err := labels.NewRequirement(constants.KubernetesPartOfLabelKey, selection.NotEquals, []string{constants.CheEclipseOrg})
 	if err != nil {
 		logrus.Error(getFailedToCreateSelectorErrorMessage())
-		return err
+		return false, err
 	}
 	objectsToMigrateLabelSelector := labels.NewSelector().
 		Add(*instanceCheSelectorRequirement).
@@ -229,39 +196,42 @@ func addPartOfCheLabelForObjectsWithLabel(ctx *chetypes.DeployContext, labelKey
 		kindsToMigrate = append(kindsToMigrate, &oauthv1.OAuthClientList{})
 	}
 
+	migrated := false
 	for _, listToGet := range kindsToMigrate {
-		if err := addPartOfCheLabelToObjectsBySelector(ctx, listOptions, listToGet); err != nil {
-			return err
+		done, err := addPartOfCheLabelToObjectsBySelector(ctx, listOptions, listToGet)
+		if err != nil {
+			return false, err
 		}
+		migrated = migrated || done
 	}
 
-	return nil
+	return migrated, nil
 }
 
 // addPartOfCheLabelToObjectsBySelector adds 'app.kubernetes.io/part-of=che.eclipse.org' label to all objects
 // of given objectsList kind that match the provided in listOptions selector and namespace.
-func addPartOfCheLabelToObjectsBySelector(ctx *chetypes.DeployContext, listOptions *client.ListOptions, objectsList client.ObjectList) error {
+func addPartOfCheLabelToObjectsBySelector(ctx *chetypes.DeployContext, listOptions *client.ListOptions, objectsList client.ObjectList) (bool, error) {
 	if err := ctx.ClusterAPI.NonCachingClient.List(context.TODO(), objectsList, listOptions); err != nil {
 		if gvk, err := apiutil.GVKForObject(objectsList, ctx.ClusterAPI.Scheme); err == nil {
 			logrus.Warnf("Failed to get %s to add %s label", gvk.Kind, constants.KubernetesPartOfLabelKey)
 		}
-		return err
+		return false, err
 	}
 
 	objects, err := meta.ExtractList(objectsList)
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	for _, runtimeObj := range objects {
 		obj := setPartOfLabel(runtimeObj.(client.Object))
 		if err := ctx.ClusterAPI.NonCachingClient.Update(context.TODO(), obj); err != nil {
-			return err
+			return false, err
 		}
 		logrus.Info(getObjectMigratedMessage(obj))
 	}
 
-	return nil
+	return len(objects) > 0, nil
 }
 
 func getFailedToCreateSelectorErrorMessage() string {
@@ -0,0 +1,239 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package namespaceprovisioning
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/eclipse-che/che-operator/pkg/common/chetypes"
+	"github.com/eclipse-che/che-operator/pkg/common/constants"
+	"github.com/eclipse-che/che-operator/pkg/common/infrastructure"
+	"github.com/eclipse-che/che-operator/pkg/common/reconciler"
+	"github.com/eclipse-che/che-operator/pkg/deploy"
+	projectv1 "github.com/openshift/api/project/v1"
+	userv1 "github.com/openshift/api/user/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// NamespaceProvisioningReconciler creates the namespaces of the users listed in
+// `spec.devEnvironments.defaultNamespace.provisioning` before they start their first workspace.
+// The namespaces are labeled and annotated the same way as the ones created by Che server,
+// so they are recognized as user namespaces. Namespaces are never deleted.
+type NamespaceProvisioningReconciler struct {
+	reconciler.Reconcilable
+}
+
+// provisioningResult is the outcome of provisioning the namespace of a single user.
+type provisioningResult int
+
+const (
+	provisioned provisioningResult = iota
+	pending
+	skipped
+)
+
+func NewNamespaceProvisioningReconciler() *NamespaceProvisioningReconciler {
+	return &NamespaceProvisioningReconciler{}
+}
+
+func (r *NamespaceProvisioningReconciler) Reconcile(ctx *chetypes.DeployContext) (reconcile.Result, bool, error) {
+	if !ctx.CheCluster.IsNamespaceProvisioningEnabled() {
+		if err := syncNamespacesProvisionedCondition(ctx, nil); err != nil {
+			return reconcile.Result{}, false, err
+		}
+		return reconcile.Result{}, true, nil
+	}
+
+	usernames, err := getProvisionedUsers(ctx)
+	if err != nil {
+		return reconcile.Result{}, false, err
+	}
+
+	isPending := false
+	var skippedMessages []string
+	for _, username := range usernames {
+		result, message, err := provisionNamespace(ctx, username)
+		if err != nil {
+			return reconcile.Result{}, false, fmt.Errorf("failed to provision namespace for user %s: %w", username, err)
+		}
+
+		switch result {
+		case pending:
+			isPending = true
+		case skipped:
+			skippedMessages = append(skippedMessages, message)
+		}
+	}
+
+	condition := &metav1.Condition{
+		Type:               chev2.NamespacesProvisionedCondition,
+		ObservedGeneration: ctx.CheCluster.Generation,
+		Status:             metav1.ConditionTrue,
+		Reason:             chev2.NamespacesProvisionedReasonProvisioned,
+		Message:            fmt.Sprintf("Namespaces are provisioned for %d user(s)", len(usernames)-len(skippedMessages)),
+	}
+	if len(skippedMessages) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = chev2.NamespacesProvisionedReasonPartiallyDone
+		condition.Message = "Namespaces are not provisioned for some users: " + strings.Join(skippedMessages, "; ")
+	}
+
+	if err := syncNamespacesProvisionedCondition(ctx, condition); err != nil {
+		return reconcile.Result{}, false, err
+	}
+
+	// Namespaces requested through the ProjectRequest API are labeled on the next reconcile.
+	// A skipped user namespace does not block other components.
+	if isPending {
+		return reconcile.Result{RequeueAfter: 5 * time.Second}, true, nil
+	}
+	return reconcile.Result{}, true, nil
+}
+
+func (r *NamespaceProvisioningReconciler) Finalize(_ *chetypes.DeployContext) bool {
+	return true
+}
+
+// getProvisionedUsers returns the users listed explicitly and the members of the listed groups.
+// Groups are resolved from the OpenShift `Group` resources.
+func getProvisionedUsers(ctx *chetypes.DeployContext) ([]string, error) {
+	provisioning := ctx.CheCluster.Spec.DevEnvironments.DefaultNamespace.Provisioning
+
+	usernames := slices.Clone(provisioning.Users)
+	if infrastructure.IsOpenShiftOAuthEnabled() {
+		for _, groupName := range provisioning.Groups {
+			group := &userv1.Group{}
+			exists, err := ctx.ClusterAPI.NonCachingClientWrapper.GetIgnoreNotFound(context.TODO(), client.ObjectKey{Name: groupName}, group)
+			if err != nil {
+				return nil, err
+			}
+
+			if exists {
+				usernames = append(usernames, group.Users...)
+			}
+		}
+	}
+
+	slices.Sort(usernames)
+	return slices.Compact(usernames), nil
+}
+
+// provisionNamespace creates the namespace of the user or labels and annotates
+// the existing one. The namespace owned by another user is skipped.
+func provisionNamespace(ctx *chetypes.DeployContext, username string) (provisioningResult, string, error) {
+	name := ctx.CheCluster.GetUserNamespaceName(username)
+	if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+		return skipped, fmt.Sprintf("invalid namespace name %s for user %s", name, username), nil
+	}
+
+	ns := &corev1.Namespace{}
+	exists, err := ctx.ClusterAPI.NonCachingClientWrapper.GetIgnoreNotFound(context.TODO(), client.ObjectKey{Name: name}, ns)
+	if err != nil {
+		return skipped, "", err
+	}
+
+	if !exists {
+		if infrastructure.IsOpenShift() && !ptr.Deref(ctx.CheCluster.Spec.DevEnvironments.DefaultNamespace.CreateKubernetesNamespaces, constants.OpenShiftCreateKubernetesNamespaces) {
+			// Project templates are applied only to the projects requested with the ProjectRequest API
+			projectRequest := &projectv1.ProjectRequest{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "ProjectRequest",
+					APIVersion: projectv1.GroupVersion.String(),
+				},
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+			}
+			return pending, "", client.IgnoreAlreadyExists(ctx.ClusterAPI.NonCachingClientWrapper.Create(context.TODO(), projectRequest))
+		}
+
+		ns = &corev1.Namespace{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Namespace",
+				APIVersion: corev1.SchemeGroupVersion.String(),
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Labels:      getUserNamespaceLabels(),
+				Annotations: map[string]string{constants.CheEclipseOrgUsername: username},
+			},
+		}
+		return provisioned, "", ctx.ClusterAPI.NonCachingClientWrapper.Create(context.TODO(), ns)
+	}
+
+	if owner := ns.GetAnnotations()[constants.CheEclipseOrgUsername]; owner != "" && owner != username {
+		return skipped, fmt.Sprintf("namespace %s is owned by user %s", name, owner), nil
+	}
+
+	labels := ns.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	annotations := ns.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	changed := annotations[constants.CheEclipseOrgUsername] != username
+	annotations[constants.CheEclipseOrgUsername] = username
+	for key, value := range getUserNamespaceLabels() {
+		changed = changed || labels[key] != value
+		labels[key] = value
+	}
+
+	if !changed {
+		return provisioned, "", nil
+	}
+
+	ns.SetLabels(labels)
+	ns.SetAnnotations(annotations)
+	return provisioned, "", ctx.ClusterAPI.NonCachingClient.Update(context.TODO(), ns)
+}
+
+// getUserNamespaceLabels returns the labels Che server sets on the user namespaces,
+// see namespacecache.NamespaceCache.
+func getUserNamespaceLabels() map[string]string {
+	return map[string]string{
+		constants.KubernetesPartOfLabelKey:    constants.CheEclipseOrg,
+		constants.KubernetesComponentLabelKey: constants.WorkspacesNamespaceComponentName,
+	}
+}
+
+// syncNamespacesProvisionedCondition sets the condition or removes it if nil.
+func syncNamespacesProvisionedCondition(ctx *chetypes.DeployContext, condition *metav1.Condition) error {
+	conditions := make([]metav1.Condition, len(ctx.CheCluster.Status.Conditions))
+	copy(conditions, ctx.CheCluster.Status.Conditions)
+
+	if condition == nil {
+		meta.RemoveStatusCondition(&conditions, chev2.NamespacesProvisionedCondition)
+	} else {
+		meta.SetStatusCondition(&conditions, *condition)
+	}
+
+	if reflect.DeepEqual(ctx.CheCluster.Status.Conditions, conditions) {
+		return nil
+	}
+
+	ctx.CheCluster.Status.Conditions = conditions
+	return deploy.UpdateCheCRStatus(ctx, "Conditions", chev2.NamespacesProvisionedCondition)
+}
@@ -0,0 +1,156 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package namespaceprovisioning
+
+import (
+	"context"
+	"testing"
+
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/eclipse-che/che-operator/pkg/common/constants"
+	"github.com/eclipse-che/che-operator/pkg/common/infrastructure"
+	"github.com/eclipse-che/che-operator/pkg/common/test"
+	projectv1 "github.com/openshift/api/project/v1"
+	userv1 "github.com/openshift/api/user/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestProvisionNamespacesForUsersAndGroups(t *testing.T) {
+	infrastructure.InitializeForTesting(infrastructure.OpenShiftV4)
+	defer infrastructure.InitializeForTesting(infrastructure.OpenShiftV4)
+
+	group := &userv1.Group{
+		ObjectMeta: metav1.ObjectMeta{Name: "developers"},
+		Users:      []string{"alice", "bob"},
+	}
+
+	ctx := test.NewCtxBuilder().WithObjects(group).Build()
+	ctx.CheCluster.Spec.DevEnvironments.DefaultNamespace = chev2.DefaultNamespace{
+		Template:                   "<username>-che",
+		CreateKubernetesNamespaces: ptr.To(true),
+		Provisioning: &chev2.NamespaceProvisioning{
+			Users:  []string{"alice", "john"},
+			Groups: []string{"developers"},
+		},
+	}
+
+	test.EnsureReconcile(t, ctx, NewNamespaceProvisioningReconciler().Reconcile)
+
+	for _, username := range []string{"alice", "bob", "john"} {
+		ns := &corev1.Namespace{}
+		err := ctx.ClusterAPI.Client.Get(context.TODO(), client.ObjectKey{Name: username + "-che"}, ns)
+		assert.NoError(t, err)
+		assert.Equal(t, constants.CheEclipseOrg, ns.Labels[constants.KubernetesPartOfLabelKey])
+		assert.Equal(t, constants.WorkspacesNamespaceComponentName, ns.Labels[constants.KubernetesComponentLabelKey])
+		assert.Equal(t, username, ns.Annotations[constants.CheEclipseOrgUsername])
+	}
+
+	condition := meta.FindStatusCondition(ctx.CheCluster.Status.Conditions, chev2.NamespacesProvisionedCondition)
+	assert.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, chev2.NamespacesProvisionedReasonProvisioned, condition.Reason)
+}
+
+func TestProvisionNamespaceLabelsExistingNamespace(t *testing.T) {
+	infrastructure.InitializeForTesting(infrastructure.Kubernetes)
+	defer infrastructure.InitializeForTesting(infrastructure.OpenShiftV4)
+
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "john-che",
+			Labels: map[string]string{"team": "a"},
+		},
+	}
+
+	ctx := test.NewCtxBuilder().WithObjects(ns).Build()
+	ctx.CheCluster.Spec.DevEnvironments.DefaultNamespace = chev2.DefaultNamespace{
+		Template:     "<username>-che",
+		Provisioning: &chev2.NamespaceProvisioning{Users: []string{"john"}},
+	}
+
+	test.EnsureReconcile(t, ctx, NewNamespaceProvisioningReconciler().Reconcile)
+
+	err := ctx.ClusterAPI.Client.Get(context.TODO(), client.ObjectKey{Name: "john-che"}, ns)
+	assert.NoError(t, err)
+	assert.Equal(t, "a", ns.Labels["team"])
+	assert.Equal(t, constants.WorkspacesNamespaceComponentName, ns.Labels[constants.KubernetesComponentLabelKey])
+	assert.Equal(t, "john", ns.Annotations[constants.CheEclipseOrgUsername])
+}
+
+func TestProvisionNamespaceSkipsNamespaceOwnedByAnotherUser(t *testing.T) {
+	infrastructure.InitializeForTesting(infrastructure.Kubernetes)
+	defer infrastructure.InitializeForTesting(infrastructure.OpenShiftV4)
+
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "che",
+			Annotations: map[string]string{constants.CheEclipseOrgUsername: "alice"},
+		},
+	}
+
+	ctx := test.NewCtxBuilder().WithObjects(ns).Build()
+	ctx.CheCluster.Spec.DevEnvironments.DefaultNamespace = chev2.DefaultNamespace{
+		Template:     "che",
+		Provisioning: &chev2.NamespaceProvisioning{Users: []string{"john"}},
+	}
+
+	test.EnsureReconcile(t, ctx, NewNamespaceProvisioningReconciler().Reconcile)
+
+	err := ctx.ClusterAPI.Client.Get(context.TODO(), client.ObjectKey{Name: "che"}, ns)
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", ns.Annotations[constants.CheEclipseOrgUsername])
+
+	condition := meta.FindStatusCondition(ctx.CheCluster.Status.Conditions, chev2.NamespacesProvisionedCondition)
+	assert.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, chev2.NamespacesProvisionedReasonPartiallyDone, condition.Reason)
+}
+
+func TestProvisionNamespaceRequestsProjectOnOpenShift(t *testing.T) {
+	infrastructure.InitializeForTesting(infrastructure.OpenShiftV4)
+
+	ctx := test.NewCtxBuilder().Build()
+	ctx.CheCluster.Spec.DevEnvironments.DefaultNamespace = chev2.DefaultNamespace{
+		Template:     "<username>-che",
+		Provisioning: &chev2.NamespaceProvisioning{Users: []string{"john"}},
+	}
+
+	result, done, err := NewNamespaceProvisioningReconciler().Reconcile(ctx)
+	assert.NoError(t, err)
+	assert.True(t, done)
+	assert.NotZero(t, result.RequeueAfter)
+
+	projectRequest := &projectv1.ProjectRequest{}
+	err = ctx.ClusterAPI.Client.Get(context.TODO(), client.ObjectKey{Name: "john-che"}, projectRequest)
+	assert.NoError(t, err)
+}
+
+func TestRemoveNamespacesProvisionedConditionWhenDisabled(t *testing.T) {
+	ctx := test.NewCtxBuilder().Build()
+	ctx.CheCluster.Status.Conditions = []metav1.Condition{
+		{
+			Type:   chev2.NamespacesProvisionedCondition,
+			Status: metav1.ConditionTrue,
+			Reason: chev2.NamespacesProvisionedReasonProvisioned,
+		},
+	}
+
+	test.EnsureReconcile(t, ctx, NewNamespaceProvisioningReconciler().Reconcile)
+
+	assert.Nil(t, meta.FindStatusCondition(ctx.CheCluster.Status.Conditions, chev2.NamespacesProvisionedCondition))
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/eclipse-che/che-operator/pkg/common/chetypes"
 	"github.com/eclipse-che/che-operator/pkg/common/constants"
 	"github.com/eclipse-che/che-operator/pkg/common/reconciler"
+	"github.com/eclipse-che/che-operator/pkg/deploy/openvsx"
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -33,9 +34,10 @@ import (
 type OpenVSXDatabaseReconciler struct {
 	reconciler.Reconcilable
 
-	// databaseProvisioned prevents recreating the setup Job on every reconcile.
+	// provisionedDatabase is the database the setup Job has been run against,
+	// it prevents recreating the setup Job on every reconcile.
 	// Resets on operator restart, which is safe - the Job uses ON CONFLICT DO NOTHING.
-	databaseProvisioned bool
+	provisionedDatabase string
 }
 
 var logger = ctrl.Log.WithName(constants.OpenVSXDatabaseComponentName)
@@ -47,30 +49,41 @@ func NewOpenVSXDatabaseReconciler() *OpenVSXDatabaseReconciler {
 func (p *OpenVSXDatabaseReconciler) Reconcile(ctx *chetypes.DeployContext) (reconcile.Result, bool, error) {
 	if !ctx.CheCluster.IsInternalOpenVSXRegistryEnabled() {
 		p.deleteResources(ctx)
-		p.databaseProvisioned = false
+		p.provisionedDatabase = ""
 		return reconcile.Result{}, true, nil
 	}
 
-	err := p.syncService(ctx)
-	if err != nil {
-		return reconcile.Result{}, false, fmt.Errorf("failed to sync service: %w", err)
-	}
-
-	err = p.syncPVC(ctx)
-	if err != nil {
-		return reconcile.Result{}, false, fmt.Errorf("failed to sync pvc: %w", err)
-	}
+	if openvsx.IsExternalDatabase(ctx) {
+		// The PVC is kept to be able to switch back to the internal database
+		p.deleteDatabase(ctx)
+	} else {
+		err := p.syncService(ctx)
+		if err != nil {
+			return reconcile.Result{}, false, fmt.Errorf("failed to sync service: %w", err)
+		}
 
-	done, err := p.syncDeployment(ctx)
-	if !done {
+		err = p.syncPVC(ctx)
 		if err != nil {
-			err = fmt.Errorf("failed to sync deployment: %w", err)
+			return reconcile.Result{}, false, fmt.Errorf("failed to sync pvc: %w", err)
 		}
-		return reconcile.Result{}, false, err
+
+		done, err := p.syncDeployment(ctx)
+		if !done {
+			if err != nil {
+				err = fmt.Errorf("failed to sync deployment: %w", err)
+			}
+			return reconcile.Result{}, false, err
+		}
+	}
+
+	databaseConnection, err := openvsx.GetDatabaseConnection(ctx)
+	if err != nil {
+		return reconcile.Result{}, false, fmt.Errorf("failed to get database connection: %w", err)
 	}
 
-	if !p.databaseProvisioned {
-		done, err = p.syncDatabaseProvisioned(ctx)
+	database := fmt.Sprintf("%s:%s/%s", databaseConnection.Host, databaseConnection.Port, openvsx.GetExternalDatabaseSecretName(ctx))
+	if p.provisionedDatabase != database {
+		done, err := p.syncDatabaseProvisioned(ctx)
 		if !done {
 			if err != nil {
 				err = fmt.Errorf("failed to sync Extensions %w", err)
@@ -78,7 +91,7 @@ func (p *OpenVSXDatabaseReconciler) Reconcile(ctx *chetypes.DeployContext) (reco
 			return reconcile.Result{}, false, err
 		}
 
-		p.databaseProvisioned = true
+		p.provisionedDatabase = database
 	}
 
 	return reconcile.Result{}, true, nil
@@ -95,17 +108,9 @@ func (p *OpenVSXDatabaseReconciler) deleteResources(ctx *chetypes.DeployContext)
 	}
 	cw := ctx.ClusterAPI.ClientWrapper
 
-	err := cw.DeleteByKeyIgnoreNotFound(context.TODO(), objtKey, &appsv1.Deployment{})
-	if err != nil {
-		logger.Error(err, "Failed to delete Deployment", "Name", objtKey.Name)
-	}
-
-	err = cw.DeleteByKeyIgnoreNotFound(context.TODO(), objtKey, &corev1.Service{})
-	if err != nil {
-		logger.Error(err, "Failed to delete Service", "Name", objtKey.Name)
-	}
+	p.deleteDatabase(ctx)
 
-	err = cw.DeleteByKeyIgnoreNotFound(context.TODO(), objtKey, &corev1.PersistentVolumeClaim{})
+	err := cw.DeleteByKeyIgnoreNotFound(context.TODO(), objtKey, &corev1.PersistentVolumeClaim{})
 	if err != nil {
 		logger.Error(err, "Failed to delete PVC", "Name", objtKey.Name)
 	}
@@ -123,3 +128,22 @@ func (p *OpenVSXDatabaseReconciler) deleteResources(ctx *chetypes.DeployContext)
 		logger.Error(err, "Failed to delete Job", "Name", constants.OpenVSXDatabaseProvisionJobName)
 	}
 }
+
+// deleteDatabase deletes the in-cluster database Deployment and Service.
+func (p *OpenVSXDatabaseReconciler) deleteDatabase(ctx *chetypes.DeployContext) {
+	objtKey := types.NamespacedName{
+		Name:      constants.OpenVSXDatabaseComponentName,
+		Namespace: ctx.CheCluster.Namespace,
+	}
+	cw := ctx.ClusterAPI.ClientWrapper
+
+	err := cw.DeleteByKeyIgnoreNotFound(context.TODO(), objtKey, &appsv1.Deployment{})
+	if err != nil {
+		logger.Error(err, "Failed to delete Deployment", "Name", objtKey.Name)
+	}
+
+	err = cw.DeleteByKeyIgnoreNotFound(context.TODO(), objtKey, &corev1.Service{})
+	if err != nil {
+		logger.Error(err, "Failed to delete Service", "Name", objtKey.Name)
+	}
+}
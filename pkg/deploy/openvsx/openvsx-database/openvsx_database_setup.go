@@ -39,6 +39,11 @@ func (r *OpenVSXDatabaseReconciler) syncDatabaseProvisioned(ctx *chetypes.Deploy
 
 	secretName := openvsx.GetCredentialsSecretName(ctx)
 
+	databaseConnection, err := openvsx.GetDatabaseConnection(ctx)
+	if err != nil {
+		return false, err
+	}
+
 	job := &batchv1.Job{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "Job",
@@ -60,15 +65,7 @@ func (r *OpenVSXDatabaseReconciler) syncDatabaseProvisioned(ctx *chetypes.Deploy
 							Name:            constants.OpenVSXDatabaseProvisionJobName + "-init",
 							Image:           image,
 							ImagePullPolicy: corev1.PullPolicy(imagePullPolicy),
-							Env: []corev1.EnvVar{
-								{
-									Name:  "PGHOST",
-									Value: constants.OpenVSXDatabaseComponentName,
-								},
-								utils.EnvVarFromSecret("PGDATABASE", secretName, "database-name"),
-								utils.EnvVarFromSecret("PGUSER", secretName, "database-user"),
-								utils.EnvVarFromSecret("PGPASSWORD", secretName, "database-password"),
-							},
+							Env:             databaseConnection.GetPostgresEnvs(),
 							Command: []string{"sh", "-c",
 								`until psql -c "SELECT 1 FROM user_data LIMIT 0" 2>/dev/null; do echo "Waiting for Flyway migrations..."; sleep 5; done`,
 							},
@@ -79,19 +76,13 @@ func (r *OpenVSXDatabaseReconciler) syncDatabaseProvisioned(ctx *chetypes.Deploy
 							Name:            constants.OpenVSXDatabaseProvisionJobName,
 							Image:           image,
 							ImagePullPolicy: corev1.PullPolicy(imagePullPolicy),
-							Env: []corev1.EnvVar{
-								{
-									Name:  "PGHOST",
-									Value: constants.OpenVSXDatabaseComponentName,
-								},
-								utils.EnvVarFromSecret("PGDATABASE", secretName, "database-name"),
-								utils.EnvVarFromSecret("PGUSER", secretName, "database-user"),
-								utils.EnvVarFromSecret("PGPASSWORD", secretName, "database-password"),
+							Env: append(
+								databaseConnection.GetPostgresEnvs(),
 								utils.EnvVarFromSecret("OPENVSX_USER_NAME", secretName, "openvsx-publisher-name"),
 								utils.EnvVarFromSecret("OPENVSX_USER_PAT", secretName, "openvsx-publisher-token"),
 								utils.EnvVarFromSecret("OPENVSX_ADMIN_NAME", secretName, "openvsx-admin-name"),
 								utils.EnvVarFromSecret("OPENVSX_ADMIN_PAT", secretName, "openvsx-admin-token"),
-							},
+							),
 							Command: []string{"sh", "-c", `
 psql \
   -v user_name="$OPENVSX_USER_NAME" \
@@ -132,6 +123,8 @@ EOSQL`,
 		},
 	}
 
+	databaseConnection.MountSSLRootCert(&job.Spec.Template.Spec)
+
 	deploy.EnsurePodSecurityStandards(
 		&job.Spec.Template.Spec,
 		constants.DefaultSecurityContextRunAsUser,
@@ -142,7 +135,7 @@ EOSQL`,
 		return false, err
 	}
 
-	err := ctx.ClusterAPI.ClientWrapper.Sync(
+	err = ctx.ClusterAPI.ClientWrapper.Sync(
 		context.TODO(),
 		job,
 		&k8sclient.SyncOptions{
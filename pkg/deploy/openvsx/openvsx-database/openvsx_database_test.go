@@ -13,16 +13,20 @@
 package openvsx_database
 
 import (
+	"context"
 	"testing"
 
 	chev2 "github.com/eclipse-che/che-operator/api/v2"
 	"github.com/eclipse-che/che-operator/pkg/common/constants"
 	"github.com/eclipse-che/che-operator/pkg/common/test"
+	"github.com/eclipse-che/che-operator/pkg/common/utils"
 	"github.com/stretchr/testify/assert"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 )
 
 func TestOpenVSXDatabaseReconciler(t *testing.T) {
@@ -56,3 +60,65 @@ func TestOpenVSXDatabaseReconciler(t *testing.T) {
 	assert.False(t, test.IsObjectExists(ctx.ClusterAPI.Client, types.NamespacedName{Name: constants.OpenVSXDatabaseComponentName, Namespace: "eclipse-che"}, &corev1.Service{}))
 	assert.False(t, test.IsObjectExists(ctx.ClusterAPI.Client, types.NamespacedName{Name: constants.OpenVSXDatabaseComponentName, Namespace: "eclipse-che"}, &corev1.PersistentVolumeClaim{}))
 }
+
+func TestOpenVSXDatabaseReconcilerWithExternalDatabase(t *testing.T) {
+	ctx := test.NewCtxBuilder().WithCheCluster(
+		&chev2.CheCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "eclipse-che",
+				Namespace: "eclipse-che",
+			},
+			Spec: chev2.CheClusterSpec{
+				Components: chev2.CheClusterComponents{
+					OpenVSXRegistry: chev2.OpenVSXRegistry{
+						Enable: true,
+					},
+				},
+			},
+		},
+	).WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "external-database",
+				Namespace: "eclipse-che",
+				Labels:    map[string]string{constants.KubernetesPartOfLabelKey: constants.CheEclipseOrg},
+			},
+			Data: map[string][]byte{
+				"host":     []byte("postgres.example.com"),
+				"database": []byte("openvsx"),
+				"user":     []byte("openvsx"),
+				"password": []byte("password"),
+				"sslmode":  []byte("require"),
+			},
+		},
+	).Build()
+
+	reconciler := NewOpenVSXDatabaseReconciler()
+	test.EnsureReconcile(t, ctx, reconciler.Reconcile)
+
+	assert.True(t, test.IsObjectExists(ctx.ClusterAPI.Client, types.NamespacedName{Name: constants.OpenVSXDatabaseComponentName, Namespace: "eclipse-che"}, &appsv1.Deployment{}))
+
+	// Switch to the external database
+	ctx.CheCluster.Spec.Components.OpenVSXRegistry.Database = &chev2.OpenVSXDatabase{
+		ExternalConnectionSecretName: ptr.To("external-database"),
+	}
+	test.EnsureReconcile(t, ctx, reconciler.Reconcile)
+
+	assert.False(t, test.IsObjectExists(ctx.ClusterAPI.Client, types.NamespacedName{Name: constants.OpenVSXDatabaseComponentName, Namespace: "eclipse-che"}, &appsv1.Deployment{}))
+	assert.False(t, test.IsObjectExists(ctx.ClusterAPI.Client, types.NamespacedName{Name: constants.OpenVSXDatabaseComponentName, Namespace: "eclipse-che"}, &corev1.Service{}))
+	assert.True(t, test.IsObjectExists(ctx.ClusterAPI.Client, types.NamespacedName{Name: constants.OpenVSXDatabaseComponentName, Namespace: "eclipse-che"}, &corev1.PersistentVolumeClaim{}))
+
+	job := &batchv1.Job{}
+	err := ctx.ClusterAPI.Client.Get(context.TODO(), types.NamespacedName{Name: constants.OpenVSXDatabaseProvisionJobName, Namespace: "eclipse-che"}, job)
+	assert.NoError(t, err)
+
+	env := job.Spec.Template.Spec.Containers[0].Env
+	assert.Equal(t, "postgres.example.com", utils.GetEnvByName("PGHOST", env))
+	assert.Equal(t, "require", utils.GetEnvByName("PGSSLMODE", env))
+
+	// Missing external database secret
+	ctx.CheCluster.Spec.Components.OpenVSXRegistry.Database.ExternalConnectionSecretName = ptr.To("unknown")
+	_, done, err := reconciler.Reconcile(ctx)
+	assert.False(t, done)
+	assert.Error(t, err)
+}
@@ -0,0 +1,129 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package openvsx_server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/eclipse-che/che-operator/pkg/common/chetypes"
+	"github.com/eclipse-che/che-operator/pkg/deploy"
+	"github.com/eclipse-che/che-operator/pkg/deploy/tls"
+)
+
+// OpenVSXRegistryClient queries the OpenVSX registry API.
+type OpenVSXRegistryClient interface {
+	// GetVersions returns all versions of the extension published in the registry.
+	GetVersions(registryURL string, publisher string, name string) ([]string, error)
+	// Exists returns true if the extension version is published in the registry.
+	Exists(registryURL string, publisher string, name string, version string) (bool, error)
+}
+
+type openVSXRegistryClient struct {
+	httpClient *http.Client
+}
+
+func NewOpenVSXRegistryClient(transport http.RoundTripper) OpenVSXRegistryClient {
+	return &openVSXRegistryClient{
+		httpClient: &http.Client{
+			Transport: transport,
+			Timeout:   time.Second * 30,
+		},
+	}
+}
+
+// newOpenVSXRegistryClient returns the client which accesses the upstream registry through the configured proxy
+// and trusts the Che CA certificates. The internal registry is always accessed directly.
+func newOpenVSXRegistryClient(ctx *chetypes.DeployContext) (OpenVSXRegistryClient, error) {
+	caBundle, err := tls.GetMergedCABundle(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	proxy := *ctx.Proxy
+	proxy.NoProxy = deploy.MergeNonProxy(proxy.NoProxy, ".svc")
+
+	transport, err := deploy.NewHTTPTransport(caBundle, &proxy)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewOpenVSXRegistryClient(transport), nil
+}
+
+func (c *openVSXRegistryClient) GetVersions(registryURL string, publisher string, name string) ([]string, error) {
+	url := fmt.Sprintf("%s/api/%s/%s", strings.TrimSuffix(registryURL, "/"), publisher, name)
+
+	response, err := c.httpClient.Get(url)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := response.Body.Close(); err != nil {
+			logger.Error(err, "unable to close response body")
+		}
+	}()
+
+	switch response.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("extension %s.%s not found in %s", publisher, name, registryURL)
+	default:
+		return nil, fmt.Errorf("unexpected status code %d from %s", response.StatusCode, url)
+	}
+
+	extension := struct {
+		AllVersions map[string]string `json:"allVersions"`
+	}{}
+	if err := json.NewDecoder(response.Body).Decode(&extension); err != nil {
+		return nil, fmt.Errorf("failed to parse response from %s: %w", url, err)
+	}
+
+	versions := make([]string, 0, len(extension.AllVersions))
+	for version := range extension.AllVersions {
+		// skip version aliases
+		if version != "latest" && version != "pre-release" {
+			versions = append(versions, version)
+		}
+	}
+
+	return versions, nil
+}
+
+func (c *openVSXRegistryClient) Exists(registryURL string, publisher string, name string, version string) (bool, error) {
+	url := fmt.Sprintf("%s/api/%s/%s/%s", strings.TrimSuffix(registryURL, "/"), publisher, name, version)
+
+	response, err := c.httpClient.Get(url)
+	if err != nil {
+		return false, err
+	}
+
+	defer func() {
+		if err := response.Body.Close(); err != nil {
+			logger.Error(err, "unable to close response body")
+		}
+	}()
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("unexpected status code %d from %s", response.StatusCode, url)
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/eclipse-che/che-operator/pkg/common/test"
 	"github.com/eclipse-che/che-operator/pkg/deploy"
 	"github.com/eclipse-che/che-operator/pkg/deploy/gateway"
+	"github.com/eclipse-che/che-operator/pkg/deploy/openvsx"
 	appsv1 "k8s.io/api/apps/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -40,6 +41,11 @@ type OpenVSXServerReconciler struct {
 	// extensionsVersion tracks the last synced ConfigMap version to avoid unnecessary Job churn.
 	// Resets on operator restart, which is safe - the Job is idempotent.
 	extensionsVersion string
+
+	// mirror keeps the extensions resolved against the upstream registry.
+	mirror *extensionsMirror
+	// newRegistryClient is replaced in tests
+	newRegistryClient func(ctx *chetypes.DeployContext) (OpenVSXRegistryClient, error)
 }
 
 var (
@@ -52,6 +58,8 @@ var (
 func NewOpenVSXServerReconciler() *OpenVSXServerReconciler {
 	return &OpenVSXServerReconciler{
 		extensionsVersion: "",
+		mirror:            newExtensionsMirror(),
+		newRegistryClient: newOpenVSXRegistryClient,
 	}
 }
 
@@ -59,6 +67,7 @@ func (r *OpenVSXServerReconciler) Reconcile(ctx *chetypes.DeployContext) (reconc
 	if !ctx.CheCluster.IsInternalOpenVSXRegistryEnabled() {
 		deleteResources(ctx)
 		r.extensionsVersion = ""
+		r.mirror.reset()
 		return reconcile.Result{}, true, nil
 	}
 
@@ -72,6 +81,23 @@ func (r *OpenVSXServerReconciler) Reconcile(ctx *chetypes.DeployContext) (reconc
 		return reconcile.Result{}, false, fmt.Errorf("failed to sync PVC: %w", err)
 	}
 
+	if openvsx.IsRestoreInProgress(ctx) {
+		err = r.scaleDownForRestore(ctx)
+		if err != nil {
+			return reconcile.Result{}, false, fmt.Errorf("failed to scale down Deployment: %w", err)
+		}
+
+		// Extensions are published again once the backup is restored
+		r.extensionsVersion = ""
+		r.mirror.reset()
+		return reconcile.Result{}, true, nil
+	}
+
+	err = r.scaleUpAfterRestore(ctx)
+	if err != nil {
+		return reconcile.Result{}, false, fmt.Errorf("failed to scale up Deployment: %w", err)
+	}
+
 	done, err := r.syncDeployment(ctx)
 	if !done {
 		if err != nil {
@@ -123,7 +149,14 @@ func (r *OpenVSXServerReconciler) Reconcile(ctx *chetypes.DeployContext) (reconc
 		r.extensionsVersion = extensionsVersion
 	}
 
-	return reconcile.Result{}, true, nil
+	result, err := r.syncExtensionsMirror(ctx)
+	if err != nil {
+		// Mirroring the extensions never blocks the reconciliation of the other components
+		logger.Error(err, "Failed to sync Extensions Mirror")
+		return reconcile.Result{RequeueAfter: extensionsMirrorCheckPeriod}, true, nil
+	}
+
+	return result, true, nil
 }
 
 func deleteResources(ctx *chetypes.DeployContext) {
@@ -177,6 +210,11 @@ func deleteResources(ctx *chetypes.DeployContext) {
 		logger.Error(err, "Failed to delete Job", "Name", constants.OpenVSXServerExtensionPublishJobName)
 	}
 
+	err = deleteExtensionsMirrorJob(ctx)
+	if err != nil {
+		logger.Error(err, "Failed to delete Job", "Name", constants.OpenVSXServerExtensionMirrorJobName)
+	}
+
 	err = cw.DeleteByKeyIgnoreNotFound(
 		context.TODO(),
 		types.NamespacedName{
@@ -196,6 +234,10 @@ func deleteResources(ctx *chetypes.DeployContext) {
 			logger.Error(err, "Failed to update status for OpenVSXURL")
 		}
 	}
+
+	if err = updateExtensionsMirrorStatus(ctx, nil); err != nil {
+		logger.Error(err, "Failed to update status for OpenVSXExtensions")
+	}
 }
 
 func (r *OpenVSXServerReconciler) isServerReady(ctx *chetypes.DeployContext) bool {
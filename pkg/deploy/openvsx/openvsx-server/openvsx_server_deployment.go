@@ -15,7 +15,6 @@ package openvsx_server
 import (
 	_ "embed"
 	"fmt"
-	"strconv"
 
 	"github.com/eclipse-che/che-operator/pkg/common/chetypes"
 	"github.com/eclipse-che/che-operator/pkg/common/constants"
@@ -52,6 +51,11 @@ func (r *OpenVSXServerReconciler) getDeploymentSpec(ctx *chetypes.DeployContext)
 	labels := deploy.GetLabels(constants.OpenVSXServerComponentName)
 	credentialsSecretName := openvsx.GetCredentialsSecretName(ctx)
 
+	databaseConnection, err := openvsx.GetDatabaseConnection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	dbImage := defaults.GetOpenVSXDatabaseImage(ctx.CheCluster)
 	dbImagePullPolicy := utils.GetPullPolicyFromDockerImage(dbImage)
 
@@ -82,14 +86,7 @@ func (r *OpenVSXServerReconciler) getDeploymentSpec(ctx *chetypes.DeployContext)
 							Name:            "wait-database",
 							Image:           dbImage,
 							ImagePullPolicy: corev1.PullPolicy(dbImagePullPolicy),
-							Env: []corev1.EnvVar{
-								{
-									Name:  "PGHOST",
-									Value: constants.OpenVSXDatabaseComponentName,
-								},
-								utils.EnvVarFromSecret("PGUSER", credentialsSecretName, "database-user"),
-								utils.EnvVarFromSecret("PGDATABASE", credentialsSecretName, "database-name"),
-							},
+							Env:             databaseConnection.GetPostgresEnvs(),
 							Resources: corev1.ResourceRequirements{
 								Requests: corev1.ResourceList{
 									corev1.ResourceCPU:    resource.MustParse("10m"),
@@ -186,17 +183,6 @@ func (r *OpenVSXServerReconciler) getDeploymentSpec(ctx *chetypes.DeployContext)
 									Name:  "CONFIG_REVISION",
 									Value: configRevision,
 								},
-								{
-									Name:  "POSTGRESQL_PORT",
-									Value: strconv.FormatInt(int64(constants.OpenVSXDatabaseServicePort), 10),
-								},
-								{
-									Name:  "POSTGRESQL_SERVICE",
-									Value: constants.OpenVSXDatabaseComponentName,
-								},
-								utils.EnvVarFromSecret("POSTGRESQL_USER", credentialsSecretName, "database-user"),
-								utils.EnvVarFromSecret("POSTGRESQL_PASSWORD", credentialsSecretName, "database-password"),
-								utils.EnvVarFromSecret("POSTGRESQL_DATABASE", credentialsSecretName, "database-name"),
 								utils.EnvVarFromSecret("OPENVSX_USER_PAT", credentialsSecretName, "openvsx-publisher-token"),
 								utils.EnvVarFromSecret("OPENVSX_ADMIN_PAT", credentialsSecretName, "openvsx-admin-token"),
 							},
@@ -229,6 +215,14 @@ func (r *OpenVSXServerReconciler) getDeploymentSpec(ctx *chetypes.DeployContext)
 		},
 	}
 
+	// Database envs are referenced by the application.yml
+	deployment.Spec.Template.Spec.Containers[0].Env = append(
+		deployment.Spec.Template.Spec.Containers[0].Env,
+		databaseConnection.GetServerEnvs()...,
+	)
+
+	databaseConnection.MountSSLRootCert(&deployment.Spec.Template.Spec)
+
 	deploy.EnsurePodSecurityStandards(
 		&deployment.Spec.Template.Spec,
 		constants.DefaultSecurityContextRunAsUser,
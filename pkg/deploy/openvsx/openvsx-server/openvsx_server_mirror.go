@@ -0,0 +1,557 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package openvsx_server
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/blang/semver/v4"
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/eclipse-che/che-operator/pkg/common/chetypes"
+	"github.com/eclipse-che/che-operator/pkg/common/constants"
+	defaults "github.com/eclipse-che/che-operator/pkg/common/operator-defaults"
+	"github.com/eclipse-che/che-operator/pkg/common/utils"
+	"github.com/eclipse-che/che-operator/pkg/deploy"
+	"github.com/eclipse-che/che-operator/pkg/deploy/openvsx"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	mirroredExtensionPending   = "Pending"
+	mirroredExtensionPublished = "Published"
+	mirroredExtensionFailed    = "Failed"
+
+	fileServerUpstreamType = "FileServer"
+
+	// extensionsResolvePeriod is the period to re-resolve the extension versions
+	// against the upstream registry and to retry the failed extensions.
+	extensionsResolvePeriod = time.Hour
+	// extensionsMirrorCheckPeriod is the period to check the progress of the mirror Job.
+	extensionsMirrorCheckPeriod = 30 * time.Second
+
+	extensionsMirrorScript = `
+failed=0
+while read -r publisher name version url; do
+  [ -z "${publisher}" ] && continue
+  extension="${publisher}.${name}@${version}"
+  if curl -fsS -o /dev/null "${OVSX_REGISTRY_URL}/api/${publisher}/${name}/${version}"; then
+    echo "Extension ${extension} is already published"
+    continue
+  fi
+  file="/tmp/${publisher}.${name}-${version}.vsix"
+  ovsx create-namespace "${publisher}" -r "${OVSX_REGISTRY_URL}" -p "${OVSX_PAT}" > /dev/null 2>&1 || true
+  if curl -fsSL --retry 3 -o "${file}" "${url}" && ovsx publish "${file}" -r "${OVSX_REGISTRY_URL}" -p "${OVSX_PAT}"; then
+    echo "Extension ${extension} published"
+  else
+    echo "Failed to publish extension ${extension} from ${url}"
+    failed=1
+  fi
+  rm -f "${file}"
+done <<EOF
+${OVSX_EXTENSIONS}
+EOF
+exit ${failed}
+`
+)
+
+// mirroredExtension is an extension resolved against the upstream registry.
+type mirroredExtension struct {
+	// extension as defined in the CheCluster
+	extension   string
+	publisher   string
+	name        string
+	version     string
+	downloadURL string
+	// err is set if the extension can't be resolved
+	err error
+}
+
+func (e *mirroredExtension) String() string {
+	return fmt.Sprintf("%s.%s@%s", e.publisher, e.name, e.version)
+}
+
+// extensionsMirror keeps the extensions resolved against the upstream registry between reconciles.
+// The extensions are resolved and checked in the background, so that the reconcile
+// never waits for the upstream or the internal registry.
+type extensionsMirror struct {
+	mu sync.Mutex
+	// generation is incremented on reset to discard the snapshots refreshed before
+	generation int
+	refreshing bool
+	snapshot   *extensionsMirrorSnapshot
+
+	// runAsync runs the refresh, replaced in tests to run it synchronously
+	runAsync func(func())
+}
+
+// extensionsMirrorSnapshot is the result of a refresh of the extensions mirror.
+type extensionsMirrorSnapshot struct {
+	specHash   string
+	resolvedAt time.Time
+	// checkedAt is the time the extensions published in the internal registry were checked
+	checkedAt time.Time
+	// finishedJob identifies the finished mirror Job, after which the published extensions were checked
+	finishedJob string
+	extensions  []mirroredExtension
+	published   map[string]bool
+}
+
+func newExtensionsMirror() *extensionsMirror {
+	return &extensionsMirror{
+		runAsync: func(f func()) { go f() },
+	}
+}
+
+func (m *extensionsMirror) reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.generation++
+	m.snapshot = nil
+}
+
+// get returns the last snapshot for the given spec, or nil if there is none.
+func (m *extensionsMirror) get(specHash string) *extensionsMirrorSnapshot {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.snapshot == nil || m.snapshot.specHash != specHash {
+		return nil
+	}
+	return m.snapshot
+}
+
+// refresh starts resolving the extensions, if requested or if the spec has changed, and checking
+// which ones are published in the internal registry. Returns false if a refresh is already running.
+func (m *extensionsMirror) refresh(
+	registryClient OpenVSXRegistryClient,
+	mirrorSpec *chev2.OpenVSXExtensionsMirror,
+	specHash string,
+	internalRegistryURL string,
+	resolve bool,
+	finishedJob string,
+) bool {
+	m.mu.Lock()
+	if m.refreshing {
+		m.mu.Unlock()
+		return false
+	}
+
+	m.refreshing = true
+	generation := m.generation
+	previous := m.snapshot
+	m.mu.Unlock()
+
+	m.runAsync(func() {
+		snapshot := &extensionsMirrorSnapshot{
+			specHash:    specHash,
+			finishedJob: finishedJob,
+			published:   map[string]bool{},
+		}
+
+		if resolve || previous == nil || previous.specHash != specHash {
+			snapshot.resolvedAt = time.Now()
+			snapshot.extensions = resolveExtensions(registryClient, mirrorSpec)
+		} else {
+			snapshot.resolvedAt = previous.resolvedAt
+			snapshot.extensions = previous.extensions
+			maps.Copy(snapshot.published, previous.published)
+		}
+
+		snapshot.checkedAt = time.Now()
+		for _, extension := range snapshot.extensions {
+			if extension.err != nil || snapshot.published[extension.String()] {
+				continue
+			}
+
+			exists, err := registryClient.Exists(internalRegistryURL, extension.publisher, extension.name, extension.version)
+			if err != nil {
+				logger.Error(err, "Failed to check extension in the internal registry", "extension", extension.String())
+			}
+			snapshot.published[extension.String()] = exists
+		}
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		if m.generation == generation {
+			m.snapshot = snapshot
+		}
+		m.refreshing = false
+	})
+
+	return true
+}
+
+// syncExtensionsMirror publishes the extensions defined in the CheCluster
+// from the upstream registry into the internal OpenVSX registry,
+// and reports the status of each extension.
+func (r *OpenVSXServerReconciler) syncExtensionsMirror(ctx *chetypes.DeployContext) (reconcile.Result, error) {
+	mirrorSpec := ctx.CheCluster.Spec.Components.OpenVSXRegistry.ExtensionsMirror
+	if mirrorSpec == nil || len(mirrorSpec.Extensions) == 0 {
+		r.mirror.reset()
+		if err := deleteExtensionsMirrorJob(ctx); err != nil {
+			return reconcile.Result{}, err
+		}
+		return reconcile.Result{}, updateExtensionsMirrorStatus(ctx, nil)
+	}
+
+	registryClient, err := r.newRegistryClient(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	specHash := utils.ComputeHash256([]byte(fmt.Sprintf("%s|%s|%s", mirrorSpec.UpstreamURL, mirrorSpec.UpstreamType, strings.Join(mirrorSpec.Extensions, ","))))
+	internalRegistryURL := openvsx.GetOpenVSXServerServiceURL(ctx)
+	refresh := func(resolve bool, finishedJob string) (*extensionsMirrorSnapshot, bool) {
+		started := r.mirror.refresh(registryClient, mirrorSpec.DeepCopy(), specHash, internalRegistryURL, resolve, finishedJob)
+		return r.mirror.get(specHash), started
+	}
+
+	snapshot := r.mirror.get(specHash)
+	if snapshot == nil {
+		if snapshot, _ = refresh(true, ""); snapshot == nil {
+			var extensionsStatus []chev2.OpenVSXExtensionStatus
+			for _, extension := range mirrorSpec.Extensions {
+				extensionsStatus = append(extensionsStatus, chev2.OpenVSXExtensionStatus{Extension: extension, Phase: mirroredExtensionPending})
+			}
+			return reconcile.Result{RequeueAfter: extensionsMirrorCheckPeriod}, updateExtensionsMirrorStatus(ctx, extensionsStatus)
+		}
+	} else if time.Since(snapshot.resolvedAt) > extensionsResolvePeriod {
+		if _, started := refresh(true, ""); started {
+			// Recreate the Job to retry the failed extensions
+			if err := deleteExtensionsMirrorJob(ctx); err != nil {
+				return reconcile.Result{}, err
+			}
+		}
+	}
+
+	var pendingExtensions []mirroredExtension
+	for _, extension := range snapshot.extensions {
+		if extension.err == nil && !snapshot.published[extension.String()] {
+			pendingExtensions = append(pendingExtensions, extension)
+		}
+	}
+
+	jobFinished := false
+	if len(pendingExtensions) == 0 {
+		if err := deleteExtensionsMirrorJob(ctx); err != nil {
+			return reconcile.Result{}, err
+		}
+	} else {
+		job, done, err := syncExtensionsMirrorJob(ctx, pendingExtensions)
+		if !done {
+			return reconcile.Result{RequeueAfter: extensionsMirrorCheckPeriod}, err
+		}
+
+		finishedAt := getJobFinishedTime(job)
+		if finishedAt.IsZero() {
+			if time.Since(snapshot.checkedAt) > extensionsMirrorCheckPeriod {
+				// Report the progress of the Job
+				refresh(false, "")
+			}
+		} else {
+			finishedJob := fmt.Sprintf("%s/%s/%d", job.Name, job.UID, finishedAt.Unix())
+			if snapshot.finishedJob != finishedJob {
+				// Check the extensions published by the Job
+				if refreshed, _ := refresh(false, finishedJob); refreshed != nil {
+					snapshot = refreshed
+				}
+			}
+			jobFinished = snapshot.finishedJob == finishedJob
+		}
+	}
+
+	var extensionsStatus []chev2.OpenVSXExtensionStatus
+	for _, extension := range snapshot.extensions {
+		extensionStatus := chev2.OpenVSXExtensionStatus{
+			Extension: extension.extension,
+			Version:   extension.version,
+		}
+
+		switch {
+		case extension.err != nil:
+			extensionStatus.Phase = mirroredExtensionFailed
+			extensionStatus.Message = extension.err.Error()
+		case snapshot.published[extension.String()]:
+			extensionStatus.Phase = mirroredExtensionPublished
+		case jobFinished:
+			extensionStatus.Phase = mirroredExtensionFailed
+			extensionStatus.Message = fmt.Sprintf("Failed to download or publish the extension, check the logs of the %s Job", constants.OpenVSXServerExtensionMirrorJobName)
+		default:
+			extensionStatus.Phase = mirroredExtensionPending
+		}
+
+		extensionsStatus = append(extensionsStatus, extensionStatus)
+	}
+
+	if err := updateExtensionsMirrorStatus(ctx, extensionsStatus); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if len(pendingExtensions) > 0 && !jobFinished {
+		return reconcile.Result{RequeueAfter: extensionsMirrorCheckPeriod}, nil
+	}
+	return reconcile.Result{RequeueAfter: extensionsResolvePeriod}, nil
+}
+
+// resolveExtensions resolves versions and download URLs of the extensions.
+func resolveExtensions(registryClient OpenVSXRegistryClient, mirrorSpec *chev2.OpenVSXExtensionsMirror) []mirroredExtension {
+	upstreamURL := strings.TrimSuffix(mirrorSpec.UpstreamURL, "/")
+	extensions := make([]mirroredExtension, 0, len(mirrorSpec.Extensions))
+
+	for _, extension := range mirrorSpec.Extensions {
+		id, versionSpec, _ := strings.Cut(extension, "@")
+		publisher, name, _ := strings.Cut(id, ".")
+		versionSpec = strings.TrimSpace(versionSpec)
+
+		mirrored := mirroredExtension{
+			extension: extension,
+			publisher: publisher,
+			name:      name,
+		}
+
+		if mirrorSpec.UpstreamType == fileServerUpstreamType {
+			if _, err := semver.ParseTolerant(versionSpec); err != nil {
+				mirrored.err = fmt.Errorf("an exact version is required to download the extension from a file server")
+			} else {
+				mirrored.version = versionSpec
+				mirrored.downloadURL = fmt.Sprintf("%s/%s/%s/%s/%s.%s-%s.vsix", upstreamURL, publisher, name, versionSpec, publisher, name, versionSpec)
+			}
+		} else {
+			versions, err := registryClient.GetVersions(upstreamURL, publisher, name)
+			if err == nil {
+				mirrored.version, err = selectExtensionVersion(versions, versionSpec)
+			}
+
+			if err != nil {
+				mirrored.err = err
+			} else {
+				mirrored.downloadURL = fmt.Sprintf("%s/api/%s/%s/%s/file/%s.%s-%s.vsix", upstreamURL, publisher, name, mirrored.version, publisher, name, mirrored.version)
+			}
+		}
+
+		extensions = append(extensions, mirrored)
+	}
+
+	return extensions
+}
+
+// selectExtensionVersion returns the version matching the given exact version or range.
+// The highest stable version is returned when several versions match.
+func selectExtensionVersion(versions []string, versionSpec string) (string, error) {
+	if versionSpec != "" && versionSpec != "latest" {
+		for _, version := range versions {
+			if version == versionSpec {
+				return version, nil
+			}
+		}
+	}
+
+	versionRange := func(semver.Version) bool { return true }
+	if versionSpec != "" && versionSpec != "latest" {
+		var err error
+		if versionRange, err = semver.ParseRange(versionSpec); err != nil {
+			return "", fmt.Errorf("invalid version or range %s: %w", versionSpec, err)
+		}
+	}
+
+	selectedVersion := ""
+	var selectedSemver semver.Version
+	for _, version := range versions {
+		v, err := semver.ParseTolerant(version)
+		if err != nil || len(v.Pre) > 0 || !versionRange(v) {
+			continue
+		}
+
+		if selectedVersion == "" || v.GT(selectedSemver) {
+			selectedVersion = version
+			selectedSemver = v
+		}
+	}
+
+	if selectedVersion == "" {
+		if versionSpec == "" {
+			return "", fmt.Errorf("no stable version found")
+		}
+		return "", fmt.Errorf("no version matches %s", versionSpec)
+	}
+
+	return selectedVersion, nil
+}
+
+// syncExtensionsMirrorJob ensures that the mirror Job publishes the given extensions.
+// The existing Job is kept as long as it covers all of them, since the list of
+// pending extensions shrinks while the Job is publishing them.
+func syncExtensionsMirrorJob(ctx *chetypes.DeployContext, extensions []mirroredExtension) (*batchv1.Job, bool, error) {
+	job, err := getExtensionsMirrorJobSpec(ctx, extensions)
+	if err != nil {
+		return nil, false, err
+	}
+
+	actual := &batchv1.Job{}
+	exists, err := deploy.GetNamespacedObject(ctx, constants.OpenVSXServerExtensionMirrorJobName, actual)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if exists {
+		if isExtensionsMirrorJobUpToDate(actual, job) {
+			return actual, true, nil
+		}
+
+		return nil, false, deleteExtensionsMirrorJob(ctx)
+	}
+
+	err = ctx.ClusterAPI.ClientWrapper.Create(context.TODO(), job)
+	if errors.IsAlreadyExists(err) {
+		return nil, false, nil
+	}
+
+	return job, err == nil, err
+}
+
+func getExtensionsMirrorJobSpec(ctx *chetypes.DeployContext, extensions []mirroredExtension) (*batchv1.Job, error) {
+	image := defaults.GetOpenVSXImage(ctx.CheCluster)
+	imagePullPolicy := utils.GetPullPolicyFromDockerImage(image)
+
+	labels := deploy.GetLabels(constants.OpenVSXServerExtensionMirrorJobName)
+	credentialsSecret := openvsx.GetCredentialsSecretName(ctx)
+
+	extensionsList := make([]string, 0, len(extensions))
+	for _, extension := range extensions {
+		extensionsList = append(extensionsList, fmt.Sprintf("%s %s %s %s", extension.publisher, extension.name, extension.version, extension.downloadURL))
+	}
+
+	job := &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Job",
+			APIVersion: batchv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      constants.OpenVSXServerExtensionMirrorJobName,
+			Namespace: ctx.CheCluster.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:            constants.OpenVSXServerExtensionMirrorJobName,
+							Image:           image,
+							ImagePullPolicy: corev1.PullPolicy(imagePullPolicy),
+							Env: []corev1.EnvVar{
+								{
+									Name:  "OVSX_REGISTRY_URL",
+									Value: openvsx.GetOpenVSXServerServiceURL(ctx),
+								},
+								{
+									Name:  "OVSX_EXTENSIONS",
+									Value: strings.Join(extensionsList, "\n"),
+								},
+								utils.EnvVarFromSecret("OVSX_PAT", credentialsSecret, "openvsx-publisher-token"),
+							},
+							Command: []string{"/bin/sh", "-c", extensionsMirrorScript},
+						},
+					},
+					RestartPolicy:                 corev1.RestartPolicyNever,
+					TerminationGracePeriodSeconds: ptr.To(int64(30)),
+				},
+			},
+			Parallelism:           ptr.To(int32(1)),
+			BackoffLimit:          ptr.To(int32(3)),
+			Completions:           ptr.To(int32(1)),
+			ActiveDeadlineSeconds: ptr.To(int64(1800)),
+		},
+	}
+
+	deploy.EnsurePodSecurityStandards(
+		&job.Spec.Template.Spec,
+		constants.DefaultSecurityContextRunAsUser,
+		constants.DefaultSecurityContextFsGroup,
+	)
+
+	if err := controllerutil.SetControllerReference(ctx.CheCluster, job, ctx.ClusterAPI.Scheme); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// isExtensionsMirrorJobUpToDate returns true if the actual Job uses the same image
+// and publishes all extensions of the desired one.
+func isExtensionsMirrorJobUpToDate(actual *batchv1.Job, desired *batchv1.Job) bool {
+	if len(actual.Spec.Template.Spec.Containers) == 0 ||
+		actual.Spec.Template.Spec.Containers[0].Image != desired.Spec.Template.Spec.Containers[0].Image {
+		return false
+	}
+
+	actualExtensions := strings.Split(utils.GetEnvByName("OVSX_EXTENSIONS", actual.Spec.Template.Spec.Containers[0].Env), "\n")
+	for _, extension := range strings.Split(utils.GetEnvByName("OVSX_EXTENSIONS", desired.Spec.Template.Spec.Containers[0].Env), "\n") {
+		if !slices.Contains(actualExtensions, extension) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func deleteExtensionsMirrorJob(ctx *chetypes.DeployContext) error {
+	return ctx.ClusterAPI.ClientWrapper.DeleteByKeyIgnoreNotFound(
+		context.TODO(),
+		types.NamespacedName{
+			Name:      constants.OpenVSXServerExtensionMirrorJobName,
+			Namespace: ctx.CheCluster.Namespace,
+		},
+		&batchv1.Job{},
+		client.PropagationPolicy(metav1.DeletePropagationBackground),
+	)
+}
+
+func updateExtensionsMirrorStatus(ctx *chetypes.DeployContext, extensionsStatus []chev2.OpenVSXExtensionStatus) error {
+	if reflect.DeepEqual(ctx.CheCluster.Status.OpenVSXExtensions, extensionsStatus) {
+		return nil
+	}
+
+	ctx.CheCluster.Status.OpenVSXExtensions = extensionsStatus
+	return deploy.UpdateCheCRStatus(ctx, "openVSXExtensions", fmt.Sprintf("%d extensions", len(extensionsStatus)))
+}
+
+// getJobFinishedTime returns the time the Job completed or failed, or zero time if it is still running.
+func getJobFinishedTime(job *batchv1.Job) time.Time {
+	for _, condition := range job.Status.Conditions {
+		if (condition.Type == batchv1.JobComplete || condition.Type == batchv1.JobFailed) && condition.Status == corev1.ConditionTrue {
+			return condition.LastTransitionTime.Time
+		}
+	}
+	return time.Time{}
+}
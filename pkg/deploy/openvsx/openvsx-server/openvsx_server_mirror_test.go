@@ -0,0 +1,351 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package openvsx_server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/eclipse-che/che-operator/pkg/common/chetypes"
+	"github.com/eclipse-che/che-operator/pkg/common/constants"
+	"github.com/eclipse-che/che-operator/pkg/common/test"
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type fakeOpenVSXRegistryClient struct {
+	// versions published in the upstream registry by `<publisher>.<name>`
+	versions map[string][]string
+	// extensions published in the internal registry by `<publisher>.<name>@<version>`
+	published map[string]bool
+}
+
+func (c *fakeOpenVSXRegistryClient) GetVersions(registryURL string, publisher string, name string) ([]string, error) {
+	versions, ok := c.versions[publisher+"."+name]
+	if !ok {
+		return nil, fmt.Errorf("extension %s.%s not found in %s", publisher, name, registryURL)
+	}
+	return versions, nil
+}
+
+func (c *fakeOpenVSXRegistryClient) Exists(registryURL string, publisher string, name string, version string) (bool, error) {
+	return c.published[fmt.Sprintf("%s.%s@%s", publisher, name, version)], nil
+}
+
+func TestSyncExtensionsMirror(t *testing.T) {
+	ctx := test.NewCtxBuilder().WithCheCluster(
+		&chev2.CheCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "eclipse-che",
+				Namespace: "eclipse-che",
+			},
+			Spec: chev2.CheClusterSpec{
+				Components: chev2.CheClusterComponents{
+					OpenVSXRegistry: chev2.OpenVSXRegistry{
+						Enable: true,
+						ExtensionsMirror: &chev2.OpenVSXExtensionsMirror{
+							UpstreamURL:  "https://open-vsx.org",
+							UpstreamType: "OpenVSX",
+							Extensions: []string{
+								"redhat.java@>=1.0.0 <2.0.0",
+								"redhat.vscode-yaml",
+								"acme.missing@1.0.0",
+								"ms-python.python@1.2.3",
+							},
+						},
+					},
+				},
+			},
+		},
+	).Build()
+
+	registryClient := &fakeOpenVSXRegistryClient{
+		versions: map[string][]string{
+			"redhat.java":        {"0.9.0", "1.5.0", "1.9.0", "1.10.0-next", "2.1.0"},
+			"redhat.vscode-yaml": {"1.0.0", "1.14.0"},
+			"ms-python.python":   {"1.2.3"},
+		},
+		published: map[string]bool{
+			"ms-python.python@1.2.3": true,
+		},
+	}
+
+	reconciler := NewOpenVSXServerReconciler()
+	reconciler.newRegistryClient = func(*chetypes.DeployContext) (OpenVSXRegistryClient, error) { return registryClient, nil }
+	reconciler.mirror.runAsync = func(f func()) { f() }
+	test.EnsureReconcile(t, ctx, reconciler.Reconcile)
+
+	assert.Equal(t,
+		[]chev2.OpenVSXExtensionStatus{
+			{Extension: "redhat.java@>=1.0.0 <2.0.0", Version: "1.9.0", Phase: mirroredExtensionPending},
+			{Extension: "redhat.vscode-yaml", Version: "1.14.0", Phase: mirroredExtensionPending},
+			{Extension: "acme.missing@1.0.0", Phase: mirroredExtensionFailed, Message: "extension acme.missing not found in https://open-vsx.org"},
+			{Extension: "ms-python.python@1.2.3", Version: "1.2.3", Phase: mirroredExtensionPublished},
+		},
+		ctx.CheCluster.Status.OpenVSXExtensions,
+	)
+
+	job := &batchv1.Job{}
+	err := ctx.ClusterAPI.Client.Get(context.TODO(), types.NamespacedName{Name: constants.OpenVSXServerExtensionMirrorJobName, Namespace: "eclipse-che"}, job)
+	assert.NoError(t, err)
+
+	extensionsEnv := ""
+	for _, env := range job.Spec.Template.Spec.Containers[0].Env {
+		if env.Name == "OVSX_EXTENSIONS" {
+			extensionsEnv = env.Value
+		}
+	}
+	assert.Equal(t,
+		"redhat java 1.9.0 https://open-vsx.org/api/redhat/java/1.9.0/file/redhat.java-1.9.0.vsix\n"+
+			"redhat vscode-yaml 1.14.0 https://open-vsx.org/api/redhat/vscode-yaml/1.14.0/file/redhat.vscode-yaml-1.14.0.vsix",
+		extensionsEnv,
+	)
+
+	// Job finished, but only one extension is published
+	registryClient.published["redhat.java@1.9.0"] = true
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, LastTransitionTime: metav1.Now()}}
+	err = ctx.ClusterAPI.Client.Status().Update(context.TODO(), job)
+	assert.NoError(t, err)
+
+	test.EnsureReconcile(t, ctx, reconciler.Reconcile)
+
+	assert.Equal(t, mirroredExtensionPublished, ctx.CheCluster.Status.OpenVSXExtensions[0].Phase)
+	assert.Equal(t, mirroredExtensionFailed, ctx.CheCluster.Status.OpenVSXExtensions[1].Phase)
+	assert.NotEmpty(t, ctx.CheCluster.Status.OpenVSXExtensions[1].Message)
+
+	// All extensions are published
+	registryClient.published["redhat.vscode-yaml@1.14.0"] = true
+	ctx.CheCluster.Spec.Components.OpenVSXRegistry.ExtensionsMirror.Extensions = []string{"redhat.java@>=1.0.0 <2.0.0", "redhat.vscode-yaml"}
+
+	test.EnsureReconcile(t, ctx, reconciler.Reconcile)
+
+	assert.Equal(t,
+		[]chev2.OpenVSXExtensionStatus{
+			{Extension: "redhat.java@>=1.0.0 <2.0.0", Version: "1.9.0", Phase: mirroredExtensionPublished},
+			{Extension: "redhat.vscode-yaml", Version: "1.14.0", Phase: mirroredExtensionPublished},
+		},
+		ctx.CheCluster.Status.OpenVSXExtensions,
+	)
+	assert.False(t, test.IsObjectExists(ctx.ClusterAPI.Client, types.NamespacedName{Name: constants.OpenVSXServerExtensionMirrorJobName, Namespace: "eclipse-che"}, &batchv1.Job{}))
+
+	// Disable mirroring
+	ctx.CheCluster.Spec.Components.OpenVSXRegistry.ExtensionsMirror = nil
+	test.EnsureReconcile(t, ctx, reconciler.Reconcile)
+
+	assert.Empty(t, ctx.CheCluster.Status.OpenVSXExtensions)
+}
+
+func TestResolveExtensionsFromFileServer(t *testing.T) {
+	extensions := resolveExtensions(&fakeOpenVSXRegistryClient{}, &chev2.OpenVSXExtensionsMirror{
+		UpstreamURL:  "http://files.example.com/vsix/",
+		UpstreamType: "FileServer",
+		Extensions:   []string{"redhat.java@1.9.0", "redhat.vscode-yaml@>=1.0.0", "redhat.vscode-xml"},
+	})
+
+	assert.Len(t, extensions, 3)
+
+	assert.NoError(t, extensions[0].err)
+	assert.Equal(t, "1.9.0", extensions[0].version)
+	assert.Equal(t, "http://files.example.com/vsix/redhat/java/1.9.0/redhat.java-1.9.0.vsix", extensions[0].downloadURL)
+
+	assert.Error(t, extensions[1].err)
+	assert.Error(t, extensions[2].err)
+}
+
+func TestSyncExtensionsMirrorInBackground(t *testing.T) {
+	ctx := test.NewCtxBuilder().WithCheCluster(
+		&chev2.CheCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "eclipse-che",
+				Namespace: "eclipse-che",
+			},
+			Spec: chev2.CheClusterSpec{
+				Components: chev2.CheClusterComponents{
+					OpenVSXRegistry: chev2.OpenVSXRegistry{
+						Enable: true,
+						ExtensionsMirror: &chev2.OpenVSXExtensionsMirror{
+							UpstreamURL: "https://open-vsx.org",
+							Extensions:  []string{"redhat.java"},
+						},
+					},
+				},
+			},
+		},
+	).Build()
+
+	var refreshes []func()
+
+	reconciler := NewOpenVSXServerReconciler()
+	reconciler.newRegistryClient = func(*chetypes.DeployContext) (OpenVSXRegistryClient, error) {
+		return &fakeOpenVSXRegistryClient{versions: map[string][]string{"redhat.java": {"1.9.0"}}}, nil
+	}
+	reconciler.mirror.runAsync = func(f func()) { refreshes = append(refreshes, f) }
+
+	// The reconcile doesn't wait for the extensions to be resolved
+	result, err := reconciler.syncExtensionsMirror(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, extensionsMirrorCheckPeriod, result.RequeueAfter)
+	assert.Equal(t, []chev2.OpenVSXExtensionStatus{{Extension: "redhat.java", Phase: mirroredExtensionPending}}, ctx.CheCluster.Status.OpenVSXExtensions)
+	assert.Len(t, refreshes, 1)
+
+	// Refresh is not started twice
+	_, err = reconciler.syncExtensionsMirror(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, refreshes, 1)
+
+	refreshes[0]()
+
+	_, err = reconciler.syncExtensionsMirror(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []chev2.OpenVSXExtensionStatus{{Extension: "redhat.java", Version: "1.9.0", Phase: mirroredExtensionPending}}, ctx.CheCluster.Status.OpenVSXExtensions)
+	assert.True(t, test.IsObjectExists(ctx.ClusterAPI.Client, types.NamespacedName{Name: constants.OpenVSXServerExtensionMirrorJobName, Namespace: "eclipse-che"}, &batchv1.Job{}))
+}
+
+func TestSelectExtensionVersion(t *testing.T) {
+	type testCase struct {
+		name            string
+		versions        []string
+		versionSpec     string
+		expectedVersion string
+		expectedError   bool
+	}
+
+	versions := []string{"0.9.0", "1.5.0", "1.9.0", "1.10.0-next", "2.1.0", "2.1"}
+
+	testCases := []testCase{
+		{
+			name:            "Latest version",
+			versions:        versions,
+			versionSpec:     "",
+			expectedVersion: "2.1.0",
+		},
+		{
+			name:            "Latest version alias",
+			versions:        versions,
+			versionSpec:     "latest",
+			expectedVersion: "2.1.0",
+		},
+		{
+			name:            "Exact version",
+			versions:        versions,
+			versionSpec:     "1.5.0",
+			expectedVersion: "1.5.0",
+		},
+		{
+			name:            "Exact pre-release version",
+			versions:        versions,
+			versionSpec:     "1.10.0-next",
+			expectedVersion: "1.10.0-next",
+		},
+		{
+			name:            "Version range",
+			versions:        versions,
+			versionSpec:     ">=1.0.0 <2.0.0",
+			expectedVersion: "1.9.0",
+		},
+		{
+			name:          "No matching version",
+			versions:      versions,
+			versionSpec:   ">=3.0.0",
+			expectedError: true,
+		},
+		{
+			name:          "Invalid range",
+			versions:      versions,
+			versionSpec:   "foo",
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			version, err := selectExtensionVersion(testCase.versions, testCase.versionSpec)
+			if testCase.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.expectedVersion, version)
+			}
+		})
+	}
+}
+
+func TestOpenVSXRegistryClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/redhat/java":
+			_, _ = w.Write([]byte(`{"allVersions": {"latest": "url", "pre-release": "url", "1.9.0": "url", "1.5.0": "url"}}`))
+		case "/api/redhat/java/1.9.0":
+			_, _ = w.Write([]byte(`{}`))
+		case "/api/broken/extension":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	registryClient := NewOpenVSXRegistryClient(http.DefaultTransport)
+
+	versions, err := registryClient.GetVersions(server.URL+"/", "redhat", "java")
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"1.9.0", "1.5.0"}, versions)
+
+	_, err = registryClient.GetVersions(server.URL, "acme", "missing")
+	assert.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "not found"))
+
+	_, err = registryClient.GetVersions(server.URL, "broken", "extension")
+	assert.Error(t, err)
+
+	exists, err := registryClient.Exists(server.URL, "redhat", "java", "1.9.0")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = registryClient.Exists(server.URL, "redhat", "java", "1.5.0")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestOpenVSXRegistryClientProxy(t *testing.T) {
+	ctx := test.NewCtxBuilder().Build()
+	ctx.Proxy = &chetypes.Proxy{
+		HttpProxy:  "http://proxy:3128",
+		HttpsProxy: "http://proxy:3128",
+	}
+
+	registryClient, err := newOpenVSXRegistryClient(ctx)
+	assert.NoError(t, err)
+
+	transport := registryClient.(*openVSXRegistryClient).httpClient.Transport.(*http.Transport)
+
+	// The upstream registry is accessed through the proxy
+	request, _ := http.NewRequest(http.MethodGet, "https://open-vsx.org/api/redhat/java", nil)
+	proxyURL, err := transport.Proxy(request)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://proxy:3128", proxyURL.String())
+
+	// The internal registry is accessed directly
+	request, _ = http.NewRequest(http.MethodGet, "http://openvsx-server.eclipse-che.svc:8080/openvsx/api/redhat/java", nil)
+	proxyURL, err = transport.Proxy(request)
+	assert.NoError(t, err)
+	assert.Nil(t, proxyURL)
+}
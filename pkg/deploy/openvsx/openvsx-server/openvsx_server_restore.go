@@ -0,0 +1,68 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package openvsx_server
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/eclipse-che/che-operator/pkg/common/chetypes"
+	"github.com/eclipse-che/che-operator/pkg/common/constants"
+	"github.com/eclipse-che/che-operator/pkg/deploy"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/utils/ptr"
+)
+
+// scaleDownForRestore scales the server down while a backup is being restored.
+// The number of replicas is kept in the annotation to scale the server up afterwards.
+func (r *OpenVSXServerReconciler) scaleDownForRestore(ctx *chetypes.DeployContext) error {
+	deployment := &appsv1.Deployment{}
+	exists, err := deploy.GetNamespacedObject(ctx, constants.OpenVSXServerComponentName, deployment)
+	if !exists {
+		return err
+	}
+
+	if _, ok := deployment.Annotations[constants.CheEclipseOrgReplicasBeforeRestore]; ok {
+		return nil
+	}
+
+	if deployment.Annotations == nil {
+		deployment.Annotations = map[string]string{}
+	}
+	deployment.Annotations[constants.CheEclipseOrgReplicasBeforeRestore] = strconv.FormatInt(int64(ptr.Deref(deployment.Spec.Replicas, 1)), 10)
+	deployment.Spec.Replicas = ptr.To(int32(0))
+
+	return ctx.ClusterAPI.Client.Update(context.TODO(), deployment)
+}
+
+// scaleUpAfterRestore restores the number of replicas the server had before the restore.
+func (r *OpenVSXServerReconciler) scaleUpAfterRestore(ctx *chetypes.DeployContext) error {
+	deployment := &appsv1.Deployment{}
+	exists, err := deploy.GetNamespacedObject(ctx, constants.OpenVSXServerComponentName, deployment)
+	if !exists {
+		return err
+	}
+
+	replicas, ok := deployment.Annotations[constants.CheEclipseOrgReplicasBeforeRestore]
+	if !ok {
+		return nil
+	}
+
+	deployment.Spec.Replicas = ptr.To(int32(1))
+	if value, err := strconv.ParseInt(replicas, 10, 32); err == nil {
+		deployment.Spec.Replicas = ptr.To(int32(value))
+	}
+	delete(deployment.Annotations, constants.CheEclipseOrgReplicasBeforeRestore)
+
+	return ctx.ClusterAPI.Client.Update(context.TODO(), deployment)
+}
@@ -13,6 +13,7 @@
 package openvsx_server
 
 import (
+	"context"
 	"strings"
 	"testing"
 
@@ -28,6 +29,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 )
 
 func TestOpenVSXServerReconciler(t *testing.T) {
@@ -120,3 +122,54 @@ func TestDeploymentSpecHasWaitDatabaseInitContainer(t *testing.T) {
 	assert.NotNil(t, ic.Resources.Requests, "init container should have resource requests")
 	assert.NotNil(t, ic.Resources.Limits, "init container should have resource limits")
 }
+
+func TestOpenVSXServerScaledDownWhileRestoring(t *testing.T) {
+	ctx := test.NewCtxBuilder().WithCheCluster(
+		&chev2.CheCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "eclipse-che",
+				Namespace: "eclipse-che",
+			},
+			Spec: chev2.CheClusterSpec{
+				Components: chev2.CheClusterComponents{
+					OpenVSXRegistry: chev2.OpenVSXRegistry{
+						Enable: true,
+					},
+				},
+			},
+		},
+	).Build()
+
+	reconciler := NewOpenVSXServerReconciler()
+	test.EnsureReconcile(t, ctx, reconciler.Reconcile)
+
+	deploymentKey := types.NamespacedName{Name: constants.OpenVSXServerComponentName, Namespace: "eclipse-che"}
+	deployment := &appsv1.Deployment{}
+	err := ctx.ClusterAPI.Client.Get(context.TODO(), deploymentKey, deployment)
+	assert.NoError(t, err)
+
+	deployment.Spec.Replicas = ptr.To(int32(2))
+	err = ctx.ClusterAPI.Client.Update(context.TODO(), deployment)
+	assert.NoError(t, err)
+
+	// Restore in progress
+	ctx.CheCluster.Status.OpenVSXBackup = &chev2.OpenVSXBackupStatus{
+		RestoredBackup: "openvsx-backup-1",
+		RestorePhase:   openvsx.BackupPhaseRunning,
+	}
+	test.EnsureReconcile(t, ctx, reconciler.Reconcile)
+
+	err = ctx.ClusterAPI.Client.Get(context.TODO(), deploymentKey, deployment)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), *deployment.Spec.Replicas)
+	assert.Equal(t, "2", deployment.Annotations[constants.CheEclipseOrgReplicasBeforeRestore])
+
+	// Restore completed
+	ctx.CheCluster.Status.OpenVSXBackup.RestorePhase = openvsx.BackupPhaseSucceeded
+	test.EnsureReconcile(t, ctx, reconciler.Reconcile)
+
+	err = ctx.ClusterAPI.Client.Get(context.TODO(), deploymentKey, deployment)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), *deployment.Spec.Replicas)
+	assert.NotContains(t, deployment.Annotations, constants.CheEclipseOrgReplicasBeforeRestore)
+}
@@ -0,0 +1,171 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package openvsx
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/eclipse-che/che-operator/pkg/common/chetypes"
+	"github.com/eclipse-che/che-operator/pkg/common/constants"
+	"github.com/eclipse-che/che-operator/pkg/common/reconciler"
+	"github.com/eclipse-che/che-operator/pkg/deploy"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	BackupPhaseRunning   = "Running"
+	BackupPhaseSucceeded = "Succeeded"
+	BackupPhaseFailed    = "Failed"
+
+	// backupsDir is the directory in the backup volume where backups are stored, one directory per backup
+	backupsDir = "/backup/backups"
+	// extensionsDir is the OpenVSX registry server storage, defined in application.yml
+	extensionsDir = "/openvsx/extensions"
+
+	// jobCheckPeriod is the period to check running backup and restore Jobs
+	jobCheckPeriod = 30 * time.Second
+	// statusCheckPeriod is the period to refresh the status of scheduled backups
+	statusCheckPeriod = 5 * time.Minute
+)
+
+// OpenVSXBackupReconciler schedules backups of the internal OpenVSX registry
+// database and extensions storage, and restores them on demand.
+type OpenVSXBackupReconciler struct {
+	reconciler.Reconcilable
+}
+
+func NewOpenVSXBackupReconciler() *OpenVSXBackupReconciler {
+	return &OpenVSXBackupReconciler{}
+}
+
+// IsRestoreInProgress returns true if a backup is being restored,
+// the OpenVSX registry server must be kept scaled down meanwhile.
+func IsRestoreInProgress(ctx *chetypes.DeployContext) bool {
+	status := ctx.CheCluster.Status.OpenVSXBackup
+	return status != nil && status.RestorePhase == BackupPhaseRunning
+}
+
+func (r *OpenVSXBackupReconciler) Reconcile(ctx *chetypes.DeployContext) (reconcile.Result, bool, error) {
+	if !ctx.CheCluster.IsInternalOpenVSXRegistryEnabled() {
+		// Backups are kept in the PVC to be able to restore them once the registry is enabled again
+		deleteBackupResources(ctx)
+		if err := updateBackupStatus(ctx, nil); err != nil {
+			return reconcile.Result{}, false, err
+		}
+		return reconcile.Result{}, true, nil
+	}
+
+	backup := ctx.CheCluster.Spec.Components.OpenVSXRegistry.Backup
+	if backup == nil {
+		// Backups are kept in the PVC to be able to restore them later
+		deleteBackupResources(ctx)
+		if err := updateBackupStatus(ctx, nil); err != nil {
+			return reconcile.Result{}, false, err
+		}
+		return reconcile.Result{}, true, nil
+	}
+
+	status := &chev2.OpenVSXBackupStatus{}
+	if ctx.CheCluster.Status.OpenVSXBackup != nil {
+		status = ctx.CheCluster.Status.OpenVSXBackup.DeepCopy()
+	}
+
+	if backup.S3 == nil {
+		if err := r.syncPVC(ctx); err != nil {
+			return reconcile.Result{}, false, fmt.Errorf("failed to sync PVC: %w", err)
+		}
+	}
+
+	restoreResult, err := r.syncRestore(ctx, status)
+	if err != nil {
+		return reconcile.Result{}, false, fmt.Errorf("failed to sync restore: %w", err)
+	}
+
+	// Backups are suspended while a backup is being restored
+	if err = r.syncCronJob(ctx, status.RestorePhase == BackupPhaseRunning); err != nil {
+		return reconcile.Result{}, false, fmt.Errorf("failed to sync CronJob: %w", err)
+	}
+
+	backupResult, err := r.syncBackupStatus(ctx, status)
+	if err != nil {
+		return reconcile.Result{}, false, fmt.Errorf("failed to sync backup status: %w", err)
+	}
+
+	status.Message = getStatusMessage(status)
+	if err = updateBackupStatus(ctx, status); err != nil {
+		return reconcile.Result{}, false, err
+	}
+
+	if restoreResult.RequeueAfter > 0 && restoreResult.RequeueAfter < backupResult.RequeueAfter {
+		return restoreResult, true, nil
+	}
+	return backupResult, true, nil
+}
+
+func (r *OpenVSXBackupReconciler) Finalize(_ *chetypes.DeployContext) bool {
+	return true
+}
+
+func getStatusMessage(status *chev2.OpenVSXBackupStatus) string {
+	if status.RestorePhase == BackupPhaseFailed {
+		return fmt.Sprintf("Failed to restore backup %s, check the logs of the %s Job", status.RestoredBackup, constants.OpenVSXRestoreJobName)
+	}
+	if status.LastBackupPhase == BackupPhaseFailed {
+		return fmt.Sprintf("Backup %s failed, check the logs of the Job with the same name", status.LastBackup)
+	}
+	return ""
+}
+
+func updateBackupStatus(ctx *chetypes.DeployContext, status *chev2.OpenVSXBackupStatus) error {
+	if reflect.DeepEqual(ctx.CheCluster.Status.OpenVSXBackup, status) {
+		return nil
+	}
+
+	ctx.CheCluster.Status.OpenVSXBackup = status
+	if status == nil {
+		return deploy.UpdateCheCRStatus(ctx, "openVSXBackup", "")
+	}
+	return deploy.UpdateCheCRStatus(ctx, "openVSXBackup", fmt.Sprintf("%s %s", status.LastBackup, status.LastBackupPhase))
+}
+
+// deleteBackupResources deletes the backup CronJob and the restore Job, backups are kept.
+func deleteBackupResources(ctx *chetypes.DeployContext) {
+	cw := ctx.ClusterAPI.ClientWrapper
+
+	// Backup Jobs are deleted along with the CronJob
+	err := cw.DeleteByKeyIgnoreNotFound(
+		context.TODO(),
+		types.NamespacedName{
+			Name:      constants.OpenVSXBackupComponentName,
+			Namespace: ctx.CheCluster.Namespace,
+		},
+		&batchv1.CronJob{},
+		client.PropagationPolicy(metav1.DeletePropagationBackground),
+	)
+	if err != nil {
+		logger.Error(err, "Failed to delete CronJob", "Name", constants.OpenVSXBackupComponentName)
+	}
+
+	err = deleteRestoreJob(ctx)
+	if err != nil {
+		logger.Error(err, "Failed to delete Job", "Name", constants.OpenVSXRestoreJobName)
+	}
+}
@@ -0,0 +1,269 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package openvsx
+
+import (
+	"context"
+	"strconv"
+
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/eclipse-che/che-operator/pkg/common/chetypes"
+	"github.com/eclipse-che/che-operator/pkg/common/constants"
+	k8sclient "github.com/eclipse-che/che-operator/pkg/common/k8s-client"
+	defaults "github.com/eclipse-che/che-operator/pkg/common/operator-defaults"
+	"github.com/eclipse-che/che-operator/pkg/common/utils"
+	"github.com/eclipse-che/che-operator/pkg/deploy"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	defaultSchedule   = "0 2 * * *"
+	defaultMaxBackups = int32(7)
+
+	backupVolumeName     = "backup"
+	extensionsVolumeName = "extensions"
+
+	// backupScript dumps the database and archives the extensions storage into `<backups-dir>/<backup-name>`,
+	// then either uploads the backup to the S3-compatible storage or removes the oldest backups from the PVC.
+	backupScript = `set -eu
+backup_dir="$BACKUPS_DIR/$BACKUP_NAME"
+staging_dir="$BACKUPS_DIR/.$BACKUP_NAME"
+rm -rf "$staging_dir" && mkdir -p "$staging_dir"
+
+echo "Dumping database..."
+pg_dump --format=custom --no-owner --no-privileges --file="$staging_dir/database.dump"
+
+echo "Archiving extensions storage..."
+tar --exclude=./lost+found -czf "$staging_dir/extensions.tar.gz" -C "$EXTENSIONS_DIR" .
+
+mv "$staging_dir" "$backup_dir"
+
+if [ -n "${S3_ENDPOINT:-}" ]; then
+  for file in database.dump extensions.tar.gz; do
+    echo "Uploading $file..."
+    curl --fail --silent --show-error \
+      --aws-sigv4 "aws:amz:$S3_REGION:s3" \
+      --user "$AWS_ACCESS_KEY_ID:$AWS_SECRET_ACCESS_KEY" \
+      -H "x-amz-content-sha256: UNSIGNED-PAYLOAD" \
+      --upload-file "$backup_dir/$file" \
+      "${S3_ENDPOINT%/}/$S3_BUCKET/$BACKUP_NAME/$file"
+  done
+else
+  echo "Removing old backups..."
+  ls -1dt "$BACKUPS_DIR"/*/ | tail -n +$((MAX_BACKUPS + 1)) | xargs -r rm -rf
+fi
+
+echo "Backup $BACKUP_NAME created"
+`
+)
+
+var cronJobDiffOpts = cmp.Options{
+	cmpopts.IgnoreFields(batchv1.CronJob{}, "TypeMeta", "ObjectMeta", "Status"),
+	cmpopts.IgnoreFields(batchv1.JobSpec{}, "Selector", "ManualSelector", "CompletionMode", "Suspend", "PodReplacementPolicy"),
+	cmpopts.IgnoreFields(corev1.Container{}, "TerminationMessagePath", "TerminationMessagePolicy"),
+	cmpopts.IgnoreFields(corev1.PodSpec{}, "DNSPolicy", "SchedulerName", "SecurityContext"),
+}
+
+func (r *OpenVSXBackupReconciler) syncCronJob(ctx *chetypes.DeployContext, suspend bool) error {
+	backup := ctx.CheCluster.Spec.Components.OpenVSXRegistry.Backup
+
+	image := defaults.GetOpenVSXDatabaseImage(ctx.CheCluster)
+	imagePullPolicy := utils.GetPullPolicyFromDockerImage(image)
+
+	labels := deploy.GetLabels(constants.OpenVSXBackupComponentName)
+
+	databaseConnection, err := GetDatabaseConnection(ctx)
+	if err != nil {
+		return err
+	}
+
+	schedule := backup.Schedule
+	if schedule == "" {
+		schedule = defaultSchedule
+	}
+
+	maxBackups := backup.MaxBackups
+	if maxBackups <= 0 {
+		maxBackups = defaultMaxBackups
+	}
+
+	env := append(
+		databaseConnection.GetPostgresEnvs(),
+		corev1.EnvVar{
+			Name: "BACKUP_NAME",
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					APIVersion: "v1",
+					FieldPath:  "metadata.labels['job-name']",
+				},
+			},
+		},
+		corev1.EnvVar{Name: "BACKUPS_DIR", Value: backupsDir},
+		corev1.EnvVar{Name: "EXTENSIONS_DIR", Value: extensionsDir},
+		corev1.EnvVar{Name: "MAX_BACKUPS", Value: strconv.FormatInt(int64(maxBackups), 10)},
+	)
+	env = append(env, getS3Envs(backup.S3)...)
+
+	cronJob := &batchv1.CronJob{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "CronJob",
+			APIVersion: batchv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      constants.OpenVSXBackupComponentName,
+			Namespace: ctx.CheCluster.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.CronJobSpec{
+			Schedule:                   schedule,
+			ConcurrencyPolicy:          batchv1.ForbidConcurrent,
+			Suspend:                    ptr.To(suspend),
+			SuccessfulJobsHistoryLimit: ptr.To(int32(3)),
+			FailedJobsHistoryLimit:     ptr.To(int32(1)),
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: batchv1.JobSpec{
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Labels: labels,
+						},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name:            constants.OpenVSXBackupComponentName,
+									Image:           image,
+									ImagePullPolicy: corev1.PullPolicy(imagePullPolicy),
+									Env:             env,
+									Command:         []string{"sh", "-c", backupScript},
+									VolumeMounts: []corev1.VolumeMount{
+										{
+											Name:      backupVolumeName,
+											MountPath: "/backup",
+										},
+										{
+											Name:      extensionsVolumeName,
+											MountPath: extensionsDir,
+											ReadOnly:  true,
+										},
+									},
+								},
+							},
+							Volumes: []corev1.Volume{
+								getBackupVolume(backup, false),
+								getExtensionsVolume(),
+							},
+							// The extensions storage PVC is mounted by the OpenVSX registry server
+							Affinity: &corev1.Affinity{
+								PodAffinity: &corev1.PodAffinity{
+									RequiredDuringSchedulingIgnoredDuringExecution: []corev1.PodAffinityTerm{
+										{
+											LabelSelector: &metav1.LabelSelector{
+												MatchLabels: deploy.GetLabels(constants.OpenVSXServerComponentName),
+											},
+											TopologyKey: "kubernetes.io/hostname",
+										},
+									},
+								},
+							},
+							RestartPolicy:                 corev1.RestartPolicyNever,
+							TerminationGracePeriodSeconds: ptr.To(int64(30)),
+						},
+					},
+					Parallelism:           ptr.To(int32(1)),
+					Completions:           ptr.To(int32(1)),
+					BackoffLimit:          ptr.To(int32(1)),
+					ActiveDeadlineSeconds: ptr.To(int64(3600)),
+				},
+			},
+		},
+	}
+
+	databaseConnection.MountSSLRootCert(&cronJob.Spec.JobTemplate.Spec.Template.Spec)
+
+	deploy.EnsurePodSecurityStandards(
+		&cronJob.Spec.JobTemplate.Spec.Template.Spec,
+		constants.DefaultSecurityContextRunAsUser,
+		constants.DefaultSecurityContextFsGroup,
+	)
+
+	if err := controllerutil.SetControllerReference(ctx.CheCluster, cronJob, ctx.ClusterAPI.Scheme); err != nil {
+		return err
+	}
+
+	return ctx.ClusterAPI.ClientWrapper.Sync(
+		context.TODO(),
+		cronJob,
+		&k8sclient.SyncOptions{DiffOpts: cronJobDiffOpts},
+	)
+}
+
+// getBackupVolume returns the PVC backups are stored in,
+// or a temporary volume if backups are uploaded to an S3-compatible storage.
+func getBackupVolume(backup *chev2.OpenVSXBackup, readOnly bool) corev1.Volume {
+	if backup.S3 != nil {
+		return corev1.Volume{
+			Name: backupVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		}
+	}
+
+	return corev1.Volume{
+		Name: backupVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: constants.OpenVSXBackupComponentName,
+				ReadOnly:  readOnly,
+			},
+		},
+	}
+}
+
+func getExtensionsVolume() corev1.Volume {
+	return corev1.Volume{
+		Name: extensionsVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: constants.OpenVSXServerComponentName,
+			},
+		},
+	}
+}
+
+func getS3Envs(s3 *chev2.OpenVSXBackupS3) []corev1.EnvVar {
+	if s3 == nil {
+		return []corev1.EnvVar{}
+	}
+
+	region := s3.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	return []corev1.EnvVar{
+		{Name: "S3_ENDPOINT", Value: s3.Endpoint},
+		{Name: "S3_BUCKET", Value: s3.Bucket},
+		{Name: "S3_REGION", Value: region},
+		utils.EnvVarFromSecret("AWS_ACCESS_KEY_ID", s3.CredentialsSecretName, "access-key-id"),
+		utils.EnvVarFromSecret("AWS_SECRET_ACCESS_KEY", s3.CredentialsSecretName, "secret-access-key"),
+	}
+}
@@ -0,0 +1,244 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package openvsx
+
+import (
+	"context"
+
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/eclipse-che/che-operator/pkg/common/chetypes"
+	"github.com/eclipse-che/che-operator/pkg/common/constants"
+	defaults "github.com/eclipse-che/che-operator/pkg/common/operator-defaults"
+	"github.com/eclipse-che/che-operator/pkg/common/utils"
+	"github.com/eclipse-che/che-operator/pkg/deploy"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// restoreScript downloads the backup from the S3-compatible storage if configured,
+// then restores the database and replaces the content of the extensions storage.
+const restoreScript = `set -eu
+backup_dir="$BACKUPS_DIR/$RESTORE_FROM"
+
+if [ -n "${S3_ENDPOINT:-}" ]; then
+  mkdir -p "$backup_dir"
+  for file in database.dump extensions.tar.gz; do
+    echo "Downloading $file..."
+    curl --fail --silent --show-error \
+      --aws-sigv4 "aws:amz:$S3_REGION:s3" \
+      --user "$AWS_ACCESS_KEY_ID:$AWS_SECRET_ACCESS_KEY" \
+      -H "x-amz-content-sha256: UNSIGNED-PAYLOAD" \
+      --output "$backup_dir/$file" \
+      "${S3_ENDPOINT%/}/$S3_BUCKET/$RESTORE_FROM/$file"
+  done
+fi
+
+if [ ! -f "$backup_dir/database.dump" ] || [ ! -f "$backup_dir/extensions.tar.gz" ]; then
+  echo "Backup $RESTORE_FROM not found" >&2
+  exit 1
+fi
+
+echo "Restoring database..."
+pg_restore --clean --if-exists --no-owner --no-privileges --single-transaction --dbname="$PGDATABASE" "$backup_dir/database.dump"
+
+echo "Restoring extensions storage..."
+find "$EXTENSIONS_DIR" -mindepth 1 -maxdepth 1 ! -name lost+found -exec rm -rf {} +
+tar -xzf "$backup_dir/extensions.tar.gz" -C "$EXTENSIONS_DIR"
+
+echo "Backup $RESTORE_FROM restored"
+`
+
+// syncRestore restores the backup defined in the `restoreFrom` field once.
+// The restore Job starts when the OpenVSX registry server is scaled down,
+// the server is scaled up again when the restore phase is no longer `Running`.
+func (r *OpenVSXBackupReconciler) syncRestore(ctx *chetypes.DeployContext, status *chev2.OpenVSXBackupStatus) (reconcile.Result, error) {
+	restoreFrom := ctx.CheCluster.Spec.Components.OpenVSXRegistry.Backup.RestoreFrom
+
+	if restoreFrom == "" {
+		if status.RestorePhase == BackupPhaseRunning {
+			// Restore is canceled
+			status.RestoredBackup = ""
+			status.RestorePhase = ""
+		}
+		return reconcile.Result{}, deleteRestoreJob(ctx)
+	}
+
+	if status.RestoredBackup != restoreFrom {
+		if err := deleteRestoreJob(ctx); err != nil {
+			return reconcile.Result{}, err
+		}
+
+		status.RestoredBackup = restoreFrom
+		status.RestorePhase = BackupPhaseRunning
+		return reconcile.Result{RequeueAfter: jobCheckPeriod}, nil
+	}
+
+	if status.RestorePhase != BackupPhaseRunning {
+		return reconcile.Result{}, nil
+	}
+
+	scaledDown, err := isServerScaledDown(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	} else if !scaledDown {
+		return reconcile.Result{RequeueAfter: jobCheckPeriod}, nil
+	}
+
+	job, err := r.getRestoreJobSpec(ctx, restoreFrom)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if err = ctx.ClusterAPI.ClientWrapper.CreateIfNotExists(context.TODO(), job); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	exists, err := ctx.ClusterAPI.ClientWrapper.GetIgnoreNotFound(
+		context.TODO(),
+		types.NamespacedName{Name: constants.OpenVSXRestoreJobName, Namespace: ctx.CheCluster.Namespace},
+		job,
+	)
+	if err != nil || !exists {
+		return reconcile.Result{RequeueAfter: jobCheckPeriod}, err
+	}
+
+	switch getJobPhase(job) {
+	case BackupPhaseSucceeded:
+		status.RestorePhase = BackupPhaseSucceeded
+		return reconcile.Result{}, deleteRestoreJob(ctx)
+	case BackupPhaseFailed:
+		// The Job is kept to be able to check its logs
+		status.RestorePhase = BackupPhaseFailed
+		return reconcile.Result{}, nil
+	default:
+		return reconcile.Result{RequeueAfter: jobCheckPeriod}, nil
+	}
+}
+
+func (r *OpenVSXBackupReconciler) getRestoreJobSpec(ctx *chetypes.DeployContext, restoreFrom string) (*batchv1.Job, error) {
+	backup := ctx.CheCluster.Spec.Components.OpenVSXRegistry.Backup
+
+	image := defaults.GetOpenVSXDatabaseImage(ctx.CheCluster)
+	imagePullPolicy := utils.GetPullPolicyFromDockerImage(image)
+
+	labels := deploy.GetLabels(constants.OpenVSXRestoreJobName)
+
+	databaseConnection, err := GetDatabaseConnection(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	env := append(
+		databaseConnection.GetPostgresEnvs(),
+		corev1.EnvVar{Name: "RESTORE_FROM", Value: restoreFrom},
+		corev1.EnvVar{Name: "BACKUPS_DIR", Value: backupsDir},
+		corev1.EnvVar{Name: "EXTENSIONS_DIR", Value: extensionsDir},
+	)
+	env = append(env, getS3Envs(backup.S3)...)
+
+	job := &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Job",
+			APIVersion: batchv1.SchemeGroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      constants.OpenVSXRestoreJobName,
+			Namespace: ctx.CheCluster.Namespace,
+			Labels:    labels,
+		},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:            constants.OpenVSXRestoreJobName,
+							Image:           image,
+							ImagePullPolicy: corev1.PullPolicy(imagePullPolicy),
+							Env:             env,
+							Command:         []string{"sh", "-c", restoreScript},
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      backupVolumeName,
+									MountPath: "/backup",
+									ReadOnly:  backup.S3 == nil,
+								},
+								{
+									Name:      extensionsVolumeName,
+									MountPath: extensionsDir,
+								},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						getBackupVolume(backup, true),
+						getExtensionsVolume(),
+					},
+					RestartPolicy:                 corev1.RestartPolicyNever,
+					TerminationGracePeriodSeconds: ptr.To(int64(30)),
+				},
+			},
+			Parallelism:           ptr.To(int32(1)),
+			Completions:           ptr.To(int32(1)),
+			BackoffLimit:          ptr.To(int32(0)),
+			ActiveDeadlineSeconds: ptr.To(int64(3600)),
+		},
+	}
+
+	databaseConnection.MountSSLRootCert(&job.Spec.Template.Spec)
+
+	deploy.EnsurePodSecurityStandards(
+		&job.Spec.Template.Spec,
+		constants.DefaultSecurityContextRunAsUser,
+		constants.DefaultSecurityContextFsGroup,
+	)
+
+	if err := controllerutil.SetControllerReference(ctx.CheCluster, job, ctx.ClusterAPI.Scheme); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// isServerScaledDown returns true if no OpenVSX registry server pod is running,
+// the extensions storage PVC can be mounted then.
+func isServerScaledDown(ctx *chetypes.DeployContext) (bool, error) {
+	deployment := &appsv1.Deployment{}
+	exists, err := deploy.GetNamespacedObject(ctx, constants.OpenVSXServerComponentName, deployment)
+	if !exists {
+		return err == nil, err
+	}
+
+	return ptr.Deref(deployment.Spec.Replicas, 1) == 0 && deployment.Status.Replicas == 0, nil
+}
+
+func deleteRestoreJob(ctx *chetypes.DeployContext) error {
+	return ctx.ClusterAPI.ClientWrapper.DeleteByKeyIgnoreNotFound(
+		context.TODO(),
+		types.NamespacedName{
+			Name:      constants.OpenVSXRestoreJobName,
+			Namespace: ctx.CheCluster.Namespace,
+		},
+		&batchv1.Job{},
+		client.PropagationPolicy(metav1.DeletePropagationBackground),
+	)
+}
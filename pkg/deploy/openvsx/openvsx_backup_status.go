@@ -0,0 +1,87 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package openvsx
+
+import (
+	"context"
+
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/eclipse-che/che-operator/pkg/common/chetypes"
+	"github.com/eclipse-che/che-operator/pkg/common/constants"
+	"github.com/eclipse-che/che-operator/pkg/deploy"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// syncBackupStatus updates the status with the backup Jobs created by the CronJob.
+// Jobs are not watched, so the status is refreshed periodically.
+func (r *OpenVSXBackupReconciler) syncBackupStatus(ctx *chetypes.DeployContext, status *chev2.OpenVSXBackupStatus) (reconcile.Result, error) {
+	jobs := &batchv1.JobList{}
+	if err := ctx.ClusterAPI.Client.List(
+		context.TODO(),
+		jobs,
+		client.InNamespace(ctx.CheCluster.Namespace),
+		client.MatchingLabels(deploy.GetLabels(constants.OpenVSXBackupComponentName)),
+	); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	var lastJob, lastSucceededJob *batchv1.Job
+	for i := range jobs.Items {
+		job := &jobs.Items[i]
+
+		if lastJob == nil || lastJob.CreationTimestamp.Before(&job.CreationTimestamp) {
+			lastJob = job
+		}
+
+		if getJobPhase(job) == BackupPhaseSucceeded {
+			if lastSucceededJob == nil || lastSucceededJob.CreationTimestamp.Before(&job.CreationTimestamp) {
+				lastSucceededJob = job
+			}
+		}
+	}
+
+	// Jobs are removed according to the CronJob history limits, the status keeps the last known backups
+	if lastJob != nil {
+		status.LastBackup = lastJob.Name
+		status.LastBackupPhase = getJobPhase(lastJob)
+	}
+
+	if lastSucceededJob != nil {
+		status.LastSuccessfulBackup = lastSucceededJob.Name
+		status.LastSuccessfulBackupTime = lastSucceededJob.Status.CompletionTime.DeepCopy()
+	}
+
+	if status.LastBackupPhase == BackupPhaseRunning {
+		return reconcile.Result{RequeueAfter: jobCheckPeriod}, nil
+	}
+	return reconcile.Result{RequeueAfter: statusCheckPeriod}, nil
+}
+
+func getJobPhase(job *batchv1.Job) string {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return BackupPhaseSucceeded
+		case batchv1.JobFailed:
+			return BackupPhaseFailed
+		}
+	}
+	return BackupPhaseRunning
+}
@@ -0,0 +1,286 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package openvsx
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/eclipse-che/che-operator/pkg/common/constants"
+	"github.com/eclipse-che/che-operator/pkg/common/test"
+	"github.com/eclipse-che/che-operator/pkg/deploy"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+func TestOpenVSXBackupToPVC(t *testing.T) {
+	ctx := test.NewCtxBuilder().WithCheCluster(
+		&chev2.CheCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "eclipse-che",
+				Namespace: "eclipse-che",
+			},
+			Spec: chev2.CheClusterSpec{
+				Components: chev2.CheClusterComponents{
+					OpenVSXRegistry: chev2.OpenVSXRegistry{
+						Enable: true,
+						Backup: &chev2.OpenVSXBackup{
+							Schedule:   "0 3 * * *",
+							MaxBackups: 3,
+							Storage: &chev2.PVC{
+								ClaimSize:    "10Gi",
+								StorageClass: "fast",
+							},
+						},
+					},
+				},
+			},
+		},
+	).Build()
+
+	reconciler := NewOpenVSXBackupReconciler()
+	test.EnsureReconcile(t, ctx, reconciler.Reconcile)
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	err := ctx.ClusterAPI.Client.Get(context.TODO(), types.NamespacedName{Name: constants.OpenVSXBackupComponentName, Namespace: "eclipse-che"}, pvc)
+	assert.NoError(t, err)
+	assert.Equal(t, "10Gi", pvc.Spec.Resources.Requests.Storage().String())
+	assert.Equal(t, "fast", *pvc.Spec.StorageClassName)
+
+	cronJob := &batchv1.CronJob{}
+	err = ctx.ClusterAPI.Client.Get(context.TODO(), types.NamespacedName{Name: constants.OpenVSXBackupComponentName, Namespace: "eclipse-che"}, cronJob)
+	assert.NoError(t, err)
+	assert.Equal(t, "0 3 * * *", cronJob.Spec.Schedule)
+	assert.False(t, *cronJob.Spec.Suspend)
+
+	podSpec := cronJob.Spec.JobTemplate.Spec.Template.Spec
+	assert.Equal(t, constants.OpenVSXBackupComponentName, podSpec.Volumes[0].PersistentVolumeClaim.ClaimName)
+	assert.Equal(t, constants.OpenVSXServerComponentName, podSpec.Volumes[1].PersistentVolumeClaim.ClaimName)
+	assert.Equal(t, "3", getEnvValue(podSpec.Containers[0].Env, "MAX_BACKUPS"))
+	assert.Equal(t, constants.OpenVSXDatabaseComponentName, getEnvValue(podSpec.Containers[0].Env, "PGHOST"))
+	assert.Empty(t, getEnvValue(podSpec.Containers[0].Env, "S3_ENDPOINT"))
+
+	// Backup Jobs created by the CronJob
+	now := time.Now()
+	err = ctx.ClusterAPI.Client.Create(context.TODO(), newBackupJob("openvsx-backup-1", now.Add(-2*time.Hour), batchv1.JobComplete))
+	assert.NoError(t, err)
+	err = ctx.ClusterAPI.Client.Create(context.TODO(), newBackupJob("openvsx-backup-2", now.Add(-time.Hour), batchv1.JobFailed))
+	assert.NoError(t, err)
+
+	test.EnsureReconcile(t, ctx, reconciler.Reconcile)
+
+	status := ctx.CheCluster.Status.OpenVSXBackup
+	assert.Equal(t, "openvsx-backup-2", status.LastBackup)
+	assert.Equal(t, BackupPhaseFailed, status.LastBackupPhase)
+	assert.Equal(t, "openvsx-backup-1", status.LastSuccessfulBackup)
+	assert.NotNil(t, status.LastSuccessfulBackupTime)
+	assert.NotEmpty(t, status.Message)
+
+	// Disable backups, backups are kept
+	ctx.CheCluster.Spec.Components.OpenVSXRegistry.Backup = nil
+	test.EnsureReconcile(t, ctx, reconciler.Reconcile)
+
+	assert.Nil(t, ctx.CheCluster.Status.OpenVSXBackup)
+	assert.False(t, test.IsObjectExists(ctx.ClusterAPI.Client, types.NamespacedName{Name: constants.OpenVSXBackupComponentName, Namespace: "eclipse-che"}, &batchv1.CronJob{}))
+	assert.True(t, test.IsObjectExists(ctx.ClusterAPI.Client, types.NamespacedName{Name: constants.OpenVSXBackupComponentName, Namespace: "eclipse-che"}, &corev1.PersistentVolumeClaim{}))
+
+	// Disable OpenVSX registry, backups are kept
+	ctx.CheCluster.Spec.Components.OpenVSXRegistry.Enable = false
+	test.EnsureReconcile(t, ctx, reconciler.Reconcile)
+
+	assert.True(t, test.IsObjectExists(ctx.ClusterAPI.Client, types.NamespacedName{Name: constants.OpenVSXBackupComponentName, Namespace: "eclipse-che"}, &corev1.PersistentVolumeClaim{}))
+}
+
+func TestOpenVSXBackupToS3(t *testing.T) {
+	ctx := test.NewCtxBuilder().WithCheCluster(
+		&chev2.CheCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "eclipse-che",
+				Namespace: "eclipse-che",
+			},
+			Spec: chev2.CheClusterSpec{
+				Components: chev2.CheClusterComponents{
+					OpenVSXRegistry: chev2.OpenVSXRegistry{
+						Enable: true,
+						Backup: &chev2.OpenVSXBackup{
+							S3: &chev2.OpenVSXBackupS3{
+								Endpoint:              "http://minio.minio.svc:9000",
+								Bucket:                "openvsx",
+								CredentialsSecretName: "minio-credentials",
+							},
+						},
+					},
+				},
+			},
+		},
+	).Build()
+
+	reconciler := NewOpenVSXBackupReconciler()
+	test.EnsureReconcile(t, ctx, reconciler.Reconcile)
+
+	assert.False(t, test.IsObjectExists(ctx.ClusterAPI.Client, types.NamespacedName{Name: constants.OpenVSXBackupComponentName, Namespace: "eclipse-che"}, &corev1.PersistentVolumeClaim{}))
+
+	cronJob := &batchv1.CronJob{}
+	err := ctx.ClusterAPI.Client.Get(context.TODO(), types.NamespacedName{Name: constants.OpenVSXBackupComponentName, Namespace: "eclipse-che"}, cronJob)
+	assert.NoError(t, err)
+	assert.Equal(t, defaultSchedule, cronJob.Spec.Schedule)
+
+	podSpec := cronJob.Spec.JobTemplate.Spec.Template.Spec
+	assert.NotNil(t, podSpec.Volumes[0].EmptyDir)
+
+	env := podSpec.Containers[0].Env
+	assert.Equal(t, "http://minio.minio.svc:9000", getEnvValue(env, "S3_ENDPOINT"))
+	assert.Equal(t, "openvsx", getEnvValue(env, "S3_BUCKET"))
+	assert.Equal(t, "us-east-1", getEnvValue(env, "S3_REGION"))
+	for _, e := range env {
+		if e.Name == "AWS_ACCESS_KEY_ID" {
+			assert.Equal(t, "minio-credentials", e.ValueFrom.SecretKeyRef.Name)
+			assert.Equal(t, "access-key-id", e.ValueFrom.SecretKeyRef.Key)
+		}
+	}
+}
+
+func TestOpenVSXRestore(t *testing.T) {
+	serverDeployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      constants.OpenVSXServerComponentName,
+			Namespace: "eclipse-che",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To(int32(1)),
+		},
+	}
+
+	ctx := test.NewCtxBuilder().WithCheCluster(
+		&chev2.CheCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "eclipse-che",
+				Namespace: "eclipse-che",
+			},
+			Spec: chev2.CheClusterSpec{
+				Components: chev2.CheClusterComponents{
+					OpenVSXRegistry: chev2.OpenVSXRegistry{
+						Enable: true,
+						Backup: &chev2.OpenVSXBackup{
+							RestoreFrom: "openvsx-backup-1",
+						},
+					},
+				},
+			},
+		},
+	).WithObjects(serverDeployment).Build()
+
+	restoreJobKey := types.NamespacedName{Name: constants.OpenVSXRestoreJobName, Namespace: "eclipse-che"}
+	cronJobKey := types.NamespacedName{Name: constants.OpenVSXBackupComponentName, Namespace: "eclipse-che"}
+
+	reconciler := NewOpenVSXBackupReconciler()
+	test.EnsureReconcile(t, ctx, reconciler.Reconcile)
+
+	// Restore started, backups are suspended
+	assert.True(t, IsRestoreInProgress(ctx))
+	assert.Equal(t, "openvsx-backup-1", ctx.CheCluster.Status.OpenVSXBackup.RestoredBackup)
+
+	cronJob := &batchv1.CronJob{}
+	err := ctx.ClusterAPI.Client.Get(context.TODO(), cronJobKey, cronJob)
+	assert.NoError(t, err)
+	assert.True(t, *cronJob.Spec.Suspend)
+
+	// Server is not scaled down yet
+	test.EnsureReconcile(t, ctx, reconciler.Reconcile)
+	assert.False(t, test.IsObjectExists(ctx.ClusterAPI.Client, restoreJobKey, &batchv1.Job{}))
+
+	// Server is scaled down
+	serverDeployment.Spec.Replicas = ptr.To(int32(0))
+	err = ctx.ClusterAPI.Client.Update(context.TODO(), serverDeployment)
+	assert.NoError(t, err)
+
+	test.EnsureReconcile(t, ctx, reconciler.Reconcile)
+
+	job := &batchv1.Job{}
+	err = ctx.ClusterAPI.Client.Get(context.TODO(), restoreJobKey, job)
+	assert.NoError(t, err)
+	assert.Equal(t, "openvsx-backup-1", getEnvValue(job.Spec.Template.Spec.Containers[0].Env, "RESTORE_FROM"))
+	assert.True(t, job.Spec.Template.Spec.Volumes[0].PersistentVolumeClaim.ReadOnly)
+
+	// Restore succeeded
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	err = ctx.ClusterAPI.Client.Status().Update(context.TODO(), job)
+	assert.NoError(t, err)
+
+	test.EnsureReconcile(t, ctx, reconciler.Reconcile)
+
+	assert.False(t, IsRestoreInProgress(ctx))
+	assert.Equal(t, BackupPhaseSucceeded, ctx.CheCluster.Status.OpenVSXBackup.RestorePhase)
+	assert.False(t, test.IsObjectExists(ctx.ClusterAPI.Client, restoreJobKey, &batchv1.Job{}))
+
+	err = ctx.ClusterAPI.Client.Get(context.TODO(), cronJobKey, cronJob)
+	assert.NoError(t, err)
+	assert.False(t, *cronJob.Spec.Suspend)
+
+	// The backup is restored once
+	test.EnsureReconcile(t, ctx, reconciler.Reconcile)
+	assert.False(t, test.IsObjectExists(ctx.ClusterAPI.Client, restoreJobKey, &batchv1.Job{}))
+
+	// Restore another backup, which fails
+	ctx.CheCluster.Spec.Components.OpenVSXRegistry.Backup.RestoreFrom = "openvsx-backup-2"
+	err = ctx.ClusterAPI.Client.Update(context.TODO(), ctx.CheCluster)
+	assert.NoError(t, err)
+
+	test.EnsureReconcile(t, ctx, reconciler.Reconcile)
+	test.EnsureReconcile(t, ctx, reconciler.Reconcile)
+
+	err = ctx.ClusterAPI.Client.Get(context.TODO(), restoreJobKey, job)
+	assert.NoError(t, err)
+
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}}
+	err = ctx.ClusterAPI.Client.Status().Update(context.TODO(), job)
+	assert.NoError(t, err)
+
+	test.EnsureReconcile(t, ctx, reconciler.Reconcile)
+
+	assert.Equal(t, "openvsx-backup-2", ctx.CheCluster.Status.OpenVSXBackup.RestoredBackup)
+	assert.Equal(t, BackupPhaseFailed, ctx.CheCluster.Status.OpenVSXBackup.RestorePhase)
+	assert.NotEmpty(t, ctx.CheCluster.Status.OpenVSXBackup.Message)
+	assert.True(t, test.IsObjectExists(ctx.ClusterAPI.Client, restoreJobKey, &batchv1.Job{}))
+}
+
+func newBackupJob(name string, creationTime time.Time, conditionType batchv1.JobConditionType) *batchv1.Job {
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "eclipse-che",
+			Labels:            deploy.GetLabels(constants.OpenVSXBackupComponentName),
+			CreationTimestamp: metav1.NewTime(creationTime),
+		},
+		Status: batchv1.JobStatus{
+			Conditions:     []batchv1.JobCondition{{Type: conditionType, Status: corev1.ConditionTrue}},
+			CompletionTime: ptr.To(metav1.NewTime(creationTime.Add(time.Minute))),
+		},
+	}
+}
+
+func getEnvValue(env []corev1.EnvVar, name string) string {
+	for _, e := range env {
+		if e.Name == name {
+			return e.Value
+		}
+	}
+	return ""
+}
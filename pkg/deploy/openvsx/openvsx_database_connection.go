@@ -0,0 +1,218 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package openvsx
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/eclipse-che/che-operator/pkg/common/chetypes"
+	"github.com/eclipse-che/che-operator/pkg/common/constants"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	externalDatabaseHostKey     = "host"
+	externalDatabasePortKey     = "port"
+	externalDatabaseNameKey     = "database"
+	externalDatabaseUserKey     = "user"
+	externalDatabasePasswordKey = "password"
+	externalDatabaseSSLModeKey  = "sslmode"
+	externalDatabaseSSLRootCert = "sslrootcert"
+
+	sslRootCertVolumeName = "database-ca"
+	sslRootCertMountPath  = "/etc/openvsx/database-ca"
+	sslRootCertFileName   = "ca.crt"
+)
+
+// DatabaseConnection describes how to connect to the OpenVSX database.
+// The database name, user and password are referenced from the Secret.
+type DatabaseConnection struct {
+	Host    string
+	Port    string
+	SSLMode string
+	// SSLRootCertSecretName is the name of the Secret containing the CA certificate of the database server
+	SSLRootCertSecretName string
+	Database              corev1.EnvVarSource
+	User                  corev1.EnvVarSource
+	Password              corev1.EnvVarSource
+}
+
+// IsExternalDatabase returns true if the OpenVSX registry uses an external PostgreSQL database.
+func IsExternalDatabase(ctx *chetypes.DeployContext) bool {
+	return GetExternalDatabaseSecretName(ctx) != ""
+}
+
+func GetExternalDatabaseSecretName(ctx *chetypes.DeployContext) string {
+	database := ctx.CheCluster.Spec.Components.OpenVSXRegistry.Database
+	if database == nil {
+		return ""
+	}
+	return ptr.Deref(database.ExternalConnectionSecretName, "")
+}
+
+// GetDatabaseConnection returns the connection to the internal database,
+// or to the external one if the connection Secret is configured.
+func GetDatabaseConnection(ctx *chetypes.DeployContext) (*DatabaseConnection, error) {
+	if !IsExternalDatabase(ctx) {
+		credentialsSecretName := GetCredentialsSecretName(ctx)
+		return &DatabaseConnection{
+			Host:     constants.OpenVSXDatabaseComponentName,
+			Port:     strconv.FormatInt(int64(constants.OpenVSXDatabaseServicePort), 10),
+			Database: secretKeyRef(credentialsSecretName, "database-name"),
+			User:     secretKeyRef(credentialsSecretName, "database-user"),
+			Password: secretKeyRef(credentialsSecretName, "database-password"),
+		}, nil
+	}
+
+	secretName := GetExternalDatabaseSecretName(ctx)
+
+	secret := &corev1.Secret{}
+	exists, err := ctx.ClusterAPI.ClientWrapper.GetIgnoreNotFound(
+		context.TODO(),
+		types.NamespacedName{Name: secretName, Namespace: ctx.CheCluster.Namespace},
+		secret,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret %s: %w", secretName, err)
+	} else if !exists {
+		return nil, fmt.Errorf("external database secret %s not found", secretName)
+	}
+
+	for _, key := range []string{externalDatabaseHostKey, externalDatabaseNameKey, externalDatabaseUserKey, externalDatabasePasswordKey} {
+		if len(secret.Data[key]) == 0 {
+			return nil, fmt.Errorf("external database secret %s must contain the %s key", secretName, key)
+		}
+	}
+
+	port := string(secret.Data[externalDatabasePortKey])
+	if port == "" {
+		port = strconv.FormatInt(int64(constants.OpenVSXDatabaseServicePort), 10)
+	} else if _, err := strconv.ParseUint(port, 10, 16); err != nil {
+		return nil, fmt.Errorf("external database secret %s contains invalid port %s", secretName, port)
+	}
+
+	databaseConnection := &DatabaseConnection{
+		Host:     string(secret.Data[externalDatabaseHostKey]),
+		Port:     port,
+		SSLMode:  string(secret.Data[externalDatabaseSSLModeKey]),
+		Database: secretKeyRef(secretName, externalDatabaseNameKey),
+		User:     secretKeyRef(secretName, externalDatabaseUserKey),
+		Password: secretKeyRef(secretName, externalDatabasePasswordKey),
+	}
+
+	if len(secret.Data[externalDatabaseSSLRootCert]) > 0 {
+		databaseConnection.SSLRootCertSecretName = secretName
+	}
+
+	return databaseConnection, nil
+}
+
+// GetPostgresEnvs returns the libpq environment variables, used by `psql` and `pg_isready`.
+func (c *DatabaseConnection) GetPostgresEnvs() []corev1.EnvVar {
+	envs := []corev1.EnvVar{
+		{Name: "PGHOST", Value: c.Host},
+		{Name: "PGPORT", Value: c.Port},
+		{Name: "PGDATABASE", ValueFrom: c.Database.DeepCopy()},
+		{Name: "PGUSER", ValueFrom: c.User.DeepCopy()},
+		{Name: "PGPASSWORD", ValueFrom: c.Password.DeepCopy()},
+	}
+
+	if c.SSLMode != "" {
+		envs = append(envs, corev1.EnvVar{Name: "PGSSLMODE", Value: c.SSLMode})
+	}
+
+	if c.SSLRootCertSecretName != "" {
+		envs = append(envs, corev1.EnvVar{Name: "PGSSLROOTCERT", Value: c.getSSLRootCertPath()})
+	}
+
+	return envs
+}
+
+// GetServerEnvs returns the environment variables used by the OpenVSX server configuration.
+func (c *DatabaseConnection) GetServerEnvs() []corev1.EnvVar {
+	envs := []corev1.EnvVar{
+		{Name: "POSTGRESQL_PORT", Value: c.Port},
+		{Name: "POSTGRESQL_SERVICE", Value: c.Host},
+		{Name: "POSTGRESQL_USER", ValueFrom: c.User.DeepCopy()},
+		{Name: "POSTGRESQL_PASSWORD", ValueFrom: c.Password.DeepCopy()},
+		{Name: "POSTGRESQL_DATABASE", ValueFrom: c.Database.DeepCopy()},
+	}
+
+	var params []string
+	if c.SSLMode != "" {
+		params = append(params, "sslmode="+c.SSLMode)
+	}
+	if c.SSLRootCertSecretName != "" {
+		params = append(params, "sslrootcert="+c.getSSLRootCertPath())
+	}
+
+	if len(params) > 0 {
+		// Takes precedence over the datasource URL defined in the application.yml
+		envs = append(envs, corev1.EnvVar{
+			Name:  "SPRING_DATASOURCE_URL",
+			Value: "jdbc:postgresql://$(POSTGRESQL_SERVICE):$(POSTGRESQL_PORT)/$(POSTGRESQL_DATABASE)?" + strings.Join(params, "&"),
+		})
+	}
+
+	return envs
+}
+
+// MountSSLRootCert mounts the CA certificate of the database server, if any, into the containers
+// of the pod, which connect to the database using the environment variables above.
+func (c *DatabaseConnection) MountSSLRootCert(podSpec *corev1.PodSpec) {
+	if c.SSLRootCertSecretName == "" {
+		return
+	}
+
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: sslRootCertVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName: c.SSLRootCertSecretName,
+				Items: []corev1.KeyToPath{
+					{Key: externalDatabaseSSLRootCert, Path: sslRootCertFileName},
+				},
+			},
+		},
+	})
+
+	volumeMount := corev1.VolumeMount{
+		Name:      sslRootCertVolumeName,
+		MountPath: sslRootCertMountPath,
+		ReadOnly:  true,
+	}
+	for i := range podSpec.InitContainers {
+		podSpec.InitContainers[i].VolumeMounts = append(podSpec.InitContainers[i].VolumeMounts, volumeMount)
+	}
+	for i := range podSpec.Containers {
+		podSpec.Containers[i].VolumeMounts = append(podSpec.Containers[i].VolumeMounts, volumeMount)
+	}
+}
+
+func (c *DatabaseConnection) getSSLRootCertPath() string {
+	return sslRootCertMountPath + "/" + sslRootCertFileName
+}
+
+func secretKeyRef(secretName string, key string) corev1.EnvVarSource {
+	return corev1.EnvVarSource{
+		SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+			Key:                  key,
+		},
+	}
+}
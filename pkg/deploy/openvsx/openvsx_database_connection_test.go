@@ -0,0 +1,200 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package openvsx
+
+import (
+	"testing"
+
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/eclipse-che/che-operator/pkg/common/constants"
+	"github.com/eclipse-che/che-operator/pkg/common/test"
+	"github.com/eclipse-che/che-operator/pkg/common/utils"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestGetDatabaseConnection(t *testing.T) {
+	type testCase struct {
+		name                 string
+		secret               *corev1.Secret
+		expectedPostgresEnvs []corev1.EnvVar
+		expectedError        bool
+	}
+
+	newSecret := func(data map[string]string) *corev1.Secret {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "external-database",
+				Namespace: "eclipse-che",
+				Labels:    map[string]string{constants.KubernetesPartOfLabelKey: constants.CheEclipseOrg},
+			},
+			Data: map[string][]byte{},
+		}
+		for k, v := range data {
+			secret.Data[k] = []byte(v)
+		}
+		return secret
+	}
+
+	testCases := []testCase{
+		{
+			name: "External database with all keys",
+			secret: newSecret(map[string]string{
+				"host":        "postgres.example.com",
+				"port":        "6432",
+				"database":    "openvsx",
+				"user":        "openvsx",
+				"password":    "password",
+				"sslmode":     "verify-full",
+				"sslrootcert": "-----BEGIN CERTIFICATE-----",
+			}),
+			expectedPostgresEnvs: []corev1.EnvVar{
+				{Name: "PGHOST", Value: "postgres.example.com"},
+				{Name: "PGPORT", Value: "6432"},
+				utils.EnvVarFromSecret("PGDATABASE", "external-database", "database"),
+				utils.EnvVarFromSecret("PGUSER", "external-database", "user"),
+				utils.EnvVarFromSecret("PGPASSWORD", "external-database", "password"),
+				{Name: "PGSSLMODE", Value: "verify-full"},
+				{Name: "PGSSLROOTCERT", Value: "/etc/openvsx/database-ca/ca.crt"},
+			},
+		},
+		{
+			name: "External database with default port",
+			secret: newSecret(map[string]string{
+				"host":     "postgres.example.com",
+				"database": "openvsx",
+				"user":     "openvsx",
+				"password": "password",
+			}),
+			expectedPostgresEnvs: []corev1.EnvVar{
+				{Name: "PGHOST", Value: "postgres.example.com"},
+				{Name: "PGPORT", Value: "5432"},
+				utils.EnvVarFromSecret("PGDATABASE", "external-database", "database"),
+				utils.EnvVarFromSecret("PGUSER", "external-database", "user"),
+				utils.EnvVarFromSecret("PGPASSWORD", "external-database", "password"),
+			},
+		},
+		{
+			name: "External database without host",
+			secret: newSecret(map[string]string{
+				"database": "openvsx",
+				"user":     "openvsx",
+				"password": "password",
+			}),
+			expectedError: true,
+		},
+		{
+			name: "External database with invalid port",
+			secret: newSecret(map[string]string{
+				"host":     "postgres.example.com",
+				"port":     "postgres",
+				"database": "openvsx",
+				"user":     "openvsx",
+				"password": "password",
+			}),
+			expectedError: true,
+		},
+		{
+			name:          "External database without secret",
+			expectedError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			var objects []client.Object
+			if testCase.secret != nil {
+				objects = append(objects, testCase.secret)
+			}
+
+			ctx := test.NewCtxBuilder().WithCheCluster(
+				&chev2.CheCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "eclipse-che",
+						Namespace: "eclipse-che",
+					},
+					Spec: chev2.CheClusterSpec{
+						Components: chev2.CheClusterComponents{
+							OpenVSXRegistry: chev2.OpenVSXRegistry{
+								Enable: true,
+								Database: &chev2.OpenVSXDatabase{
+									ExternalConnectionSecretName: ptr.To("external-database"),
+								},
+							},
+						},
+					},
+				},
+			).WithObjects(objects...).Build()
+
+			databaseConnection, err := GetDatabaseConnection(ctx)
+			if testCase.expectedError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, testCase.expectedPostgresEnvs, databaseConnection.GetPostgresEnvs())
+			}
+		})
+	}
+}
+
+func TestGetDatabaseConnectionForInternalDatabase(t *testing.T) {
+	ctx := test.NewCtxBuilder().Build()
+
+	databaseConnection, err := GetDatabaseConnection(ctx)
+	assert.NoError(t, err)
+
+	assert.Equal(t, constants.OpenVSXDatabaseComponentName, databaseConnection.Host)
+	assert.Equal(t, "5432", databaseConnection.Port)
+	assert.Empty(t, databaseConnection.SSLMode)
+	assert.Equal(t, constants.OpenVSXCredentialsSecret, databaseConnection.Password.SecretKeyRef.Name)
+	assert.Equal(t, "database-password", databaseConnection.Password.SecretKeyRef.Key)
+
+	for _, env := range databaseConnection.GetServerEnvs() {
+		assert.NotEqual(t, "SPRING_DATASOURCE_URL", env.Name)
+	}
+
+	podSpec := &corev1.PodSpec{Containers: []corev1.Container{{Name: "openvsx-server"}}}
+	databaseConnection.MountSSLRootCert(podSpec)
+	assert.Empty(t, podSpec.Volumes)
+	assert.Empty(t, podSpec.Containers[0].VolumeMounts)
+}
+
+func TestMountSSLRootCert(t *testing.T) {
+	databaseConnection := &DatabaseConnection{
+		Host:                  "postgres.example.com",
+		Port:                  "5432",
+		SSLMode:               "verify-full",
+		SSLRootCertSecretName: "external-database",
+	}
+
+	assert.Contains(t, databaseConnection.GetServerEnvs(), corev1.EnvVar{
+		Name:  "SPRING_DATASOURCE_URL",
+		Value: "jdbc:postgresql://$(POSTGRESQL_SERVICE):$(POSTGRESQL_PORT)/$(POSTGRESQL_DATABASE)?sslmode=verify-full&sslrootcert=/etc/openvsx/database-ca/ca.crt",
+	})
+
+	podSpec := &corev1.PodSpec{
+		InitContainers: []corev1.Container{{Name: "wait-database"}},
+		Containers:     []corev1.Container{{Name: "openvsx-server"}},
+	}
+	databaseConnection.MountSSLRootCert(podSpec)
+
+	assert.Len(t, podSpec.Volumes, 1)
+	assert.Equal(t, "external-database", podSpec.Volumes[0].Secret.SecretName)
+	assert.Equal(t, []corev1.KeyToPath{{Key: "sslrootcert", Path: "ca.crt"}}, podSpec.Volumes[0].Secret.Items)
+	for _, container := range append(podSpec.InitContainers, podSpec.Containers...) {
+		assert.Equal(t, []corev1.VolumeMount{{Name: "database-ca", MountPath: "/etc/openvsx/database-ca", ReadOnly: true}}, container.VolumeMounts)
+	}
+}
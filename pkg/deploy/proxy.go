@@ -13,6 +13,9 @@
 package deploy
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
 	"strings"
 
 	"github.com/eclipse-che/che-operator/pkg/common/chetypes"
@@ -166,6 +169,35 @@ func removeProtocolPrefix(url string) string {
 	return url
 }
 
+// NewHTTPTransport returns a transport that verifies the server certificates with the system and the given
+// PEM-encoded CA certificates, and goes through the given proxy unless it is empty.
+func NewHTTPTransport(caBundle []byte, proxy *chetypes.Proxy) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if len(caBundle) > 0 {
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM(caBundle) {
+			return nil, fmt.Errorf("no valid certificates found in the CA bundle")
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: rootCAs}
+	}
+
+	if proxy != nil && (proxy.HttpProxy != "" || proxy.HttpsProxy != "") {
+		proxyFunc := (&httpproxy.Config{
+			HTTPProxy:  proxy.HttpProxy,
+			HTTPSProxy: proxy.HttpsProxy,
+			NoProxy:    proxy.NoProxy,
+		}).ProxyFunc()
+		transport.Proxy = func(r *http.Request) (*url.URL, error) {
+			return proxyFunc(r.URL)
+		}
+	}
+
+	return transport, nil
+}
+
 // ConfigureProxy adds existing proxy configuration into provided transport object.
 func ConfigureProxy(deployContext *chetypes.DeployContext, transport *http.Transport) {
 	config := httpproxy.Config{
@@ -197,7 +197,7 @@ func (s *CheServerReconciler) getConfigMapData(ctx *chetypes.DeployContext) (che
 		return nil, err
 	}
 
-	if infrastructure.IsOpenShiftExternalAuth() {
+	if infrastructure.IsOpenShiftExternalAuth() || ctx.CheCluster.Spec.Networking.Auth.ClaimMappings != nil {
 		s.updateOIDCClaimMappings(ctx, cheEnv)
 	}
 
@@ -322,4 +322,11 @@ func (s *CheServerReconciler) updateOIDCClaimMappings(ctx *chetypes.DeployContex
 	cheEnv["CHE_OIDC_GROUPS__PREFIX"] = ctx.Authentication.GroupsPrefix
 	cheEnv["CHE_OIDC_USERNAME__CLAIM"] = ctx.Authentication.UsernameClaim
 	cheEnv["CHE_OIDC_USERNAME__PREFIX"] = ctx.Authentication.UsernamePrefix
+
+	if ctx.Authentication.GroupsExpression != "" {
+		cheEnv["CHE_OIDC_GROUPS__EXPRESSION"] = ctx.Authentication.GroupsExpression
+	}
+	if ctx.Authentication.UsernameExpression != "" {
+		cheEnv["CHE_OIDC_USERNAME__EXPRESSION"] = ctx.Authentication.UsernameExpression
+	}
 }
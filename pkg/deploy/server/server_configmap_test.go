@@ -549,3 +549,37 @@ func TestUpdateAdvancedAuthorizationEnv(t *testing.T) {
 		})
 	}
 }
+
+func TestGetConfigMapDataWithClaimMappings(t *testing.T) {
+	cheCluster := &chev2.CheCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "eclipse-che",
+			Namespace: "eclipse-che",
+		},
+		Spec: chev2.CheClusterSpec{
+			Networking: chev2.CheClusterSpecNetworking{
+				Auth: chev2.Auth{
+					ClaimMappings: &chev2.ClaimMappings{
+						Username: &chev2.ClaimMapping{Expression: "has(claims.upn) ? claims.upn : claims.email"},
+						Groups:   &chev2.ClaimMapping{Claim: "groups", Prefix: "oidc:"},
+					},
+				},
+			},
+		},
+	}
+
+	ctx := test.NewCtxBuilder().WithCheCluster(cheCluster).Build()
+	ctx.Authentication.UsernameExpression = "has(claims.upn) ? claims.upn : claims.email"
+	ctx.Authentication.GroupsClaim = "groups"
+	ctx.Authentication.GroupsPrefix = "oidc:"
+	serverReconciler := NewCheServerReconciler()
+
+	cheEnv, err := serverReconciler.getConfigMapData(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "has(claims.upn) ? claims.upn : claims.email", cheEnv["CHE_OIDC_USERNAME__EXPRESSION"])
+	assert.Equal(t, "", cheEnv["CHE_OIDC_USERNAME__CLAIM"])
+	assert.Equal(t, "groups", cheEnv["CHE_OIDC_GROUPS__CLAIM"])
+	assert.Equal(t, "oidc:", cheEnv["CHE_OIDC_GROUPS__PREFIX"])
+	assert.NotContains(t, cheEnv, "CHE_OIDC_GROUPS__EXPRESSION")
+}
@@ -147,6 +147,11 @@ func (s *CheServerReconciler) syncDeployment(ctx *chetypes.DeployContext) (bool,
 }
 
 func (s CheServerReconciler) syncCheVersion(ctx *chetypes.DeployContext) (bool, error) {
+	if ctx.CheCluster.IsPreviousCheVersionKept() {
+		// The previous version is still deployed
+		return true, nil
+	}
+
 	cheVersion := defaults.GetCheVersion()
 	if ctx.CheCluster.Status.CheVersion != cheVersion {
 		ctx.CheCluster.Status.CheVersion = cheVersion
@@ -49,6 +49,9 @@ const (
 	// The ConfigMap name for merged CA bundle certificates
 	CheMergedCABundleCertsCMName = "ca-certs-merged"
 	OIDCIssuerCACMName           = "oidc-issuer-ca"
+
+	// The ConfigMap key for merged CA bundle certificates
+	CheMergedCABundleCertsCMKey = kubernetesCABundleCertsFile
 )
 
 type CertificatesReconciler struct {
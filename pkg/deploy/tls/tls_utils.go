@@ -541,6 +541,16 @@ func GetAdditionalCACertsConfigMapVersion(ctx *chetypes.DeployContext) string {
 	return ""
 }
 
+// GetMergedCABundle returns the Che trusted CA certificates, see CertificatesReconciler.
+func GetMergedCABundle(ctx *chetypes.DeployContext) ([]byte, error) {
+	cm := &corev1.ConfigMap{}
+	exists, err := deploy.GetNamespacedObject(ctx, CheMergedCABundleCertsCMName, cm)
+	if err != nil || !exists {
+		return nil, err
+	}
+	return []byte(cm.Data[CheMergedCABundleCertsCMKey]), nil
+}
+
 // CreateTLSSecret creates TLS secret with given name.
 // Does nothing if secret with given name already exists.
 func CreateTLSSecret(ctx *chetypes.DeployContext, name string) (err error) {
@@ -0,0 +1,308 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/eclipse-che/che-operator/pkg/common/chetypes"
+	"github.com/eclipse-che/che-operator/pkg/common/constants"
+	defaults "github.com/eclipse-che/che-operator/pkg/common/operator-defaults"
+	"github.com/eclipse-che/che-operator/pkg/common/reconciler"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	defaultTimeoutSeconds = int32(1800)
+
+	// preflightCheckPeriod is the period to run the failed pre-flight checks again
+	preflightCheckPeriod = time.Minute
+	// rolloutCheckPeriod is the period to check the components being rolled out
+	rolloutCheckPeriod = 30 * time.Second
+	// maxHistory is the number of previous upgrades kept in the status
+	maxHistory = 10
+)
+
+var logger = ctrl.Log.WithName("upgrade")
+
+// UpgradeReconciler orchestrates the upgrades to a new Che version.
+// The pod templates of the previous version are kept before the pre-flight checks run,
+// and restored by the components reconcilers until the checks pass, see SyncDeploymentSpecToCluster.
+// Components are rolled out one by one by the following reconcilers, each waiting for its Deployment to be available.
+// If a component fails to become available, the pod templates of the previous version are restored.
+type UpgradeReconciler struct {
+	reconciler.Reconcilable
+}
+
+func NewUpgradeReconciler() *UpgradeReconciler {
+	return &UpgradeReconciler{}
+}
+
+func (r *UpgradeReconciler) Reconcile(ctx *chetypes.DeployContext) (reconcile.Result, bool, error) {
+	if ctx.CheCluster.IsCheBeingInstalled() {
+		return reconcile.Result{}, true, nil
+	}
+
+	if _, ok := ctx.CheCluster.GetAnnotations()[constants.CheEclipseOrgRetryUpgrade]; ok {
+		return r.retryUpgrade(ctx)
+	}
+
+	status := &chev2.UpgradeStatus{}
+	if ctx.CheCluster.Status.Upgrade != nil {
+		status = ctx.CheCluster.Status.Upgrade.DeepCopy()
+	}
+
+	toVersion := defaults.GetCheVersion()
+	if status.ToVersion != toVersion {
+		if ctx.CheCluster.Status.CheVersion == toVersion {
+			return reconcile.Result{}, true, nil
+		}
+		newUpgrade(status, ctx.CheCluster.Status.CheVersion, toVersion)
+	}
+
+	var result reconcile.Result
+	var err error
+	done := true
+
+	switch status.Phase {
+	case "", chev2.UpgradePhasePreflightCheckFailed:
+		result, done, err = r.startUpgrade(ctx, status)
+	case chev2.UpgradePhaseInProgress:
+		result, err = r.checkUpgrade(ctx, status)
+	case chev2.UpgradePhaseRollingBack:
+		result, err = r.checkRollback(ctx, status)
+	}
+
+	if err != nil {
+		return reconcile.Result{}, false, err
+	}
+
+	if err = updateUpgradeStatus(ctx, status); err != nil {
+		return reconcile.Result{}, false, err
+	}
+
+	return result, done, nil
+}
+
+func (r *UpgradeReconciler) Finalize(_ *chetypes.DeployContext) bool {
+	return true
+}
+
+// retryUpgrade starts a failed, rolled back or blocked upgrade again
+// and removes the `che.eclipse.org/retry-upgrade` annotation.
+// The reconciliation is requeued to continue with the patched CheCluster.
+func (r *UpgradeReconciler) retryUpgrade(ctx *chetypes.DeployContext) (reconcile.Result, bool, error) {
+	toVersion := defaults.GetCheVersion()
+	if ctx.CheCluster.Status.Upgrade != nil &&
+		isRetryable(ctx.CheCluster.Status.Upgrade.Phase) &&
+		ctx.CheCluster.Status.CheVersion != toVersion {
+
+		status := ctx.CheCluster.Status.Upgrade.DeepCopy()
+		newUpgrade(status, ctx.CheCluster.Status.CheVersion, toVersion)
+		if err := updateUpgradeStatus(ctx, status); err != nil {
+			return reconcile.Result{}, false, err
+		}
+	}
+
+	patch := client.MergeFrom(ctx.CheCluster.DeepCopy())
+	delete(ctx.CheCluster.Annotations, constants.CheEclipseOrgRetryUpgrade)
+	if err := ctx.ClusterAPI.Client.Patch(context.TODO(), ctx.CheCluster, patch); err != nil {
+		return reconcile.Result{}, false, err
+	}
+
+	return reconcile.Result{Requeue: true}, false, nil
+}
+
+// startUpgrade keeps the pod templates of the previous version and runs the pre-flight checks.
+// The previous version is kept deployed until the pre-flight checks pass,
+// the other reconcilers are not blocked meanwhile.
+func (r *UpgradeReconciler) startUpgrade(ctx *chetypes.DeployContext, status *chev2.UpgradeStatus) (reconcile.Result, bool, error) {
+	if status.Phase == "" {
+		if err := savePreviousPodTemplates(ctx); err != nil {
+			return reconcile.Result{}, false, err
+		}
+	}
+
+	if !getUpgradeConfig(ctx.CheCluster).SkipPreflightChecks {
+		failures, err := runPreflightChecks(ctx)
+		if err != nil {
+			return reconcile.Result{}, false, err
+		}
+
+		if len(failures) > 0 {
+			status.Phase = chev2.UpgradePhasePreflightCheckFailed
+			status.Message = "Pre-flight checks failed: " + strings.Join(failures, "; ")
+			return reconcile.Result{RequeueAfter: preflightCheckPeriod}, true, nil
+		}
+	}
+
+	logger.Info("Upgrading", "from", status.FromVersion, "to", status.ToVersion)
+
+	status.Phase = chev2.UpgradePhaseInProgress
+	status.StartTime = now()
+	status.Message = ""
+	return reconcile.Result{RequeueAfter: rolloutCheckPeriod}, true, nil
+}
+
+// checkUpgrade completes the upgrade when the new version is deployed and all components are available,
+// or fails it if a component is not available in time.
+func (r *UpgradeReconciler) checkUpgrade(ctx *chetypes.DeployContext, status *chev2.UpgradeStatus) (reconcile.Result, error) {
+	previousPodTemplates, err := getPreviousPodTemplates(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	currentPodTemplates, err := getComponentPodTemplates(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// Deployments which pod templates are not changed by the upgrade are not waited for
+	health, err := getComponentsHealth(ctx, func(deployment *appsv1.Deployment) bool {
+		return isUpgraded(deployment.Name, previousPodTemplates, currentPodTemplates)
+	})
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	upgradeConfig := getUpgradeConfig(ctx.CheCluster)
+	timeout := time.Duration(upgradeConfig.TimeoutSeconds) * time.Second
+
+	var failure string
+	if len(health.failed) > 0 {
+		failure = fmt.Sprintf("Deployments exceeded their progress deadline: %s", strings.Join(health.failed, ", "))
+	} else if status.StartTime != nil && time.Since(status.StartTime.Time) > timeout {
+		failure = fmt.Sprintf("Components are not rolled out after %s: %s", timeout, strings.Join(health.notReady, ", "))
+	}
+
+	if failure != "" {
+		if upgradeConfig.DisableRollback {
+			logger.Info("Upgrade failed", "reason", failure)
+
+			status.Phase = chev2.UpgradePhaseFailed
+			status.CompletionTime = now()
+			status.Message = failure
+			return reconcile.Result{}, deletePreviousPodTemplates(ctx)
+		}
+
+		logger.Info("Upgrade failed, rolling back", "reason", failure)
+
+		status.Phase = chev2.UpgradePhaseRollingBack
+		status.Message = failure
+		return reconcile.Result{RequeueAfter: rolloutCheckPeriod}, nil
+	}
+
+	if ctx.CheCluster.Status.CheVersion == status.ToVersion && len(health.notReady) == 0 {
+		logger.Info("Upgrade succeeded", "version", status.ToVersion)
+
+		status.Phase = chev2.UpgradePhaseSucceeded
+		status.CompletionTime = now()
+		status.Message = ""
+		return reconcile.Result{}, deletePreviousPodTemplates(ctx)
+	}
+
+	if len(health.notReady) > 0 {
+		status.Message = fmt.Sprintf("Waiting for components to be rolled out: %s", strings.Join(health.notReady, ", "))
+	}
+	return reconcile.Result{RequeueAfter: rolloutCheckPeriod}, nil
+}
+
+// checkRollback completes the rollback when all components are available with the pod templates of the previous version.
+// Pod templates are restored by the components reconcilers, see SyncDeploymentSpecToCluster.
+func (r *UpgradeReconciler) checkRollback(ctx *chetypes.DeployContext, status *chev2.UpgradeStatus) (reconcile.Result, error) {
+	previousPodTemplates, err := getPreviousPodTemplates(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	// Deployments added by the upgrade are not waited for
+	health, err := getComponentsHealth(ctx, func(deployment *appsv1.Deployment) bool {
+		_, ok := previousPodTemplates[deployment.Name]
+		return ok
+	})
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	currentPodTemplates, err := getComponentPodTemplates(ctx)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if len(health.notReady) == 0 && isRolledBack(previousPodTemplates, currentPodTemplates) {
+		logger.Info("Upgrade rolled back", "version", status.FromVersion)
+
+		status.Phase = chev2.UpgradePhaseRolledBack
+		status.CompletionTime = now()
+		return reconcile.Result{}, nil
+	}
+
+	return reconcile.Result{RequeueAfter: rolloutCheckPeriod}, nil
+}
+
+// newUpgrade moves the current upgrade into the history and resets the status for a new one.
+func newUpgrade(status *chev2.UpgradeStatus, fromVersion string, toVersion string) {
+	if status.ToVersion != "" {
+		status.History = append([]chev2.UpgradeRecord{status.UpgradeRecord}, status.History...)
+		if len(status.History) > maxHistory {
+			status.History = status.History[:maxHistory]
+		}
+	}
+
+	status.UpgradeRecord = chev2.UpgradeRecord{
+		FromVersion: fromVersion,
+		ToVersion:   toVersion,
+	}
+}
+
+// isRetryable returns true if the upgrade can be retried with the `che.eclipse.org/retry-upgrade` annotation.
+func isRetryable(phase string) bool {
+	return phase == chev2.UpgradePhaseFailed ||
+		phase == chev2.UpgradePhaseRolledBack ||
+		phase == chev2.UpgradePhasePreflightCheckFailed
+}
+
+func getUpgradeConfig(cheCluster *chev2.CheCluster) chev2.CheClusterUpgrade {
+	upgradeConfig := chev2.CheClusterUpgrade{}
+	if cheCluster.Spec.Upgrade != nil {
+		upgradeConfig = *cheCluster.Spec.Upgrade
+	}
+
+	if upgradeConfig.TimeoutSeconds <= 0 {
+		upgradeConfig.TimeoutSeconds = defaultTimeoutSeconds
+	}
+	return upgradeConfig
+}
+
+func updateUpgradeStatus(ctx *chetypes.DeployContext, status *chev2.UpgradeStatus) error {
+	if reflect.DeepEqual(ctx.CheCluster.Status.Upgrade, status) {
+		return nil
+	}
+
+	ctx.CheCluster.Status.Upgrade = status
+	return UpdateCheCRStatus(ctx, "upgrade", fmt.Sprintf("%s to %s", status.Phase, status.ToVersion))
+}
+
+// now returns the current time with a second precision, as it is kept in the status.
+func now() *metav1.Time {
+	return &metav1.Time{Time: time.Now().UTC().Truncate(time.Second)}
+}
@@ -0,0 +1,182 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package deploy
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/eclipse-che/che-operator/pkg/common/chetypes"
+	"github.com/eclipse-che/che-operator/pkg/common/constants"
+	"github.com/eclipse-che/che-operator/pkg/common/diffs"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	previousPodTemplatesConfigMapName = "che-upgrade-previous-pod-templates"
+	upgradeComponentName              = "upgrade"
+)
+
+// componentsHealth is the rollout state of the components Deployments.
+type componentsHealth struct {
+	// notReady are the Deployments being rolled out
+	notReady []string
+	// failed are the Deployments that exceeded their progress deadline
+	failed []string
+}
+
+// getComponentsHealth returns the rollout state of the components Deployments accepted by the filter.
+func getComponentsHealth(ctx *chetypes.DeployContext, filter func(deployment *appsv1.Deployment) bool) (*componentsHealth, error) {
+	deployments, err := listComponentDeployments(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	health := &componentsHealth{}
+	for _, deployment := range deployments {
+		if !filter(&deployment) {
+			continue
+		}
+
+		if isProgressDeadlineExceeded(&deployment) {
+			health.failed = append(health.failed, deployment.Name)
+		}
+		if !isRolledOut(&deployment) {
+			health.notReady = append(health.notReady, deployment.Name)
+		}
+	}
+
+	return health, nil
+}
+
+// getComponentPodTemplates returns the serialized pod templates of the components Deployments, by Deployment name.
+func getComponentPodTemplates(ctx *chetypes.DeployContext) (map[string]string, error) {
+	deployments, err := listComponentDeployments(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	podTemplates := make(map[string]string, len(deployments))
+	for _, deployment := range deployments {
+		data, err := json.Marshal(deployment.Spec.Template)
+		if err != nil {
+			return nil, err
+		}
+		podTemplates[deployment.Name] = string(data)
+	}
+
+	return podTemplates, nil
+}
+
+// savePreviousPodTemplates keeps the pod templates of the components before the upgrade,
+// to restore them while the pre-flight checks fail or when the upgrade is rolled back.
+func savePreviousPodTemplates(ctx *chetypes.DeployContext) error {
+	podTemplates, err := getComponentPodTemplates(ctx)
+	if err != nil {
+		return err
+	}
+
+	cm := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ConfigMap",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      previousPodTemplatesConfigMapName,
+			Namespace: ctx.CheCluster.Namespace,
+			Labels:    GetLabels(upgradeComponentName),
+		},
+		Data: podTemplates,
+	}
+
+	_, err = Sync(ctx, cm, diffs.ConfigMapEnsureLabels)
+	return err
+}
+
+// getPreviousPodTemplates returns the serialized pod templates of the components before the upgrade, by Deployment name.
+func getPreviousPodTemplates(ctx *chetypes.DeployContext) (map[string]string, error) {
+	cm := &corev1.ConfigMap{}
+	if exists, err := GetNamespacedObject(ctx, previousPodTemplatesConfigMapName, cm); !exists || err != nil {
+		return map[string]string{}, err
+	}
+	return cm.Data, nil
+}
+
+// deletePreviousPodTemplates removes the pod templates of the components before the upgrade,
+// once they can not be restored anymore.
+func deletePreviousPodTemplates(ctx *chetypes.DeployContext) error {
+	_, err := DeleteNamespacedObject(ctx, previousPodTemplatesConfigMapName, &corev1.ConfigMap{})
+	return err
+}
+
+// listComponentDeployments returns the Deployments managed by the operator in the Che namespace, sorted by name.
+func listComponentDeployments(ctx *chetypes.DeployContext) ([]appsv1.Deployment, error) {
+	deployments := &appsv1.DeploymentList{}
+	if err := ctx.ClusterAPI.Client.List(
+		context.TODO(),
+		deployments,
+		client.InNamespace(ctx.CheCluster.Namespace),
+		client.MatchingLabels{
+			constants.KubernetesPartOfLabelKey:    constants.CheEclipseOrg,
+			constants.KubernetesManagedByLabelKey: GetManagedByLabel(),
+		},
+	); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(deployments.Items, func(i, j int) bool {
+		return deployments.Items[i].Name < deployments.Items[j].Name
+	})
+	return deployments.Items, nil
+}
+
+// isRolledOut returns true if all replicas are updated and available.
+func isRolledOut(deployment *appsv1.Deployment) bool {
+	replicas := ptr.Deref(deployment.Spec.Replicas, 1)
+	return deployment.Status.ObservedGeneration >= deployment.Generation &&
+		deployment.Status.UpdatedReplicas == replicas &&
+		deployment.Status.AvailableReplicas == replicas &&
+		deployment.Status.UnavailableReplicas == 0
+}
+
+func isProgressDeadlineExceeded(deployment *appsv1.Deployment) bool {
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing &&
+			condition.Status == corev1.ConditionFalse &&
+			condition.Reason == "ProgressDeadlineExceeded" {
+			return true
+		}
+	}
+	return false
+}
+
+// isUpgraded returns true if the Deployment is added by the upgrade or its pod template is changed.
+func isUpgraded(name string, previousPodTemplates map[string]string, currentPodTemplates map[string]string) bool {
+	previous, ok := previousPodTemplates[name]
+	return !ok || previous != currentPodTemplates[name]
+}
+
+// isRolledBack returns true if all Deployments, which existed before the upgrade, have their previous pod template.
+func isRolledBack(previousPodTemplates map[string]string, currentPodTemplates map[string]string) bool {
+	for name, previous := range previousPodTemplates {
+		if current, ok := currentPodTemplates[name]; ok && current != previous {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,260 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/eclipse-che/che-operator/pkg/common/chetypes"
+	"github.com/eclipse-che/che-operator/pkg/common/constants"
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// minDevWorkspaceOperatorVersion is the oldest DevWorkspace Operator version the operator supports.
+// Bump it along with the DevWorkspace Operator dependency when the operator relies on the new features.
+const minDevWorkspaceOperatorVersion = "0.42.0"
+
+// requiredAPIs are the APIs the components of the new version rely on
+var requiredAPIs = []struct {
+	groupVersion string
+	resources    []string
+}{
+	{
+		groupVersion: chev2.GroupVersion.String(),
+		resources:    []string{"checlusters"},
+	},
+	{
+		groupVersion: "workspace.devfile.io/v1alpha2",
+		resources:    []string{"devworkspaces", "devworkspacetemplates"},
+	},
+	{
+		groupVersion: "controller.devfile.io/v1alpha1",
+		resources:    []string{"devworkspaceoperatorconfigs", "devworkspaceroutings"},
+	},
+}
+
+// runPreflightChecks returns the failed checks.
+func runPreflightChecks(ctx *chetypes.DeployContext) ([]string, error) {
+	var failures []string
+
+	for _, check := range []func(ctx *chetypes.DeployContext) ([]string, error){
+		checkDevWorkspaceOperatorVersion,
+		checkServedAPIs,
+		checkResourceQuotas,
+	} {
+		checkFailures, err := check(ctx)
+		if err != nil {
+			return nil, err
+		}
+		failures = append(failures, checkFailures...)
+	}
+
+	return failures, nil
+}
+
+// checkDevWorkspaceOperatorVersion checks that the DevWorkspace Operator is not older
+// than the minimum supported version.
+// The check is skipped if the version of the DevWorkspace Operator is unknown.
+func checkDevWorkspaceOperatorVersion(ctx *chetypes.DeployContext) ([]string, error) {
+	minVersion := semver.MustParse(minDevWorkspaceOperatorVersion)
+
+	version, ok, err := getDevWorkspaceOperatorVersion(ctx)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	if version.LT(minVersion) {
+		return []string{
+			fmt.Sprintf("DevWorkspace Operator %s is not supported, upgrade it to %s or later", version, minVersion),
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// getDevWorkspaceOperatorVersion returns the version of the DevWorkspace Operator, read from
+// the `app.kubernetes.io/version` label of the controller Deployment,
+// or from the ClusterServiceVersion owning the controller Deployment when installed by OLM.
+func getDevWorkspaceOperatorVersion(ctx *chetypes.DeployContext) (semver.Version, bool, error) {
+	deployments := &appsv1.DeploymentList{}
+	if err := ctx.ClusterAPI.NonCachingClient.List(
+		context.TODO(),
+		deployments,
+		client.InNamespace(ctx.DWONamespace),
+		client.MatchingLabels{
+			constants.KubernetesNameLabelKey:   constants.DevWorkspaceControllerName,
+			constants.KubernetesPartOfLabelKey: constants.DevWorkspaceOperatorName,
+		},
+	); err != nil {
+		return semver.Version{}, false, err
+	}
+
+	for _, deployment := range deployments.Items {
+		labels := deployment.GetLabels()
+
+		if version, err := semver.ParseTolerant(labels[constants.KubernetesVersionLabelKey]); err == nil {
+			return version, true, nil
+		}
+
+		if labels[constants.OLMOwnerKindLabelKey] == operatorsv1alpha1.ClusterServiceVersionKind {
+			csv := &operatorsv1alpha1.ClusterServiceVersion{}
+			if err := ctx.ClusterAPI.NonCachingClient.Get(
+				context.TODO(),
+				types.NamespacedName{Name: labels[constants.OLMOwnerLabelKey], Namespace: labels[constants.OLMOwnerNamespaceLabelKey]},
+				csv,
+			); err != nil {
+				if errors.IsNotFound(err) {
+					continue
+				}
+				return semver.Version{}, false, err
+			}
+
+			if !csv.Spec.Version.Equals(semver.Version{}) {
+				return csv.Spec.Version.Version, true, nil
+			}
+		}
+	}
+
+	return semver.Version{}, false, nil
+}
+
+// checkServedAPIs checks that the required API versions are served, meaning CRDs are up to date.
+func checkServedAPIs(ctx *chetypes.DeployContext) ([]string, error) {
+	var failures []string
+
+	for _, api := range requiredAPIs {
+		resources, err := ctx.ClusterAPI.DiscoveryClient.ServerResourcesForGroupVersion(api.groupVersion)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				failures = append(failures, fmt.Sprintf("API %s is not served", api.groupVersion))
+				continue
+			}
+			return nil, err
+		}
+
+		served := map[string]bool{}
+		for _, resource := range resources.APIResources {
+			served[resource.Name] = true
+		}
+
+		for _, resource := range api.resources {
+			if !served[resource] {
+				failures = append(failures, fmt.Sprintf("resource %s is not served in API %s", resource, api.groupVersion))
+			}
+		}
+	}
+
+	return failures, nil
+}
+
+// checkResourceQuotas checks that the resource quotas of the Che namespace allow the rolling update
+// of the components, meaning one more pod of each Deployment.
+// Quotas restricted by scopes are not checked.
+func checkResourceQuotas(ctx *chetypes.DeployContext) ([]string, error) {
+	quotas := &corev1.ResourceQuotaList{}
+	// Resource quotas are not necessarily labeled to be cached
+	if err := ctx.ClusterAPI.NonCachingClient.List(
+		context.TODO(),
+		quotas,
+		client.InNamespace(ctx.CheCluster.Namespace),
+	); err != nil {
+		return nil, err
+	}
+
+	if len(quotas.Items) == 0 {
+		return nil, nil
+	}
+
+	deployments, err := listComponentDeployments(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	required := getRollingUpdateResources(deployments)
+
+	var failures []string
+	for _, quota := range quotas.Items {
+		if len(quota.Spec.Scopes) > 0 || quota.Spec.ScopeSelector != nil {
+			continue
+		}
+
+		hard := quota.Status.Hard
+		if len(hard) == 0 {
+			hard = quota.Spec.Hard
+		}
+
+		var exceeded []string
+		for name, hardQuantity := range hard {
+			requiredQuantity, ok := required[name]
+			if !ok {
+				continue
+			}
+
+			free := hardQuantity.DeepCopy()
+			if used, ok := quota.Status.Used[name]; ok {
+				free.Sub(used)
+			}
+
+			if free.Cmp(requiredQuantity) < 0 {
+				exceeded = append(exceeded, fmt.Sprintf("%s (required %s, free %s)", name, requiredQuantity.String(), free.String()))
+			}
+		}
+
+		if len(exceeded) > 0 {
+			sort.Strings(exceeded)
+			failures = append(failures, fmt.Sprintf("resource quota %s is exceeded: %s", quota.Name, strings.Join(exceeded, ", ")))
+		}
+	}
+
+	return failures, nil
+}
+
+// getRollingUpdateResources returns the resources of the additional pods created during the rolling update.
+func getRollingUpdateResources(deployments []appsv1.Deployment) corev1.ResourceList {
+	required := corev1.ResourceList{}
+	add := func(name corev1.ResourceName, quantity resource.Quantity) {
+		total := required[name]
+		total.Add(quantity)
+		required[name] = total
+	}
+
+	for _, deployment := range deployments {
+		if deployment.Spec.Strategy.Type == appsv1.RecreateDeploymentStrategyType {
+			continue
+		}
+
+		add(corev1.ResourcePods, resource.MustParse("1"))
+		for _, container := range deployment.Spec.Template.Spec.Containers {
+			for name, quantity := range container.Resources.Requests {
+				add(name, quantity)
+				add(corev1.ResourceName("requests."+string(name)), quantity)
+			}
+			for name, quantity := range container.Resources.Limits {
+				add(corev1.ResourceName("limits."+string(name)), quantity)
+			}
+		}
+	}
+
+	return required
+}
@@ -0,0 +1,546 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package deploy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/blang/semver/v4"
+	chev2 "github.com/eclipse-che/che-operator/api/v2"
+	"github.com/eclipse-che/che-operator/pkg/common/chetypes"
+	"github.com/eclipse-che/che-operator/pkg/common/constants"
+	defaults "github.com/eclipse-che/che-operator/pkg/common/operator-defaults"
+	"github.com/eclipse-che/che-operator/pkg/common/test"
+	"github.com/operator-framework/api/pkg/lib/version"
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	fakeDiscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestUpgradeRolledBack(t *testing.T) {
+	ctx := test.NewCtxBuilder().WithCheCluster(getCheCluster()).WithObjects(
+		getDeployment("dashboard:1.0.0"),
+		getDevWorkspaceControllerDeployment(map[string]string{
+			constants.OLMOwnerLabelKey:          "devworkspace-operator.v0.30.0",
+			constants.OLMOwnerKindLabelKey:      operatorsv1alpha1.ClusterServiceVersionKind,
+			constants.OLMOwnerNamespaceLabelKey: "devworkspace-controller",
+		}),
+		getDevWorkspaceOperatorCSV("0.30.0"),
+	).Build()
+
+	reconciler := NewUpgradeReconciler()
+
+	// Pre-flight checks fail, the previous version is kept
+	_, done, err := reconciler.Reconcile(ctx)
+	assert.NoError(t, err)
+	assert.True(t, done)
+
+	status := ctx.CheCluster.Status.Upgrade
+	assert.Equal(t, chev2.UpgradePhasePreflightCheckFailed, status.Phase)
+	assert.Equal(t, "1.0.0", status.FromVersion)
+	assert.Equal(t, defaults.GetCheVersion(), status.ToVersion)
+	assert.Contains(t, status.Message, "DevWorkspace Operator 0.30.0 is not supported")
+	assert.Contains(t, status.Message, "API workspace.devfile.io/v1alpha2 is not served")
+	assert.Contains(t, getPreviousPodTemplatesData(t, ctx), "che-dashboard")
+
+	deployment := getDeployment("dashboard:2.0.0")
+	_, err = SyncDeploymentSpecToCluster(ctx, deployment, DefaultDeploymentDiffOpts)
+	assert.NoError(t, err)
+	assert.Equal(t, "dashboard:1.0.0", getDeploymentImage(t, ctx))
+
+	// Fix pre-flight checks
+	csv := &operatorsv1alpha1.ClusterServiceVersion{}
+	assert.NoError(t, ctx.ClusterAPI.Client.Get(context.TODO(), types.NamespacedName{Name: "devworkspace-operator.v0.30.0", Namespace: "devworkspace-controller"}, csv))
+	csv.Spec.Version = version.OperatorVersion{Version: semver.MustParse("99.0.0")}
+	assert.NoError(t, ctx.ClusterAPI.Client.Update(context.TODO(), csv))
+	serveRequiredAPIs(ctx)
+
+	test.EnsureReconcile(t, ctx, reconciler.Reconcile)
+
+	status = ctx.CheCluster.Status.Upgrade
+	assert.Equal(t, chev2.UpgradePhaseInProgress, status.Phase)
+	assert.NotNil(t, status.StartTime)
+	assert.Empty(t, status.Message)
+	assert.Contains(t, getPreviousPodTemplatesData(t, ctx), "che-dashboard")
+
+	// The new version is rolled out
+	deployment = getDeployment("dashboard:2.0.0")
+	_, err = SyncDeploymentSpecToCluster(ctx, deployment, DefaultDeploymentDiffOpts)
+	assert.NoError(t, err)
+	setDeploymentStatus(t, ctx, false, "ProgressDeadlineExceeded")
+
+	test.EnsureReconcile(t, ctx, reconciler.Reconcile)
+
+	status = ctx.CheCluster.Status.Upgrade
+	assert.Equal(t, chev2.UpgradePhaseRollingBack, status.Phase)
+	assert.Contains(t, status.Message, "che-dashboard")
+
+	// The previous version is rolled out
+	deployment = getDeployment("dashboard:2.0.0")
+	_, err = SyncDeploymentSpecToCluster(ctx, deployment, DefaultDeploymentDiffOpts)
+	assert.NoError(t, err)
+	assert.Equal(t, "dashboard:1.0.0", getDeploymentImage(t, ctx))
+
+	test.EnsureReconcile(t, ctx, reconciler.Reconcile)
+	assert.Equal(t, chev2.UpgradePhaseRollingBack, ctx.CheCluster.Status.Upgrade.Phase)
+
+	setDeploymentStatus(t, ctx, true, "NewReplicaSetAvailable")
+	test.EnsureReconcile(t, ctx, reconciler.Reconcile)
+
+	status = ctx.CheCluster.Status.Upgrade
+	assert.Equal(t, chev2.UpgradePhaseRolledBack, status.Phase)
+	assert.NotNil(t, status.CompletionTime)
+	assert.True(t, ctx.CheCluster.IsUpgradeRolledBack())
+
+	// The previous version is kept
+	test.EnsureReconcile(t, ctx, reconciler.Reconcile)
+	assert.Equal(t, chev2.UpgradePhaseRolledBack, ctx.CheCluster.Status.Upgrade.Phase)
+
+	// Retry the upgrade
+	ctx.CheCluster.Annotations = map[string]string{constants.CheEclipseOrgRetryUpgrade: "true"}
+	assert.NoError(t, ctx.ClusterAPI.Client.Update(context.TODO(), ctx.CheCluster))
+
+	test.EnsureReconcile(t, ctx, reconciler.Reconcile)
+
+	status = ctx.CheCluster.Status.Upgrade
+	assert.NotContains(t, ctx.CheCluster.Annotations, constants.CheEclipseOrgRetryUpgrade)
+	assert.Equal(t, chev2.UpgradePhaseInProgress, status.Phase)
+	assert.Len(t, status.History, 1)
+	assert.Equal(t, chev2.UpgradePhaseRolledBack, status.History[0].Phase)
+	assert.False(t, ctx.CheCluster.IsPreviousCheVersionKept())
+
+	deployment = getDeployment("dashboard:2.0.0")
+	_, err = SyncDeploymentSpecToCluster(ctx, deployment, DefaultDeploymentDiffOpts)
+	assert.NoError(t, err)
+	assert.Equal(t, "dashboard:2.0.0", getDeploymentImage(t, ctx))
+}
+
+func TestUpgradeSucceeded(t *testing.T) {
+	ctx := test.NewCtxBuilder().WithCheCluster(getCheCluster()).WithObjects(getDeployment("dashboard:1.0.0")).Build()
+	serveRequiredAPIs(ctx)
+
+	reconciler := NewUpgradeReconciler()
+	test.EnsureReconcile(t, ctx, reconciler.Reconcile)
+	assert.Equal(t, chev2.UpgradePhaseInProgress, ctx.CheCluster.Status.Upgrade.Phase)
+
+	// The new version is deployed, but not rolled out yet
+	_, err := SyncDeploymentSpecToCluster(ctx, getDeployment("dashboard:2.0.0"), DefaultDeploymentDiffOpts)
+	assert.NoError(t, err)
+	ctx.CheCluster.Status.CheVersion = defaults.GetCheVersion()
+	assert.NoError(t, ctx.ClusterAPI.Client.Status().Update(context.TODO(), ctx.CheCluster))
+	setDeploymentStatus(t, ctx, false, "ReplicaSetUpdated")
+
+	test.EnsureReconcile(t, ctx, reconciler.Reconcile)
+	assert.Equal(t, chev2.UpgradePhaseInProgress, ctx.CheCluster.Status.Upgrade.Phase)
+	assert.Contains(t, ctx.CheCluster.Status.Upgrade.Message, "che-dashboard")
+
+	setDeploymentStatus(t, ctx, true, "NewReplicaSetAvailable")
+	test.EnsureReconcile(t, ctx, reconciler.Reconcile)
+
+	status := ctx.CheCluster.Status.Upgrade
+	assert.Equal(t, chev2.UpgradePhaseSucceeded, status.Phase)
+	assert.NotNil(t, status.CompletionTime)
+	assert.Empty(t, status.Message)
+	assert.Nil(t, getPreviousPodTemplatesData(t, ctx))
+}
+
+func TestUpgradeFailedWithoutRollback(t *testing.T) {
+	cheCluster := getCheCluster()
+	cheCluster.Spec.Upgrade = &chev2.CheClusterUpgrade{
+		SkipPreflightChecks: true,
+		DisableRollback:     true,
+		TimeoutSeconds:      60,
+	}
+
+	ctx := test.NewCtxBuilder().WithCheCluster(cheCluster).WithObjects(getDeployment("dashboard:1.0.0")).Build()
+
+	reconciler := NewUpgradeReconciler()
+	test.EnsureReconcile(t, ctx, reconciler.Reconcile)
+	assert.Equal(t, chev2.UpgradePhaseInProgress, ctx.CheCluster.Status.Upgrade.Phase)
+
+	// Components are not rolled out in time
+	_, err := SyncDeploymentSpecToCluster(ctx, getDeployment("dashboard:2.0.0"), DefaultDeploymentDiffOpts)
+	assert.NoError(t, err)
+	setDeploymentStatus(t, ctx, false, "ReplicaSetUpdated")
+	ctx.CheCluster.Status.Upgrade.StartTime = &metav1.Time{Time: time.Now().Add(-2 * time.Minute)}
+	assert.NoError(t, ctx.ClusterAPI.Client.Status().Update(context.TODO(), ctx.CheCluster))
+
+	test.EnsureReconcile(t, ctx, reconciler.Reconcile)
+
+	status := ctx.CheCluster.Status.Upgrade
+	assert.Equal(t, chev2.UpgradePhaseFailed, status.Phase)
+	assert.Contains(t, status.Message, "Components are not rolled out after 1m0s: che-dashboard")
+	assert.Nil(t, getPreviousPodTemplatesData(t, ctx))
+	assert.False(t, ctx.CheCluster.IsUpgradeRolledBack())
+}
+
+func TestUpgradeIgnoresUnchangedDeployments(t *testing.T) {
+	cheCluster := getCheCluster()
+	cheCluster.Spec.Upgrade = &chev2.CheClusterUpgrade{SkipPreflightChecks: true}
+
+	unchanged := getDeployment("plugin-registry:1.0.0")
+	unchanged.Name = "plugin-registry"
+	unchanged.Status = appsv1.DeploymentStatus{
+		Conditions: []appsv1.DeploymentCondition{
+			{
+				Type:   appsv1.DeploymentProgressing,
+				Status: corev1.ConditionFalse,
+				Reason: "ProgressDeadlineExceeded",
+			},
+		},
+	}
+
+	ctx := test.NewCtxBuilder().WithCheCluster(cheCluster).WithObjects(getDeployment("dashboard:1.0.0"), unchanged).Build()
+
+	reconciler := NewUpgradeReconciler()
+	test.EnsureReconcile(t, ctx, reconciler.Reconcile)
+	assert.Equal(t, chev2.UpgradePhaseInProgress, ctx.CheCluster.Status.Upgrade.Phase)
+
+	_, err := SyncDeploymentSpecToCluster(ctx, getDeployment("dashboard:2.0.0"), DefaultDeploymentDiffOpts)
+	assert.NoError(t, err)
+	ctx.CheCluster.Status.CheVersion = defaults.GetCheVersion()
+	assert.NoError(t, ctx.ClusterAPI.Client.Status().Update(context.TODO(), ctx.CheCluster))
+	setDeploymentStatus(t, ctx, true, "NewReplicaSetAvailable")
+
+	test.EnsureReconcile(t, ctx, reconciler.Reconcile)
+	assert.Equal(t, chev2.UpgradePhaseSucceeded, ctx.CheCluster.Status.Upgrade.Phase)
+}
+
+func TestUpgradeNotStartedOnInstallation(t *testing.T) {
+	cheCluster := getCheCluster()
+	cheCluster.Status.CheVersion = ""
+
+	ctx := test.NewCtxBuilder().WithCheCluster(cheCluster).Build()
+
+	test.EnsureReconcile(t, ctx, NewUpgradeReconciler().Reconcile)
+	assert.Nil(t, ctx.CheCluster.Status.Upgrade)
+}
+
+func TestCheckResourceQuotas(t *testing.T) {
+	deployment := getDeployment("dashboard:1.0.0")
+	deployment.Spec.Template.Spec.Containers[0].Resources = corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("256Mi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("512Mi"),
+		},
+	}
+
+	ctx := test.NewCtxBuilder().WithCheCluster(getCheCluster()).WithObjects(
+		deployment,
+		&corev1.ResourceQuota{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "quota",
+				Namespace: "eclipse-che",
+			},
+			Status: corev1.ResourceQuotaStatus{
+				Hard: corev1.ResourceList{
+					corev1.ResourceRequestsMemory: resource.MustParse("1Gi"),
+					corev1.ResourceLimitsMemory:   resource.MustParse("1Gi"),
+					corev1.ResourcePods:           resource.MustParse("10"),
+				},
+				Used: corev1.ResourceList{
+					corev1.ResourceRequestsMemory: resource.MustParse("256Mi"),
+					corev1.ResourceLimitsMemory:   resource.MustParse("768Mi"),
+					corev1.ResourcePods:           resource.MustParse("1"),
+				},
+			},
+		},
+	).Build()
+
+	failures, err := checkResourceQuotas(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"resource quota quota is exceeded: limits.memory (required 512Mi, free 256Mi)"}, failures)
+}
+
+func TestRollbackRestoresPodTemplate(t *testing.T) {
+	cheCluster := getCheCluster()
+	cheCluster.Spec.Upgrade = &chev2.CheClusterUpgrade{SkipPreflightChecks: true}
+
+	ctx := test.NewCtxBuilder().WithCheCluster(cheCluster).WithObjects(getDeployment("dashboard:1.0.0")).Build()
+
+	reconciler := NewUpgradeReconciler()
+	test.EnsureReconcile(t, ctx, reconciler.Reconcile)
+
+	_, err := SyncDeploymentSpecToCluster(ctx, getDeployment("dashboard:2.0.0"), DefaultDeploymentDiffOpts)
+	assert.NoError(t, err)
+	setDeploymentStatus(t, ctx, false, "ProgressDeadlineExceeded")
+
+	test.EnsureReconcile(t, ctx, reconciler.Reconcile)
+	assert.Equal(t, chev2.UpgradePhaseRollingBack, ctx.CheCluster.Status.Upgrade.Phase)
+
+	// The whole pod template of the previous version is restored
+	deployment := getUpgradedDeployment("dashboard:2.0.0")
+	deployment.Spec.Template.Spec.Containers = append(deployment.Spec.Template.Spec.Containers, corev1.Container{Name: "sidecar", Image: "sidecar:2.0.0"})
+	_, err = SyncDeploymentSpecToCluster(ctx, deployment, DefaultDeploymentDiffOpts)
+	assert.NoError(t, err)
+
+	actual := &appsv1.Deployment{}
+	exists, err := GetNamespacedObject(ctx, "che-dashboard", actual)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, getDeployment("dashboard:1.0.0").Spec.Template, actual.Spec.Template)
+
+	// A Deployment added in the new version keeps its pod template
+	added := getUpgradedDeployment("registry:2.0.0")
+	added.Name = "registry"
+	_, err = SyncDeploymentSpecToCluster(ctx, added, DefaultDeploymentDiffOpts)
+	assert.NoError(t, err)
+
+	exists, err = GetNamespacedObject(ctx, "registry", actual)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+	assert.Equal(t, added.Spec.Template, actual.Spec.Template)
+
+	setDeploymentStatus(t, ctx, true, "NewReplicaSetAvailable")
+	test.EnsureReconcile(t, ctx, reconciler.Reconcile)
+	assert.Equal(t, chev2.UpgradePhaseRolledBack, ctx.CheCluster.Status.Upgrade.Phase)
+}
+
+func TestGetDevWorkspaceOperatorVersion(t *testing.T) {
+	type testCase struct {
+		name     string
+		objects  []client.Object
+		version  string
+		hasValue bool
+	}
+
+	testCases := []testCase{
+		{
+			name: "version label",
+			objects: []client.Object{
+				getDevWorkspaceControllerDeployment(map[string]string{constants.KubernetesVersionLabelKey: "v0.42.1"}),
+			},
+			version:  "0.42.1",
+			hasValue: true,
+		},
+		{
+			name: "installed by OLM",
+			objects: []client.Object{
+				getDevWorkspaceControllerDeployment(map[string]string{
+					constants.OLMOwnerLabelKey:          "devworkspace-operator.v0.30.0",
+					constants.OLMOwnerKindLabelKey:      operatorsv1alpha1.ClusterServiceVersionKind,
+					constants.OLMOwnerNamespaceLabelKey: "devworkspace-controller",
+				}),
+				getDevWorkspaceOperatorCSV("0.30.0"),
+			},
+			version:  "0.30.0",
+			hasValue: true,
+		},
+		{
+			name: "ClusterServiceVersion not found",
+			objects: []client.Object{
+				getDevWorkspaceControllerDeployment(map[string]string{
+					constants.OLMOwnerLabelKey:          "devworkspace-operator.v0.30.0",
+					constants.OLMOwnerKindLabelKey:      operatorsv1alpha1.ClusterServiceVersionKind,
+					constants.OLMOwnerNamespaceLabelKey: "devworkspace-controller",
+				}),
+			},
+			hasValue: false,
+		},
+		{
+			name:     "no version",
+			objects:  []client.Object{getDevWorkspaceControllerDeployment(map[string]string{})},
+			hasValue: false,
+		},
+		{
+			name:     "not installed",
+			hasValue: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctx := test.NewCtxBuilder().WithCheCluster(getCheCluster()).WithObjects(testCase.objects...).Build()
+
+			actual, ok, err := getDevWorkspaceOperatorVersion(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.hasValue, ok)
+			if testCase.hasValue {
+				assert.Equal(t, testCase.version, actual.String())
+			}
+		})
+	}
+}
+
+func TestCheckDevWorkspaceOperatorVersion(t *testing.T) {
+	type testCase struct {
+		name     string
+		version  string
+		failures int
+	}
+
+	testCases := []testCase{
+		{name: "Minimum version", version: minDevWorkspaceOperatorVersion, failures: 0},
+		{name: "Newer version", version: "99.0.0", failures: 0},
+		{name: "Older version", version: "0.30.0", failures: 1},
+		{name: "Unknown version", failures: 0},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			labels := map[string]string{}
+			if testCase.version != "" {
+				labels[constants.KubernetesVersionLabelKey] = testCase.version
+			}
+
+			ctx := test.NewCtxBuilder().WithCheCluster(getCheCluster()).WithObjects(getDevWorkspaceControllerDeployment(labels)).Build()
+
+			failures, err := checkDevWorkspaceOperatorVersion(ctx)
+			assert.NoError(t, err)
+			assert.Len(t, failures, testCase.failures)
+		})
+	}
+}
+
+func getCheCluster() *chev2.CheCluster {
+	return &chev2.CheCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "eclipse-che",
+			Namespace: "eclipse-che",
+		},
+		Status: chev2.CheClusterStatus{
+			CheVersion: "1.0.0",
+		},
+	}
+}
+
+func getDeployment(image string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Deployment",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "che-dashboard",
+			Namespace: "eclipse-che",
+			Labels:    GetLabels(defaults.GetCheFlavor() + "-dashboard"),
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To(int32(1)),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  "che-dashboard",
+							Image: image,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func getDevWorkspaceControllerDeployment(labels map[string]string) *appsv1.Deployment {
+	labels[constants.KubernetesNameLabelKey] = constants.DevWorkspaceControllerName
+	labels[constants.KubernetesPartOfLabelKey] = constants.DevWorkspaceOperatorName
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "devworkspace-controller-manager",
+			Namespace: "devworkspace-controller",
+			Labels:    labels,
+		},
+	}
+}
+
+func getDevWorkspaceOperatorCSV(operatorVersion string) *operatorsv1alpha1.ClusterServiceVersion {
+	return &operatorsv1alpha1.ClusterServiceVersion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "devworkspace-operator.v" + operatorVersion,
+			Namespace: "devworkspace-controller",
+		},
+		Spec: operatorsv1alpha1.ClusterServiceVersionSpec{
+			Version: version.OperatorVersion{Version: semver.MustParse(operatorVersion)},
+		},
+	}
+}
+
+func getUpgradedDeployment(image string) *appsv1.Deployment {
+	deployment := getDeployment(image)
+	deployment.Spec.Template.Spec.Containers[0].Env = []corev1.EnvVar{{Name: "NEW", Value: "true"}}
+	return deployment
+}
+
+func getDeploymentImage(t *testing.T, ctx *chetypes.DeployContext) string {
+	deployment := &appsv1.Deployment{}
+	exists, err := GetNamespacedObject(ctx, "che-dashboard", deployment)
+	assert.True(t, exists)
+	assert.NoError(t, err)
+	return deployment.Spec.Template.Spec.Containers[0].Image
+}
+
+func setDeploymentStatus(t *testing.T, ctx *chetypes.DeployContext, available bool, reason string) {
+	deployment := &appsv1.Deployment{}
+	exists, err := GetNamespacedObject(ctx, "che-dashboard", deployment)
+	assert.True(t, exists)
+	assert.NoError(t, err)
+
+	progressing := corev1.ConditionTrue
+	if reason == "ProgressDeadlineExceeded" {
+		progressing = corev1.ConditionFalse
+	}
+
+	deployment.Status = appsv1.DeploymentStatus{
+		ObservedGeneration: deployment.Generation,
+		Replicas:           1,
+		UpdatedReplicas:    1,
+		Conditions: []appsv1.DeploymentCondition{
+			{
+				Type:   appsv1.DeploymentProgressing,
+				Status: progressing,
+				Reason: reason,
+			},
+		},
+	}
+	if available {
+		deployment.Status.AvailableReplicas = 1
+		deployment.Status.ReadyReplicas = 1
+	} else {
+		deployment.Status.UnavailableReplicas = 1
+	}
+
+	assert.NoError(t, ctx.ClusterAPI.Client.Status().Update(context.TODO(), deployment))
+}
+
+func getPreviousPodTemplatesData(t *testing.T, ctx *chetypes.DeployContext) map[string]string {
+	cm := &corev1.ConfigMap{}
+	exists, err := GetNamespacedObject(ctx, previousPodTemplatesConfigMapName, cm)
+	assert.NoError(t, err)
+	if !exists {
+		return nil
+	}
+	return cm.Data
+}
+
+func serveRequiredAPIs(ctx *chetypes.DeployContext) {
+	discoveryClient := ctx.ClusterAPI.DiscoveryClient.(*fakeDiscovery.FakeDiscovery)
+	for _, api := range requiredAPIs {
+		resourceList := &metav1.APIResourceList{GroupVersion: api.groupVersion}
+		for _, resource := range api.resources {
+			resourceList.APIResources = append(resourceList.APIResources, metav1.APIResource{Name: resource})
+		}
+		discoveryClient.Resources = append(discoveryClient.Resources, resourceList)
+	}
+}
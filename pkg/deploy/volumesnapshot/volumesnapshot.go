@@ -0,0 +1,80 @@
+//
+// Copyright (c) 2019-2026 Red Hat, Inc.
+// This program and the accompanying materials are made
+// available under the terms of the Eclipse Public License 2.0
+// which is available at https://www.eclipse.org/legal/epl-2.0/
+//
+// SPDX-License-Identifier: EPL-2.0
+//
+// Contributors:
+//   Red Hat, Inc. - initial API and implementation
+//
+
+package volumesnapshot
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// VolumeSnapshot API is not a part of the core Kubernetes API,
+// so snapshots are handled as unstructured objects to avoid a dependency on the CSI external snapshotter.
+var (
+	GroupVersionKind     = schema.GroupVersionKind{Group: "snapshot.storage.k8s.io", Version: "v1", Kind: "VolumeSnapshot"}
+	ListGroupVersionKind = schema.GroupVersionKind{Group: "snapshot.storage.k8s.io", Version: "v1", Kind: "VolumeSnapshotList"}
+)
+
+// New returns a VolumeSnapshot of the given PVC.
+func New(name string, namespace string, pvcName string, volumeSnapshotClassName string, labels map[string]string) *unstructured.Unstructured {
+	snapshot := &unstructured.Unstructured{}
+	snapshot.SetGroupVersionKind(GroupVersionKind)
+	snapshot.SetName(name)
+	snapshot.SetNamespace(namespace)
+	snapshot.SetLabels(labels)
+	snapshot.Object["spec"] = map[string]interface{}{
+		"volumeSnapshotClassName": volumeSnapshotClassName,
+		"source": map[string]interface{}{
+			"persistentVolumeClaimName": pvcName,
+		},
+	}
+
+	return snapshot
+}
+
+// NewEmpty returns an empty VolumeSnapshot to read the object into.
+func NewEmpty() *unstructured.Unstructured {
+	snapshot := &unstructured.Unstructured{}
+	snapshot.SetGroupVersionKind(GroupVersionKind)
+	return snapshot
+}
+
+// NewList returns an empty VolumeSnapshot list.
+func NewList() *unstructured.UnstructuredList {
+	snapshots := &unstructured.UnstructuredList{}
+	snapshots.SetGroupVersionKind(ListGroupVersionKind)
+	return snapshots
+}
+
+// GetSourcePVCName returns the name of the snapshotted PVC.
+func GetSourcePVCName(snapshot *unstructured.Unstructured) string {
+	pvcName, _, _ := unstructured.NestedString(snapshot.Object, "spec", "source", "persistentVolumeClaimName")
+	return pvcName
+}
+
+// IsReadyToUse returns true if the snapshot can be used to provision a PVC.
+func IsReadyToUse(snapshot *unstructured.Unstructured) bool {
+	ready, _, _ := unstructured.NestedBool(snapshot.Object, "status", "readyToUse")
+	return ready
+}
+
+// GetRestoreSize returns the minimum size of a PVC provisioned from the snapshot.
+func GetRestoreSize(snapshot *unstructured.Unstructured) (resource.Quantity, bool) {
+	restoreSize, found, _ := unstructured.NestedString(snapshot.Object, "status", "restoreSize")
+	if !found {
+		return resource.Quantity{}, false
+	}
+
+	quantity, err := resource.ParseQuantity(restoreSize)
+	return quantity, err == nil
+}